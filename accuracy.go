@@ -0,0 +1,66 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Accuracy handling
+
+// metersPerArcSecond is the length of one second of arc on the mean Earth sphere.
+const metersPerArcSecond = earthRadius * degToRad / 3600
+
+// StringWithAccuracy returns the LTR representation followed by the accuracy
+// as an angle, e.g. `40°26'46.30" N 79°56'55.90" W ±0°0'0.32"`.
+func (c *Coordinate) StringWithAccuracy() string {
+	opts := DefaultFormatOptions()
+	opts.ShowAccuracy = true
+	return c.Format(opts)
+}
+
+// maxAutoPrecision caps the decimals chosen by PrecisionForAccuracy.
+const maxAutoPrecision = 9
+
+// PrecisionForAccuracy returns the number of decimals of the seconds that
+// honestly reflects a horizontal accuracy in meters: the last printed digit is
+// the first significant digit of the accuracy in arc seconds. For example,
+// 1 m is about 0.03" and yields 2 decimals, while 35 m yields 0.
+func PrecisionForAccuracy(meters float64) int {
+	if meters <= 0 || math.IsNaN(meters) {
+		return maxAutoPrecision
+	}
+	decimals := -int(math.Floor(math.Log10(meters / metersPerArcSecond)))
+	return min(max(decimals, 0), maxAutoPrecision)
+}
+
+// formatAccuracy returns an accuracy in meters as a ± angle of arc.
+func formatAccuracy(meters float64, opts FormatOptions) string {
+	var d DMS
+	d.Degree, d.Minutes, d.Seconds = decimalToDMSComponents(meters / metersPerArcSecond / 3600)
+	d = d.roundedSeconds(max(opts.Precision, 0), opts.Rounding)
+	seconds := strconv.FormatFloat(d.Seconds, 'f', max(opts.Precision, 0), 64)
+	return fmt.Sprintf(`±%d°%d'%s"`, d.Degree, d.Minutes, seconds)
+}
+
+// DistanceWithAccuracy returns the great-circle distance in meters between
+// two coordinates and its accuracy, combining their accuracies as
+// independent errors.
+func DistanceWithAccuracy(a, b Coordinate) (distance, accuracy float64) {
+	return Distance(a, b), math.Hypot(a.Accuracy, b.Accuracy)
+}
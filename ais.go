@@ -0,0 +1,171 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// AIS position reports
+
+// ErrAISNoPosition is returned for AIS position reports that mark the
+// position as not available.
+var ErrAISNoPosition = errors.New("AIS position not available")
+
+// AISPosition is a vessel position decoded from an AIS position report.
+type AISPosition struct {
+	MessageType int        // 1, 2 or 3 for Class A, 18 for Class B, 27 for long-range reports.
+	MMSI        uint32     // Maritime Mobile Service Identity of the vessel.
+	Coordinate  Coordinate // Reported position.
+	Accurate    bool       // Whether the position accuracy is better than 10 m.
+	Speed       Speed      // Speed over ground, NaN when not available.
+	Course      float64    // Course over ground in degrees from true north, NaN when not available.
+	Heading     float64    // True heading in degrees, NaN when not available.
+}
+
+// ParseAIS decodes the position report carried by a single-fragment AIVDM or
+// AIVDO sentence, such as "!AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TKH,0*5C".
+func ParseAIS(sentence string) (AISPosition, error) {
+	fields, err := splitNMEA(sentence)
+	if err != nil {
+		return AISPosition{}, err
+	}
+	if len(fields) < 7 || len(fields[0]) != 5 || (fields[0][2:] != "VDM" && fields[0][2:] != "VDO") {
+		return AISPosition{}, fmt.Errorf("Invalid AIS sentence %q", sentence)
+	}
+	if fields[1] != "1" {
+		return AISPosition{}, errors.New("Multi-fragment AIS messages are not supported")
+	}
+	fill, err := strconv.Atoi(fields[6])
+	if err != nil {
+		return AISPosition{}, fmt.Errorf("Invalid AIS fill bits %q", fields[6])
+	}
+	return DecodeAISPayload(fields[5], fill)
+}
+
+// DecodeAISPayload decodes the 6-bit armored payload of an AIS position
+// report of type 1, 2, 3, 18 or 27, ignoring the fill padding bits at its end.
+func DecodeAISPayload(payload string, fill int) (AISPosition, error) {
+	bits, err := unarmorAIS(payload, fill)
+	if err != nil {
+		return AISPosition{}, err
+	}
+	if len(bits) < 6 {
+		return AISPosition{}, errors.New("Empty AIS payload")
+	}
+	p := AISPosition{MessageType: int(bits.uint(0, 6))}
+	var lon, lat float64 // Signed position in minutes.
+	var sog, cog, heading uint64
+	switch p.MessageType {
+	case 1, 2, 3:
+		if len(bits) < 137 {
+			return AISPosition{}, errors.New("Truncated AIS position report")
+		}
+		sog, p.Accurate = bits.uint(50, 10), bits.uint(60, 1) == 1
+		lon, lat = float64(bits.int(61, 28))/10000, float64(bits.int(89, 27))/10000
+		cog, heading = bits.uint(116, 12), bits.uint(128, 9)
+	case 18:
+		if len(bits) < 133 {
+			return AISPosition{}, errors.New("Truncated AIS position report")
+		}
+		sog, p.Accurate = bits.uint(46, 10), bits.uint(56, 1) == 1
+		lon, lat = float64(bits.int(57, 28))/10000, float64(bits.int(85, 27))/10000
+		cog, heading = bits.uint(112, 12), bits.uint(124, 9)
+	case 27:
+		if len(bits) < 96 {
+			return AISPosition{}, errors.New("Truncated AIS position report")
+		}
+		p.Accurate = bits.uint(38, 1) == 1
+		lon, lat = float64(bits.int(44, 18))/10, float64(bits.int(62, 17))/10
+		// Long-range reports carry whole knots and degrees.
+		sog, cog, heading = bits.uint(79, 6)*10, bits.uint(85, 9)*10, 511
+		if sog == 630 {
+			sog = 1023
+		}
+		if cog == 5110 {
+			cog = 3600
+		}
+	default:
+		return AISPosition{}, fmt.Errorf("AIS message type %d is not a position report", p.MessageType)
+	}
+	p.MMSI = uint32(bits.uint(8, 30))
+	if lon == 181*60 || lat == 91*60 {
+		return AISPosition{}, ErrAISNoPosition
+	}
+	if p.Coordinate, err = NewCoordinate(lat/60, lon/60); err != nil {
+		return AISPosition{}, err
+	}
+	p.Speed, p.Course, p.Heading = Speed(math.NaN()), math.NaN(), math.NaN()
+	if sog != 1023 {
+		p.Speed = NewSpeed(float64(sog)/10, UnitKnot)
+	}
+	if cog < 3600 {
+		p.Course = float64(cog) / 10
+	}
+	if heading < 360 {
+		p.Heading = float64(heading)
+	}
+	return p, nil
+}
+
+// aisBits is an unpacked AIS payload, one bit per byte.
+type aisBits []byte
+
+// unarmorAIS unpacks the 6-bit ASCII armoring of an AIS payload.
+func unarmorAIS(payload string, fill int) (aisBits, error) {
+	if fill < 0 || fill > 5 {
+		return nil, fmt.Errorf("Invalid AIS fill bits %d", fill)
+	}
+	bits := make(aisBits, 0, len(payload)*6)
+	for i := 0; i < len(payload); i++ {
+		c := payload[i]
+		if c < '0' || c > 'w' || (c > 'W' && c < '`') {
+			return nil, fmt.Errorf("Invalid AIS payload character %q", c)
+		}
+		v := c - '0'
+		if v > 40 {
+			v -= 8
+		}
+		for b := 5; b >= 0; b-- {
+			bits = append(bits, v>>b&1)
+		}
+	}
+	if fill > len(bits) {
+		return nil, errors.New("Empty AIS payload")
+	}
+	return bits[:len(bits)-fill], nil
+}
+
+// uint returns the unsigned field of width bits at offset.
+func (b aisBits) uint(offset, width int) uint64 {
+	var v uint64
+	for _, bit := range b[offset : offset+width] {
+		v = v<<1 | uint64(bit)
+	}
+	return v
+}
+
+// int returns the two's complement signed field of width bits at offset.
+func (b aisBits) int(offset, width int) int64 {
+	v := int64(b.uint(offset, width))
+	if v&(1<<(width-1)) != 0 {
+		v -= 1 << width
+	}
+	return v
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// aisArmor packs fields of {width, value} into an armored AIS payload and
+// returns it with its number of fill bits.
+func aisArmor(fields ...[2]int64) (string, int) {
+	var bits []byte
+	for _, f := range fields {
+		for b := f[0] - 1; b >= 0; b-- {
+			bits = append(bits, byte(f[1]>>b&1))
+		}
+	}
+	fill := (6 - len(bits)%6) % 6
+	bits = append(bits, make([]byte, fill)...)
+	var payload []byte
+	for i := 0; i < len(bits); i += 6 {
+		v := byte(0)
+		for _, bit := range bits[i : i+6] {
+			v = v<<1 | bit
+		}
+		if v >= 40 {
+			v += 8
+		}
+		payload = append(payload, v+'0')
+	}
+	return string(payload), fill
+}
+
+// aisLongRange returns the payload of a type 27 report at a position in
+// tenths of minutes.
+func aisLongRange(lon, lat int64) (string, int) {
+	return aisArmor([2]int64{6, 27}, [2]int64{2, 0}, [2]int64{30, 123456789}, [2]int64{1, 1},
+		[2]int64{1, 0}, [2]int64{4, 0}, [2]int64{18, lon}, [2]int64{17, lat},
+		[2]int64{6, 12}, [2]int64{9, 90}, [2]int64{1, 0}, [2]int64{1, 0})
+}
+
+func TestParseAIS(t *testing.T) {
+	tests := []struct {
+		sentence             string
+		messageType          int
+		mmsi                 uint32
+		lat, lon, knots, cog float64
+		heading              float64
+		accurate             bool
+	}{
+		{"!AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TKH,0*5C", 1, 477553000, 47.582833, -122.345833, 0, 51, 181, false},
+		{"!AIVDM,1,1,,A,B6CdCm0t3`tba35f@V9faHi7kP06,0*58", 18, 423302100, 40.005283, 53.010997, 1.4, 177, 177, true},
+	}
+	for _, tt := range tests {
+		p, err := ParseAIS(tt.sentence)
+		if err != nil {
+			t.Errorf("ParseAIS(%q): %v", tt.sentence, err)
+			continue
+		}
+		lat, lon := p.Coordinate.Decimal()
+		if p.MessageType != tt.messageType || p.MMSI != tt.mmsi || p.Accurate != tt.accurate ||
+			math.Abs(lat-tt.lat) > 1e-6 || math.Abs(lon-tt.lon) > 1e-6 ||
+			math.Abs(p.Speed.Knots()-tt.knots) > 1e-9 || p.Course != tt.cog || p.Heading != tt.heading {
+			t.Errorf("ParseAIS(%q) = %+v", tt.sentence, p)
+		}
+	}
+}
+
+func TestDecodeAISLongRange(t *testing.T) {
+	payload, fill := aisLongRange(-795*10, 404*10)
+	p, err := DecodeAISPayload(payload, fill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lat, lon := p.Coordinate.Decimal()
+	if p.MessageType != 27 || p.MMSI != 123456789 || !p.Accurate || math.Abs(lat-404.0/60) > 1e-9 ||
+		math.Abs(lon+795.0/60) > 1e-9 || p.Speed.Knots() != 12 || p.Course != 90 || !math.IsNaN(p.Heading) {
+		t.Errorf("DecodeAISPayload = %+v", p)
+	}
+	payload, fill = aisLongRange(181*600, 91*600)
+	if _, err := DecodeAISPayload(payload, fill); !errors.Is(err, ErrAISNoPosition) {
+		t.Errorf("DecodeAISPayload without position: %v, want ErrAISNoPosition", err)
+	}
+}
+
+func TestParseAISMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TKH,0",
+		"!AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TKH,0*5D",
+		"!AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TKH",
+		"!AIXXX,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TKH,0",
+		"!AIVDM,2,1,3,B,177KQJ5000G?tO`K>RA1wUbN0TKH,0",
+		"!AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TKH,x",
+		"!AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TKH,6",
+		"!AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1,0",
+		"!AIVDM,1,1,,B,177KQJ5000G?tO`K>RA1wUbN0TK~,0",
+		"!AIVDM,1,1,,B,,0",
+		"!AIVDM,1,1,,B,55?MbV02;H;s<HtKR20EHE:0@T4@Dn2222222216L961O5Gf0NSQEp6ClRp8,0",
+	} {
+		if p, err := ParseAIS(s); err == nil {
+			t.Errorf("ParseAIS(%q) = %+v, want error", s, p)
+		}
+	}
+	payload, fill := aisLongRange(-795*10, 95*600)
+	if _, err := DecodeAISPayload(payload, fill); err == nil {
+		t.Error("DecodeAISPayload accepted a latitude of 95°")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Angle is a sexagesimal angle that is not bound to a geographic axis, such
+// as a total station horizontal angle or a cumulative rotation.
+type Angle struct {
+	Negative bool    // Whether the angle is negative.
+	Degree   uint    // Degree part of the angle.
+	Minutes  uint    // Minute part of the angle.
+	Seconds  float64 // Second part of the angle.
+}
+
+// WrapMode selects how angles are brought into range.
+type WrapMode int
+
+const (
+	WrapNone WrapMode = iota // Keep the angle as is, e.g. for cumulative angles.
+	Wrap360                  // Wrap into [0°, 360°), e.g. for horizontal circle readings.
+	Wrap180                  // Wrap into [-180°, 180°), e.g. for signed deviations.
+)
+
+// NewAngle creates an Angle from decimal degrees, wrapped according to wrap.
+func NewAngle(decimal float64, wrap WrapMode) Angle {
+	decimal = wrapAngle(decimal, wrap)
+	var a Angle
+	a.Negative = decimal < 0
+	a.Degree, a.Minutes, a.Seconds = decimalToDMSComponents(math.Abs(decimal))
+	return a
+}
+
+// Decimal returns the angle in signed decimal degrees.
+func (a *Angle) Decimal() float64 {
+	value := float64(a.Degree) + float64(a.Minutes)/60 + a.Seconds/3600
+	if a.Negative {
+		return -value
+	}
+	return value
+}
+
+// Wrap returns the angle wrapped according to wrap.
+func (a *Angle) Wrap(wrap WrapMode) Angle {
+	return NewAngle(a.Decimal(), wrap)
+}
+
+// String returns the angle in DMS notation with a leading minus sign when negative.
+func (a *Angle) String() string {
+	d := DMS{Degree: a.Degree, Minutes: a.Minutes, Seconds: a.Seconds}
+	r := d.roundedSeconds(2, RoundHalfAwayFromZero)
+	sign := ""
+	if a.Negative && (r.Degree != 0 || r.Minutes != 0 || r.Seconds != 0) {
+		sign = "-"
+	}
+	return fmt.Sprintf(`%s%d°%d'%.02f"`, sign, r.Degree, r.Minutes, r.Seconds)
+}
+
+// ParseAngle parses a signed sexagesimal angle such as `-271°15'30"`,
+// `359 59 59.5` or `12.5°` of any magnitude, wrapped according to wrap.
+// Directions are not accepted; use ParseDMS for geographic values.
+func ParseAngle(s string, wrap WrapMode) (Angle, error) {
+	tokens, err := tokenizeDMS(s)
+	if err != nil {
+		return Angle{}, err
+	}
+	var values []dmsToken
+	negative := false
+	for i, t := range tokens {
+		switch {
+		case t.direction != "" || t.separator:
+			return Angle{}, fmt.Errorf("Invalid angle %q", s)
+		case t.negative && i > 0:
+			return Angle{}, errors.New("Misplaced minus sign in angle")
+		}
+		negative = negative || t.negative
+		values = append(values, t)
+	}
+	var value float64
+	switch len(values) {
+	case 1:
+		value = values[0].value
+	case 2, 3:
+		for _, t := range values[:len(values)-1] {
+			if !t.integer {
+				return Angle{}, errors.New("Fractional angle part followed by another part")
+			}
+		}
+		for _, t := range values[1:] {
+			if t.value >= 60 {
+				return Angle{}, errors.New("Invalid minutes or seconds value")
+			}
+		}
+		value = values[0].value + values[1].value/60
+		if len(values) == 3 {
+			value += values[2].value / 3600
+		}
+	default:
+		return Angle{}, fmt.Errorf("Invalid angle %q", s)
+	}
+	if negative {
+		value = -value
+	}
+	return NewAngle(value, wrap), nil
+}
+
+// Normalize360 wraps an angle in decimal degrees into the range [0, 360).
+func Normalize360(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+// Normalize180 wraps an angle in decimal degrees into the range [-180, 180).
+func Normalize180(angle float64) float64 {
+	return Normalize360(angle+180) - 180
+}
+
+// AngleDifference returns the shortest signed angle in decimal degrees that
+// turns from onto to, in the range [-180, 180). It is positive clockwise,
+// e.g. from a bearing of 350° to one of 10° the difference is 20°.
+func AngleDifference(from, to float64) float64 {
+	return Normalize180(to - from)
+}
+
+// Normalize360 returns the angle wrapped into [0°, 360°).
+func (a *Angle) Normalize360() Angle {
+	return a.Wrap(Wrap360)
+}
+
+// Normalize180 returns the angle wrapped into [-180°, 180°).
+func (a *Angle) Normalize180() Angle {
+	return a.Wrap(Wrap180)
+}
+
+// Difference returns the shortest signed angle that turns from a onto to.
+func (a *Angle) Difference(to Angle) Angle {
+	return NewAngle(AngleDifference(a.Decimal(), to.Decimal()), WrapNone)
+}
+
+// Angle units
+
+// AngleUnit identifies a unit of plane angle.
+type AngleUnit int
+
+const (
+	UnitDegree  AngleUnit = iota // Degrees, 360 per circle.
+	UnitGon                      // Gradians (gon), 400 per circle, used by surveying instruments.
+	UnitNATOMil                  // NATO mils, 6400 per circle, used by artillery and military optics.
+	UnitRadian                   // Radians, 2π per circle.
+)
+
+// perCircle returns the number of units in a full circle.
+func (u AngleUnit) perCircle() float64 {
+	switch u {
+	case UnitGon:
+		return 400
+	case UnitNATOMil:
+		return 6400
+	case UnitRadian:
+		return 2 * math.Pi
+	}
+	return 360
+}
+
+// ConvertAngle converts an angle value between units.
+func ConvertAngle(value float64, from, to AngleUnit) float64 {
+	return value / from.perCircle() * to.perCircle()
+}
+
+// Format returns an angle value in the unit with the given number of
+// decimals, e.g. "123.4567 gon" or "0800 mil". Whole NATO mils are padded to
+// the customary four digits.
+func (u AngleUnit) Format(value float64, decimals int) string {
+	decimals = max(decimals, 0)
+	switch u {
+	case UnitGon:
+		return fmt.Sprintf("%.*f gon", decimals, value)
+	case UnitNATOMil:
+		if decimals == 0 && value >= 0 {
+			return fmt.Sprintf("%04.0f mil", value)
+		}
+		return fmt.Sprintf("%.*f mil", decimals, value)
+	case UnitRadian:
+		return fmt.Sprintf("%.*f rad", decimals, value)
+	}
+	return fmt.Sprintf("%.*f°", decimals, value)
+}
+
+// NewAngleFromUnit creates an Angle from a value in the given unit, wrapped
+// according to wrap.
+func NewAngleFromUnit(value float64, unit AngleUnit, wrap WrapMode) Angle {
+	return NewAngle(ConvertAngle(value, unit, UnitDegree), wrap)
+}
+
+// In returns the angle as a value in the given unit.
+func (a *Angle) In(unit AngleUnit) float64 {
+	return ConvertAngle(a.Decimal(), UnitDegree, unit)
+}
+
+// wrapAngle wraps an angle in decimal degrees according to wrap.
+func wrapAngle(angle float64, wrap WrapMode) float64 {
+	switch wrap {
+	case Wrap360:
+		return Normalize360(angle)
+	case Wrap180:
+		return Normalize180(angle)
+	}
+	return angle
+}
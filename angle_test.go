@@ -0,0 +1,103 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseAngle(t *testing.T) {
+	tests := []struct {
+		s    string
+		wrap WrapMode
+		want float64
+	}{
+		{`-271°15'30"`, WrapNone, -271.258333333},
+		{`-271°15'30"`, Wrap360, 88.741666667},
+		{`359 59 59.5`, Wrap180, -0.000138889},
+		{`12.5°`, WrapNone, 12.5},
+		{`725 30`, Wrap360, 5.5},
+		{`190`, Wrap180, -170},
+	}
+	for _, tt := range tests {
+		a, err := ParseAngle(tt.s, tt.wrap)
+		if err != nil {
+			t.Errorf("ParseAngle(%q) error: %v", tt.s, err)
+			continue
+		}
+		if got := a.Decimal(); math.Abs(got-tt.want) > 1e-8 {
+			t.Errorf("ParseAngle(%q, %d) = %v, want %v", tt.s, tt.wrap, got, tt.want)
+		}
+		back, err := ParseAngle(a.String(), WrapNone)
+		if err != nil || math.Abs(back.Decimal()-a.Decimal()) > 1e-5 {
+			t.Errorf("ParseAngle(%q) = %v, %v, want %v", a.String(), back.Decimal(), err, a.Decimal())
+		}
+	}
+}
+
+func TestParseAngleMalformed(t *testing.T) {
+	for _, s := range []string{``, `40°N`, `12 -30`, `12.5 30`, `12 60`, `1 2 3 4`, `abc`} {
+		if a, err := ParseAngle(s, WrapNone); err == nil {
+			t.Errorf("ParseAngle(%q) = %v, want error", s, a.String())
+		}
+	}
+}
+
+func TestConvertAngle(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to AngleUnit
+		want     float64
+	}{
+		{90, UnitDegree, UnitGon, 100},
+		{45, UnitDegree, UnitNATOMil, 800},
+		{3200, UnitNATOMil, UnitRadian, math.Pi},
+		{123.4567, UnitGon, UnitDegree, 111.11103},
+		{-100, UnitGon, UnitNATOMil, -1600},
+	}
+	for _, tt := range tests {
+		if got := ConvertAngle(tt.value, tt.from, tt.to); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ConvertAngle(%v, %d, %d) = %v, want %v", tt.value, tt.from, tt.to, got, tt.want)
+		}
+	}
+	a := NewAngleFromUnit(-100, UnitGon, Wrap360)
+	if got := a.In(UnitGon); math.Abs(got-300) > 1e-9 {
+		t.Errorf("NewAngleFromUnit(-100 gon, Wrap360).In(UnitGon) = %v, want 300", got)
+	}
+}
+
+func TestAngleUnitFormat(t *testing.T) {
+	tests := []struct {
+		unit     AngleUnit
+		value    float64
+		decimals int
+		want     string
+	}{
+		{UnitGon, 123.45671, 4, "123.4567 gon"},
+		{UnitNATOMil, 800, 0, "0800 mil"},
+		{UnitNATOMil, 12.4, 0, "0012 mil"},
+		{UnitNATOMil, -800, 0, "-800 mil"},
+		{UnitNATOMil, 800.25, 1, "800.2 mil"},
+		{UnitRadian, math.Pi, 3, "3.142 rad"},
+		{UnitDegree, 12.5, -1, "12°"},
+	}
+	for _, tt := range tests {
+		if got := tt.unit.Format(tt.value, tt.decimals); got != tt.want {
+			t.Errorf("Format(%v, %d) = %q, want %q", tt.value, tt.decimals, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// APRS positions
+
+// APRSPosition is an APRS position with its map symbol.
+type APRSPosition struct {
+	Coordinate  Coordinate // Reported position.
+	SymbolTable byte       // Symbol table: '/' primary, '\' alternate, or an overlay character.
+	SymbolCode  byte       // Symbol within the table, e.g. '>' for a car.
+}
+
+// Lengths of APRS position fields.
+const (
+	aprsUncompressedLength = 19 // "4903.50N/07201.75W-"
+	aprsCompressedLength   = 13 // "/5L!!<*e7>  !"
+	aprsTimestampLength    = 7  // "092345z"
+)
+
+// String returns the position in the uncompressed format, latitude and
+// longitude in degrees and hundredths of minutes around the symbol table,
+// followed by the symbol code, e.g. "4903.50N/07201.75W-".
+func (p *APRSPosition) String() string {
+	return aprsDDM(p.Coordinate.Latitude, 2) + string(p.SymbolTable) +
+		aprsDDM(p.Coordinate.Longitude, 3) + string(p.SymbolCode)
+}
+
+// Compressed returns the position in the 13-character compressed format,
+// base-91 encoded without course, speed or altitude, e.g. "/5L!!<*e7>  !".
+// Overlays of the primary table are written as letters A-J.
+func (p *APRSPosition) Compressed() string {
+	lat, lon := p.Coordinate.Decimal()
+	table := p.SymbolTable
+	if table >= '0' && table <= '9' {
+		table += 'a' - '0'
+	}
+	var b strings.Builder
+	b.WriteByte(table)
+	b.WriteString(base91(math.Round(380926*(90-lat)), 4))
+	b.WriteString(base91(math.Round(190463*(180+lon)), 4))
+	b.WriteByte(p.SymbolCode)
+	b.WriteString("  !")
+	return b.String()
+}
+
+// ParseAPRS parses an APRS position in the uncompressed or compressed format.
+// The information field of a position packet is accepted as well: a leading
+// data type identifier ('!', '=', '/' or '@') and timestamp are skipped, and
+// text following the position is ignored. Spaces used for position ambiguity
+// are read as zeros.
+func ParseAPRS(s string) (APRSPosition, error) {
+	if s == "" {
+		return APRSPosition{}, fmt.Errorf("Invalid APRS position %q", s)
+	}
+	switch {
+	case s[0] == '!' || s[0] == '=':
+		s = s[1:]
+	case (s[0] == '/' || s[0] == '@') && isAPRSTimestamp(s[1:]):
+		s = s[1+aprsTimestampLength:]
+	}
+	if len(s) > 0 && s[0] >= '0' && s[0] <= '9' {
+		return parseAPRSUncompressed(s)
+	}
+	return parseAPRSCompressed(s)
+}
+
+// isAPRSTimestamp reports whether s starts with an APRS timestamp, six
+// digits followed by 'z', 'h' or '/'.
+func isAPRSTimestamp(s string) bool {
+	if len(s) < aprsTimestampLength || !strings.ContainsRune("zh/", rune(s[6])) {
+		return false
+	}
+	for i := 0; i < 6; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAPRSUncompressed parses a position in the uncompressed format.
+func parseAPRSUncompressed(s string) (APRSPosition, error) {
+	if len(s) < aprsUncompressedLength {
+		return APRSPosition{}, fmt.Errorf("Invalid APRS position %q", s)
+	}
+	field := strings.ReplaceAll(s[:aprsUncompressedLength], " ", "0")
+	p := APRSPosition{SymbolTable: field[8], SymbolCode: field[18]}
+	var err error
+	if p.Coordinate.Latitude, err = parseNMEAPosition(field[:7], field[7:8], AxisLatitude); err != nil {
+		return APRSPosition{}, err
+	}
+	if p.Coordinate.Longitude, err = parseNMEAPosition(field[9:17], field[17:18], AxisLongitude); err != nil {
+		return APRSPosition{}, err
+	}
+	return p, nil
+}
+
+// parseAPRSCompressed parses a position in the compressed format.
+func parseAPRSCompressed(s string) (APRSPosition, error) {
+	if len(s) < aprsCompressedLength {
+		return APRSPosition{}, fmt.Errorf("Invalid APRS position %q", s)
+	}
+	y, err := parseBase91(s[1:5])
+	if err != nil {
+		return APRSPosition{}, err
+	}
+	x, err := parseBase91(s[5:9])
+	if err != nil {
+		return APRSPosition{}, err
+	}
+	table := s[0]
+	if table >= 'a' && table <= 'j' {
+		table -= 'a' - '0'
+	}
+	p := APRSPosition{SymbolTable: table, SymbolCode: s[9]}
+	if p.Coordinate, err = NewCoordinate(90-y/380926, -180+x/190463); err != nil {
+		return APRSPosition{}, err
+	}
+	return p, nil
+}
+
+// aprsDDM returns a DMS value as degrees of the given width and minutes with
+// two decimals followed by the direction, e.g. "07201.75W".
+func aprsDDM(d DMS, width int) string {
+	degree := d.Degree
+	minutes := roundTo(float64(d.Minutes)+d.Seconds/60, 2, RoundHalfAwayFromZero)
+	if minutes >= 60 {
+		minutes -= 60
+		degree++
+	}
+	return fmt.Sprintf("%0*d%05.2f%s", width, degree, minutes, d.Direction)
+}
+
+// base91 returns v in base 91 with the given number of digits, as used by
+// compressed APRS positions.
+func base91(v float64, digits int) string {
+	n := int64(v)
+	b := make([]byte, digits)
+	for i := digits - 1; i >= 0; i-- {
+		b[i] = byte(n%91) + 33
+		n /= 91
+	}
+	return string(b)
+}
+
+// parseBase91 parses a base-91 number of a compressed APRS position.
+func parseBase91(s string) (float64, error) {
+	var v float64
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 123 {
+			return 0, fmt.Errorf("Invalid base-91 digit %q", s[i])
+		}
+		v = v*91 + float64(s[i]-33)
+	}
+	return v, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseAPRS(t *testing.T) {
+	tests := []struct {
+		input       string
+		lat, lon    float64
+		table, code byte
+	}{
+		{"4903.50N/07201.75W-", 49 + 3.5/60, -(72 + 1.75/60), '/', '-'},
+		{"!4903.50N/07201.75W-Test 001234", 49 + 3.5/60, -(72 + 1.75/60), '/', '-'},
+		{"@092345z4903.50N/07201.75W>", 49 + 3.5/60, -(72 + 1.75/60), '/', '>'},
+		{"=3353.  S\\15112.  E&", -(33 + 53.0/60), 151 + 12.0/60, '\\', '&'},
+		{"=/5L!!<*e7>7P[", 49.5, -72.75, '/', '>'},
+		{"a5L!!<*e7>  !", 49.5, -72.75, '0', '>'},
+	}
+	for _, tt := range tests {
+		p, err := ParseAPRS(tt.input)
+		if err != nil {
+			t.Errorf("ParseAPRS(%q): %v", tt.input, err)
+			continue
+		}
+		lat, lon := p.Coordinate.Decimal()
+		if math.Abs(lat-tt.lat) > 1e-5 || math.Abs(lon-tt.lon) > 1e-5 || p.SymbolTable != tt.table || p.SymbolCode != tt.code {
+			t.Errorf("ParseAPRS(%q) = %v, %v, %q%q", tt.input, lat, lon, p.SymbolTable, p.SymbolCode)
+		}
+	}
+}
+
+func TestAPRSRoundTrip(t *testing.T) {
+	for _, c := range []Coordinate{
+		coordinateFromDecimal(49.5, -72.75),
+		coordinateFromDecimal(-33.8568, 151.2153),
+		coordinateFromDecimal(0, 0),
+		coordinateFromDecimal(59.99999, -0.000001),
+	} {
+		p := APRSPosition{Coordinate: c, SymbolTable: '/', SymbolCode: '>'}
+		for _, s := range []string{p.String(), p.Compressed()} {
+			got, err := ParseAPRS(s)
+			if err != nil {
+				t.Errorf("ParseAPRS(%q): %v", s, err)
+				continue
+			}
+			if d := Distance(got.Coordinate, c); d > 20 || got.SymbolTable != '/' || got.SymbolCode != '>' {
+				t.Errorf("ParseAPRS(%q) = %+v, %g m from %v", s, got, d, c)
+			}
+		}
+	}
+	overlay := APRSPosition{Coordinate: coordinateFromDecimal(1, 2), SymbolTable: '3', SymbolCode: '#'}
+	if got, err := ParseAPRS(overlay.Compressed()); err != nil || got.SymbolTable != '3' {
+		t.Errorf("ParseAPRS(%q) = %+v, %v, want overlay 3", overlay.Compressed(), got, err)
+	}
+}
+
+func TestParseAPRSMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"!",
+		"4903.50N/07201.75W",
+		"4903.50X/07201.75W-",
+		"4903.50N/07201.75N-",
+		"4963.50N/07201.75W-",
+		"9103.50N/07201.75W-",
+		"4903.50N/18101.75W-",
+		"49O3.50N/07201.75W-",
+		"/5L!!<*e7>",
+		"/5L!~<*e7>7P[",
+		"/{{{{<*e7>7P[",
+	} {
+		if p, err := ParseAPRS(s); err == nil {
+			t.Errorf("ParseAPRS(%q) = %+v, want error", s, p)
+		}
+	}
+}
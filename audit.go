@@ -0,0 +1,75 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Conversion audit trail
+
+// ConversionStep is one transformation recorded in a ConversionRecord.
+type ConversionStep struct {
+	Stage      string     `json:"stage"`      // Kind of transformation, e.g. "datum-shift".
+	Detail     string     `json:"detail"`     // Parameters of the transformation.
+	Coordinate Coordinate `json:"coordinate"` // Coordinate after the transformation.
+}
+
+// ConversionRecord records how an output coordinate was produced, for audits
+// in regulated fields such as aviation and surveying.
+type ConversionRecord struct {
+	Time   time.Time        `json:"time"`   // When the conversion started.
+	Input  string           `json:"input"`  // Text read by the pipeline, if any.
+	Steps  []ConversionStep `json:"steps"`  // Transformations in the order applied.
+	Output string           `json:"output"` // Text produced by the pipeline, if any.
+}
+
+// add appends a step for the coordinate of s.
+func (r *ConversionRecord) add(s *PipelineState, stage, detail string) {
+	r.Steps = append(r.Steps, ConversionStep{Stage: stage, Detail: detail, Coordinate: s.Coordinate})
+}
+
+// String returns the record as text, one step per line, with coordinates in
+// decimal degrees at full precision.
+func (r *ConversionRecord) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "time: %s\n", r.Time.Format(time.RFC3339Nano))
+	fmt.Fprintf(&b, "input: %q\n", r.Input)
+	for i, step := range r.Steps {
+		lat, lon := step.Coordinate.Decimal()
+		fmt.Fprintf(&b, "%d. %s (%s): %s, %s\n", i+1, step.Stage, step.Detail,
+			formatDecimal(lat, 9), formatDecimal(lon, 9))
+	}
+	fmt.Fprintf(&b, "output: %q\n", r.Output)
+	return b.String()
+}
+
+// RunAudited runs the pipeline on an input string like Run and returns its
+// output together with the record of the transformations applied. The record
+// covers the stages completed before an error. It is observed like Run.
+func (p Pipeline) RunAudited(input string) (string, *ConversionRecord, error) {
+	defer observe("pipeline", time.Now(), nil)
+	record := &ConversionRecord{Time: time.Now(), Input: input}
+	s := PipelineState{Input: input, Record: record}
+	err := p.Process(&s)
+	record.Output = s.Output
+	if err != nil {
+		return "", record, err
+	}
+	return s.Output, record, nil
+}
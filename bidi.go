@@ -0,0 +1,67 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "html"
+
+// Bidirectional and HTML output
+
+// BidiMode selects the Unicode bidi control characters placed around RTL output.
+type BidiMode int
+
+const (
+	BidiNone      BidiMode = iota // No control characters.
+	BidiEmbedding                 // RIGHT-TO-LEFT EMBEDDING (U+202B) ... POP DIRECTIONAL FORMATTING (U+202C).
+	BidiIsolate                   // RIGHT-TO-LEFT ISOLATE (U+2067) ... POP DIRECTIONAL ISOLATE (U+2069).
+	BidiMark                      // RIGHT-TO-LEFT MARK (U+200F) on both sides.
+)
+
+// Unicode bidi control characters.
+const (
+	rle = "\u202b"
+	pdf = "\u202c"
+	rli = "\u2067"
+	pdi = "\u2069"
+	rlm = "\u200f"
+)
+
+// StringRTLBidi returns the RTL representation wrapped in the bidi control
+// characters of mode, so it keeps its layout when embedded in text of the
+// opposite direction.
+func (d *DMS) StringRTLBidi(mode BidiMode) string {
+	s := d.StringRTL()
+	switch mode {
+	case BidiEmbedding:
+		return rle + s + pdf
+	case BidiIsolate:
+		return rli + s + pdi
+	case BidiMark:
+		return rlm + s + rlm
+	}
+	return s
+}
+
+// StringHTML returns the DMS formatted according to opts with HTML special
+// characters, such as the seconds quote, escaped.
+func (d *DMS) StringHTML(opts FormatOptions) string {
+	return html.EscapeString(d.Format(opts))
+}
+
+// StringRTLHTML returns the escaped RTL representation in a span with an
+// explicit right-to-left direction.
+func (d *DMS) StringRTLHTML() string {
+	return `<span dir="rtl">` + html.EscapeString(d.StringRTL()) + `</span>`
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "testing"
+
+func TestStringRTLBidi(t *testing.T) {
+	rtl := formatSample.StringRTL()
+	tests := []struct {
+		mode BidiMode
+		want string
+	}{
+		{BidiNone, rtl},
+		{BidiEmbedding, "\u202b" + rtl + "\u202c"},
+		{BidiIsolate, "\u2067" + rtl + "\u2069"},
+		{BidiMark, "\u200f" + rtl + "\u200f"},
+	}
+	for _, tt := range tests {
+		if got := formatSample.StringRTLBidi(tt.mode); got != tt.want {
+			t.Errorf("StringRTLBidi(%d) = %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestStringHTML(t *testing.T) {
+	opts := DefaultFormatOptions()
+	if got, want := formatSample.StringHTML(opts), "40°1&#39;46.30&#34; N"; got != want {
+		t.Errorf("StringHTML() = %q, want %q", got, want)
+	}
+	opts.Style = StyleASCII
+	if got, want := formatSample.StringHTML(opts), "40d 1m 46.30s N"; got != want {
+		t.Errorf("StringHTML(StyleASCII) = %q, want %q", got, want)
+	}
+	if got, want := formatSample.StringRTLHTML(), `<span dir="rtl">N &#34;46.30 &#39;1 °40</span>`; got != want {
+		t.Errorf("StringRTLHTML() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Binary serialization
+
+// Sizes of the fixed-size binary encodings.
+const (
+	DMSBinarySize        = 12                  // Degree (2), Minutes (1), Direction (1), Seconds (8).
+	CoordinateBinarySize = 2*DMSBinarySize + 8 // Latitude, Longitude and Accuracy.
+)
+
+// AppendBinary appends the fixed-size binary encoding of the DMS to buf.
+func (d DMS) AppendBinary(buf []byte) ([]byte, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	buf = binary.BigEndian.AppendUint16(buf, uint16(d.Degree))
+	buf = append(buf, byte(d.Minutes), d.Direction[0])
+	return binary.BigEndian.AppendUint64(buf, math.Float64bits(d.Seconds)), nil
+}
+
+// MarshalBinary returns the fixed-size binary encoding of the DMS. Since DMS
+// implements encoding.BinaryMarshaler, encoding/gob uses this compact form.
+func (d DMS) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(make([]byte, 0, DMSBinarySize))
+}
+
+// UnmarshalBinary decodes the fixed-size binary encoding of a DMS.
+func (d *DMS) UnmarshalBinary(data []byte) error {
+	if len(data) != DMSBinarySize {
+		return errors.New("Invalid DMS binary length")
+	}
+	decoded := DMS{
+		Degree:    uint(binary.BigEndian.Uint16(data)),
+		Minutes:   uint(data[2]),
+		Direction: string(data[3:4]),
+		Seconds:   math.Float64frombits(binary.BigEndian.Uint64(data[4:])),
+	}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// AppendBinary appends the fixed-size binary encoding of the coordinate to buf.
+func (c Coordinate) AppendBinary(buf []byte) ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	buf, _ = c.Latitude.AppendBinary(buf)
+	buf, _ = c.Longitude.AppendBinary(buf)
+	return binary.BigEndian.AppendUint64(buf, math.Float64bits(c.Accuracy)), nil
+}
+
+// MarshalBinary returns the fixed-size binary encoding of the coordinate.
+func (c Coordinate) MarshalBinary() ([]byte, error) {
+	return c.AppendBinary(make([]byte, 0, CoordinateBinarySize))
+}
+
+// UnmarshalBinary decodes the fixed-size binary encoding of a coordinate.
+func (c *Coordinate) UnmarshalBinary(data []byte) error {
+	if len(data) != CoordinateBinarySize {
+		return errors.New("Invalid Coordinate binary length")
+	}
+	var decoded Coordinate
+	if err := decoded.Latitude.UnmarshalBinary(data[:DMSBinarySize]); err != nil {
+		return err
+	}
+	if err := decoded.Longitude.UnmarshalBinary(data[DMSBinarySize : 2*DMSBinarySize]); err != nil {
+		return err
+	}
+	decoded.Accuracy = math.Float64frombits(binary.BigEndian.Uint64(data[2*DMSBinarySize:]))
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+	*c = decoded
+	return nil
+}
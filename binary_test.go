@@ -0,0 +1,107 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"math"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	tests := []Coordinate{
+		coordinateFromDecimal(40.446195, -79.948862),
+		coordinateFromDecimal(-33.8568, 151.2153),
+		coordinateFromDecimal(90, -180),
+	}
+	tests[0].Accuracy = 3.5
+	for _, want := range tests {
+		data, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%v): %v", want, err)
+		}
+		if len(data) != CoordinateBinarySize {
+			t.Errorf("MarshalBinary(%v) wrote %d bytes, want %d", want, len(data), CoordinateBinarySize)
+		}
+		var got Coordinate
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary(%v): %v", want, err)
+		}
+		if got != want {
+			t.Errorf("round trip of %+v gave %+v", want, got)
+		}
+	}
+}
+
+func TestBinaryGob(t *testing.T) {
+	want := coordinateFromDecimal(51.5007, -0.1246)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatal(err)
+	}
+	var got Coordinate
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("gob round trip of %+v gave %+v", want, got)
+	}
+}
+
+func TestUnmarshalBinaryMalformed(t *testing.T) {
+	valid, _ := (DMS{Degree: 40, Minutes: 26, Seconds: 46.3, Direction: "N"}).MarshalBinary()
+	corrupt := func(fn func([]byte)) []byte {
+		data := append([]byte(nil), valid...)
+		fn(data)
+		return data
+	}
+	tests := map[string][]byte{
+		"empty":       nil,
+		"short":       valid[:DMSBinarySize-1],
+		"long":        append(append([]byte(nil), valid...), 0),
+		"degree 95":   corrupt(func(b []byte) { b[1] = 95 }),
+		"minutes 60":  corrupt(func(b []byte) { b[2] = 60 }),
+		"direction X": corrupt(func(b []byte) { b[3] = 'X' }),
+		"seconds NaN": corrupt(func(b []byte) {
+			copy(b[4:], []byte{0x7f, 0xf8, 0, 0, 0, 0, 0, 1})
+		}),
+	}
+	for name, data := range tests {
+		var d DMS
+		if err := d.UnmarshalBinary(data); err == nil {
+			t.Errorf("%s: UnmarshalBinary = %+v, want error", name, d)
+		}
+	}
+	var c Coordinate
+	if err := c.UnmarshalBinary(valid); err == nil {
+		t.Error("Coordinate.UnmarshalBinary accepted a DMS encoding")
+	}
+	good, _ := coordinateFromDecimal(1, 2).MarshalBinary()
+	bad := append([]byte(nil), good...)
+	copy(bad[DMSBinarySize:], valid) // A latitude as the longitude.
+	if err := c.UnmarshalBinary(bad); err == nil {
+		t.Error("Coordinate.UnmarshalBinary accepted a latitude as the longitude")
+	}
+	binary.BigEndian.PutUint64(good[2*DMSBinarySize:], math.Float64bits(-1))
+	if err := c.UnmarshalBinary(good); err == nil {
+		t.Error("Coordinate.UnmarshalBinary accepted a negative accuracy")
+	}
+	if _, err := (DMS{Degree: 40, Seconds: math.Inf(1), Direction: "N"}).MarshalBinary(); err == nil {
+		t.Error("MarshalBinary accepted infinite seconds")
+	}
+}
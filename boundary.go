@@ -0,0 +1,128 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+// Boundary classification
+//
+// Tolerances are given in decimal degrees; a tolerance of zero requires the
+// value to lie exactly on the boundary.
+
+// Pole identifies which geographic pole, if any, a position lies on.
+type Pole int
+
+const (
+	NoPole    Pole = iota // Position is not at a pole.
+	NorthPole             // Position is at the North Pole.
+	SouthPole             // Position is at the South Pole.
+)
+
+// Pole returns the pole a latitude lies on within tolerance.
+func (d *DMS) Pole(tolerance float64) Pole {
+	if !d.isLatitude() || DMSToDecimal(*d) < 90-tolerance {
+		return NoPole
+	}
+	if d.Direction == "N" {
+		return NorthPole
+	}
+	return SouthPole
+}
+
+// IsPole reports whether a latitude lies on either pole within tolerance.
+func (d *DMS) IsPole(tolerance float64) bool {
+	return d.Pole(tolerance) != NoPole
+}
+
+// IsEquator reports whether a latitude lies on the equator within tolerance.
+func (d *DMS) IsEquator(tolerance float64) bool {
+	return d.isLatitude() && DMSToDecimal(*d) <= tolerance
+}
+
+// IsAntimeridian reports whether a longitude lies on the 180th meridian within tolerance.
+func (d *DMS) IsAntimeridian(tolerance float64) bool {
+	return d.isLongitude() && DMSToDecimal(*d) >= 180-tolerance
+}
+
+// IsPrimeMeridian reports whether a longitude lies on the prime meridian within tolerance.
+func (d *DMS) IsPrimeMeridian(tolerance float64) bool {
+	return d.isLongitude() && DMSToDecimal(*d) <= tolerance
+}
+
+// Pole returns the pole the coordinate lies on within tolerance.
+func (c *Coordinate) Pole(tolerance float64) Pole {
+	return c.Latitude.Pole(tolerance)
+}
+
+// IsPole reports whether the coordinate lies on either pole within tolerance.
+func (c *Coordinate) IsPole(tolerance float64) bool {
+	return c.Latitude.IsPole(tolerance)
+}
+
+// IsEquator reports whether the coordinate lies on the equator within tolerance.
+func (c *Coordinate) IsEquator(tolerance float64) bool {
+	return c.Latitude.IsEquator(tolerance)
+}
+
+// IsAntimeridian reports whether the coordinate lies on the 180th meridian within tolerance.
+func (c *Coordinate) IsAntimeridian(tolerance float64) bool {
+	return c.Longitude.IsAntimeridian(tolerance)
+}
+
+// IsPrimeMeridian reports whether the coordinate lies on the prime meridian within tolerance.
+func (c *Coordinate) IsPrimeMeridian(tolerance float64) bool {
+	return c.Longitude.IsPrimeMeridian(tolerance)
+}
+
+// Hemisphere and quadrant queries
+
+// Hemisphere returns the north-south hemisphere of the coordinate (N or S).
+func (c *Coordinate) Hemisphere() Direction {
+	return Direction(c.Latitude.Direction)
+}
+
+// Quadrant returns the combined hemispheres of the coordinate: NE, NW, SE or SW.
+func (c *Coordinate) Quadrant() string {
+	return c.Latitude.Direction + c.Longitude.Direction
+}
+
+// IsNorthern reports whether the coordinate is in the northern hemisphere.
+func (c *Coordinate) IsNorthern() bool {
+	return c.Latitude.Direction == "N"
+}
+
+// IsSouthern reports whether the coordinate is in the southern hemisphere.
+func (c *Coordinate) IsSouthern() bool {
+	return c.Latitude.Direction == "S"
+}
+
+// IsEastern reports whether the coordinate is in the eastern hemisphere.
+func (c *Coordinate) IsEastern() bool {
+	return c.Longitude.Direction == "E"
+}
+
+// IsWestern reports whether the coordinate is in the western hemisphere.
+func (c *Coordinate) IsWestern() bool {
+	return c.Longitude.Direction == "W"
+}
+
+// isLatitude reports whether the direction of the DMS is N or S.
+func (d *DMS) isLatitude() bool {
+	return d.Direction == "N" || d.Direction == "S"
+}
+
+// isLongitude reports whether the direction of the DMS is E or W.
+func (d *DMS) isLongitude() bool {
+	return d.Direction == "E" || d.Direction == "W"
+}
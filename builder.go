@@ -0,0 +1,112 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CoordinateBuilder accumulates the parts of a Coordinate together with their
+// validation errors, so all problems of a value can be reported at once:
+//
+//	c, err := dms.Builder().Lat(40, 26, 46.3, dms.North).Lon(79, 58, 56, dms.West).Build()
+type CoordinateBuilder struct {
+	coord          Coordinate
+	hasLat, hasLon bool
+	errs           []error
+}
+
+// Builder returns a new, empty CoordinateBuilder.
+func Builder() *CoordinateBuilder {
+	return &CoordinateBuilder{}
+}
+
+// Lat sets the latitude from its components. The direction must be N or S.
+func (b *CoordinateBuilder) Lat(deg, min uint, sec float64, dir Direction) *CoordinateBuilder {
+	if dir != North && dir != South {
+		b.errs = append(b.errs, fmt.Errorf("latitude: Invalid direction %q", dir))
+	}
+	d, err := NewDMSFromComponents(deg, min, sec, dir)
+	b.setLat(d, err)
+	return b
+}
+
+// Lon sets the longitude from its components. The direction must be E or W.
+func (b *CoordinateBuilder) Lon(deg, min uint, sec float64, dir Direction) *CoordinateBuilder {
+	if dir != East && dir != West {
+		b.errs = append(b.errs, fmt.Errorf("longitude: Invalid direction %q", dir))
+	}
+	d, err := NewDMSFromComponents(deg, min, sec, dir)
+	b.setLon(d, err)
+	return b
+}
+
+// LatDecimal sets the latitude from signed decimal degrees.
+func (b *CoordinateBuilder) LatDecimal(dec float64) *CoordinateBuilder {
+	b.setLat(NewLatitude(dec))
+	return b
+}
+
+// LonDecimal sets the longitude from signed decimal degrees.
+func (b *CoordinateBuilder) LonDecimal(dec float64) *CoordinateBuilder {
+	b.setLon(NewLongitude(dec))
+	return b
+}
+
+// Accuracy sets the horizontal accuracy radius in meters.
+func (b *CoordinateBuilder) Accuracy(meters float64) *CoordinateBuilder {
+	if meters < 0 || math.IsNaN(meters) {
+		b.errs = append(b.errs, errors.New("accuracy: Invalid accuracy value"))
+	}
+	b.coord.Accuracy = meters
+	return b
+}
+
+// Build returns the validated Coordinate, or all errors accumulated while
+// building it joined together.
+func (b *CoordinateBuilder) Build() (Coordinate, error) {
+	errs := b.errs
+	if !b.hasLat {
+		errs = append(errs, errors.New("latitude: Missing value"))
+	}
+	if !b.hasLon {
+		errs = append(errs, errors.New("longitude: Missing value"))
+	}
+	if len(errs) > 0 {
+		return Coordinate{}, errors.Join(errs...)
+	}
+	return b.coord, nil
+}
+
+// setLat records a latitude or the error from creating it.
+func (b *CoordinateBuilder) setLat(d DMS, err error) {
+	b.hasLat = true
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("latitude: %w", err))
+	}
+	b.coord.Latitude = d
+}
+
+// setLon records a longitude or the error from creating it.
+func (b *CoordinateBuilder) setLon(d DMS, err error) {
+	b.hasLon = true
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("longitude: %w", err))
+	}
+	b.coord.Longitude = d
+}
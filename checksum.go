@@ -0,0 +1,73 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Checksummed text
+//
+// Checksummed text ends with "*" and two hexadecimal digits holding the XOR
+// of its bytes, as in NMEA 0183 and AIS sentences, so transmission errors
+// are detected when it is read back.
+
+// ErrChecksumMismatch is returned when checksummed text does not match its checksum.
+var ErrChecksumMismatch = errors.New("Checksum mismatch")
+
+// AppendChecksum returns text followed by "*" and its checksum, e.g.
+// "402646.302N0795855.999W*20".
+func AppendChecksum(text string) string {
+	return fmt.Sprintf("%s*%02X", text, nmeaChecksum(text))
+}
+
+// VerifyChecksum checks the checksum at the end of text and returns the text
+// without it. It fails when the checksum is missing or does not match.
+func VerifyChecksum(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	i := strings.LastIndexByte(s, '*')
+	if i < 0 || len(s)-i != 3 {
+		return "", fmt.Errorf("Missing checksum in %q", s)
+	}
+	want, err := strconv.ParseUint(s[i+1:], 16, 8)
+	if err != nil {
+		return "", fmt.Errorf("Invalid checksum in %q", s)
+	}
+	text := s[:i]
+	if nmeaChecksum(text) != byte(want) {
+		return "", fmt.Errorf("%w in %q", ErrChecksumMismatch, s)
+	}
+	return text, nil
+}
+
+// StringChecksum returns the compact representation of the coordinate
+// followed by its checksum, e.g. "402646.302N0795855.999W*20".
+func (c *Coordinate) StringChecksum() string {
+	return AppendChecksum(c.StringCompact())
+}
+
+// ParseChecksum verifies the checksum of checksummed text and parses the
+// coordinate it holds in any notation accepted by ParseAny.
+func ParseChecksum(s string) (Coordinate, Notation, error) {
+	text, err := VerifyChecksum(s)
+	if err != nil {
+		return Coordinate{}, 0, err
+	}
+	return ParseAny(text)
+}
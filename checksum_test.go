@@ -0,0 +1,60 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChecksumRoundTrip(t *testing.T) {
+	c := coordinateFromDecimal(40.446195, -79.948862)
+	s := c.StringChecksum()
+	if s != "402646.302N0795655.903W*2D" {
+		t.Errorf("StringChecksum = %q", s)
+	}
+	got, n, err := ParseChecksum(s)
+	if err != nil || n != NotationCompact || Distance(got, c) > 0.05 {
+		t.Errorf("ParseChecksum(%q) = %v, %v, %v", s, got, n, err)
+	}
+	if got, n, err := ParseChecksum(AppendChecksum("40.5, -79.9")); err != nil || n != NotationDecimal || got.Latitude.Degree != 40 {
+		t.Errorf("ParseChecksum of decimal text = %v, %v, %v", got, n, err)
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	s := AppendChecksum("GPGLL,4916.45,N")
+	lower := s[:len(s)-2] + strings.ToLower(s[len(s)-2:])
+	if text, err := VerifyChecksum(" " + lower + " "); err != nil || text != "GPGLL,4916.45,N" {
+		t.Errorf("VerifyChecksum = %q, %v", text, err)
+	}
+	tests := map[string]bool{
+		"402646.302N0795655.903W":     false,
+		"402646.302N0795655.903W*":    false,
+		"402646.302N0795655.903W*2":   false,
+		"402646.302N0795655.903W*245": false,
+		"402646.302N0795655.903W*ZZ":  false,
+		"402646.302N0795655.903W*2E":  true,
+		"402646.302N0795655.904W*2D":  true,
+	}
+	for s, mismatch := range tests {
+		_, err := VerifyChecksum(s)
+		if err == nil || errors.Is(err, ErrChecksumMismatch) != mismatch {
+			t.Errorf("VerifyChecksum(%q) = %v, want mismatch %v", s, err, mismatch)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+//go:build js && wasm
+
+// Command dms-wasm exposes the dms conversions to JavaScript, so browsers
+// format coordinates exactly like Go servers do. Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o dms.wasm ./cmd/dms-wasm
+//
+// and load it with wasm_exec.js. It registers a global "dms" object with:
+//
+//	dms.ParseCoordinate(text)          -> {latitude, longitude} or {error}
+//	dms.Format(lat, lon, options)      -> string or {error}
+//	dms.Convert(text)                  -> dms.Description fields or {error}
+//
+// The Format options object may set style ("symbols", "units", "words",
+// "phonetic", "ascii"), locale, precision (0 to 9 decimals of the seconds)
+// and fullDirection. Arguments of the wrong type, an unknown style or an
+// out-of-range precision are reported as errors.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"syscall/js"
+
+	"github.com/mshafiee/dms"
+)
+
+// errMissingArgument is returned when a function is called with too few arguments.
+var errMissingArgument = errors.New("Missing argument")
+
+// maxPrecision is the largest number of decimals of the seconds accepted by
+// Format, already finer than a millimeter on the ground.
+const maxPrecision = 9
+
+// styles maps style names accepted from JavaScript to format styles.
+var styles = map[string]dms.FormatStyle{
+	"symbols":  dms.StyleSymbols,
+	"units":    dms.StyleUnits,
+	"words":    dms.StyleWords,
+	"phonetic": dms.StylePhonetic,
+	"ascii":    dms.StyleASCII,
+}
+
+func main() {
+	js.Global().Set("dms", js.ValueOf(map[string]interface{}{
+		"ParseCoordinate": js.FuncOf(parseCoordinate),
+		"Format":          js.FuncOf(format),
+		"Convert":         js.FuncOf(convert),
+	}))
+	// Keep the functions available for the lifetime of the page.
+	select {}
+}
+
+// parseCoordinate implements dms.ParseCoordinate(text).
+func parseCoordinate(this js.Value, args []js.Value) interface{} {
+	c, err := parseArg(args)
+	if err != nil {
+		return errorValue(err)
+	}
+	lat, lon := c.Decimal()
+	return map[string]interface{}{"latitude": lat, "longitude": lon}
+}
+
+// format implements dms.Format(lat, lon, options).
+func format(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return errorValue(errMissingArgument)
+	}
+	if args[0].Type() != js.TypeNumber || args[1].Type() != js.TypeNumber {
+		return errorValue(errors.New("Latitude and longitude must be numbers"))
+	}
+	c, err := dms.NewCoordinate(args[0].Float(), args[1].Float())
+	if err != nil {
+		return errorValue(err)
+	}
+	opts := dms.DefaultFormatOptions()
+	if len(args) > 2 && args[2].Type() == js.TypeObject {
+		o := args[2]
+		if v := o.Get("style"); v.Type() == js.TypeString {
+			style, ok := styles[v.String()]
+			if !ok {
+				return errorValue(fmt.Errorf("Unknown style %q", v.String()))
+			}
+			opts.Style = style
+		}
+		if v := o.Get("locale"); v.Type() == js.TypeString {
+			opts.Locale = dms.Locale(v.String())
+		}
+		if v := o.Get("precision"); v.Type() == js.TypeNumber {
+			if p := v.Float(); p < 0 || p > maxPrecision || p != float64(int(p)) {
+				return errorValue(fmt.Errorf("Precision must be a whole number from 0 to %d", maxPrecision))
+			}
+			opts.Precision = v.Int()
+		}
+		if v := o.Get("fullDirection"); v.Type() == js.TypeBoolean {
+			opts.FullDirection = v.Bool()
+		}
+	}
+	return c.Format(opts)
+}
+
+// convert implements dms.Convert(text).
+func convert(this js.Value, args []js.Value) interface{} {
+	c, err := parseArg(args)
+	if err != nil {
+		return errorValue(err)
+	}
+	data, err := json.Marshal(dms.Describe(c))
+	if err != nil {
+		return errorValue(err)
+	}
+	return js.Global().Get("JSON").Call("parse", string(data))
+}
+
+// parseArg parses the coordinate text passed as the first argument.
+func parseArg(args []js.Value) (dms.Coordinate, error) {
+	if len(args) < 1 || args[0].Type() != js.TypeString {
+		return dms.Coordinate{}, errMissingArgument
+	}
+	return dms.ParseQRPayload(args[0].String())
+}
+
+// errorValue returns the JavaScript value reporting err.
+func errorValue(err error) interface{} {
+	return map[string]interface{}{"error": err.Error()}
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mshafiee/dms"
+)
+
+// runConvert implements "dms convert": it reads coordinates in any notation
+// accepted by dms.ParseAny, one per line, and writes them in the target
+// notation. Blank lines and lines starting with # are skipped. Lines that
+// cannot be converted are reported on stderr with their line number and left
+// out of the output. With -profile, the named profile of the -profiles file
+// sets the input and output notations and datums instead of -to. With -range
+// clamp or wrap, out-of-range values are fixed instead of reported.
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	input := flags.String("i", "-", "input `file`, - for stdin")
+	output := flags.String("o", "-", "output `file`, - for stdout")
+	to := flags.String("to", "dms", "target `notation`: "+formatterNames())
+	verbose := flags.Bool("v", false, "report the detected notation of every line on stderr")
+	profile := flags.String("profile", "", "conversion profile `name`, overriding -to")
+	profiles := flags.String("profiles", os.Getenv("DMS_PROFILES"), "profile `file`, $DMS_PROFILES by default")
+	rangePolicy := flags.String("range", "error", "out-of-range `policy`: error, clamp or wrap")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: dms convert [-i file] [-o file] [-to notation | -profile name] [-range policy] [-v]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	policy, err := dms.ParseRangePolicy(*rangePolicy)
+	if err != nil {
+		fmt.Fprintf(stderr, "dms convert: %v\n", err)
+		return 2
+	}
+	dms.SetRangePolicy(policy)
+	var pipeline dms.Pipeline
+	if *profile != "" {
+		p, err := dms.LoadProfile(*profiles, *profile)
+		if err == nil {
+			pipeline, err = p.Pipeline()
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "dms convert: %v\n", err)
+			return 2
+		}
+	} else {
+		format, ok := formatters[*to]
+		if !ok {
+			fmt.Fprintf(stderr, "dms convert: unknown notation %q, want one of %s\n", *to, formatterNames())
+			return 2
+		}
+		pipeline = dms.NewPipeline(dms.ParseStage(), dms.StageFunc(func(s *dms.PipelineState) error {
+			var err error
+			s.Output, err = format(s.Coordinate)
+			return err
+		}))
+	}
+
+	name, in := "<stdin>", stdin
+	if *input != "-" {
+		f, err := os.Open(*input)
+		if err != nil {
+			fmt.Fprintf(stderr, "dms convert: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		name, in = *input, f
+	}
+	out := stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "dms convert: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	status := 0
+	scanner := bufio.NewScanner(in)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		state := dms.PipelineState{Input: text}
+		if err := pipeline.Process(&state); err != nil {
+			fmt.Fprintf(stderr, "%s:%d: %v\n", name, line, err)
+			status = 1
+			continue
+		}
+		if *verbose {
+			fmt.Fprintf(stderr, "%s:%d: %s\n", name, line, state.Notation)
+		}
+		fmt.Fprintln(w, state.Output)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "dms convert: %v\n", err)
+		status = 1
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(stderr, "dms convert: %v\n", err)
+		status = 1
+	}
+	return status
+}
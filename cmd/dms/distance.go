@@ -0,0 +1,132 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mshafiee/dms"
+)
+
+// unitNames lists the symbols of the distance units accepted by -unit.
+const unitNames = "m, km, nmi, mi, ft"
+
+// runDistance implements "dms distance": it prints the distance between two
+// coordinates given in any notation accepted by dms.ParseAny.
+func runDistance(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("distance", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	method := flags.String("method", "haversine", "`formula`: haversine (sphere) or vincenty (ellipsoid)")
+	unit := flags.String("unit", "m", "output `unit`: "+unitNames)
+	precision := flags.Int("precision", 3, "number of `decimals`")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: dms distance [-method formula] [-unit unit] [-precision decimals] <from> <to>")
+		fmt.Fprintln(stderr, pairUsageNote)
+		flags.PrintDefaults()
+	}
+	from, to, ok := parsePair(flags, args, stderr)
+	if !ok {
+		return 2
+	}
+	u, err := dms.ParseDistanceUnit(*unit)
+	if err != nil {
+		fmt.Fprintf(stderr, "dms distance: %v, want one of %s\n", err, unitNames)
+		return 2
+	}
+	var distance float64
+	switch *method {
+	case "haversine":
+		distance = dms.Distance(from, to)
+	case "vincenty":
+		if distance, err = dms.VincentyDistance(from, to); err != nil {
+			fmt.Fprintf(stderr, "dms distance: %v\n", err)
+			return 1
+		}
+	default:
+		fmt.Fprintf(stderr, "dms distance: unknown method %q\n", *method)
+		return 2
+	}
+	fmt.Fprintf(stdout, "%.*f\n", max(*precision, 0), u.FromMeters(distance))
+	return 0
+}
+
+// runBearing implements "dms bearing": it prints the initial bearing, in
+// degrees clockwise from true north, from one coordinate to another.
+func runBearing(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("bearing", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	method := flags.String("method", "haversine", "`formula`: haversine (great circle) or vincenty (geodesic)")
+	final := flags.Bool("final", false, "also print the final bearing on arrival")
+	precision := flags.Int("precision", 2, "number of `decimals`")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: dms bearing [-method formula] [-final] [-precision decimals] <from> <to>")
+		fmt.Fprintln(stderr, pairUsageNote)
+		flags.PrintDefaults()
+	}
+	from, to, ok := parsePair(flags, args, stderr)
+	if !ok {
+		return 2
+	}
+	var initial, arrival float64
+	switch *method {
+	case "haversine":
+		initial = dms.Bearing(from, to)
+		arrival = dms.Normalize360(dms.Bearing(to, from) + 180)
+	case "vincenty":
+		var err error
+		if initial, arrival, err = dms.VincentyBearing(from, to); err != nil {
+			fmt.Fprintf(stderr, "dms bearing: %v\n", err)
+			return 1
+		}
+	default:
+		fmt.Fprintf(stderr, "dms bearing: unknown method %q\n", *method)
+		return 2
+	}
+	prec := max(*precision, 0)
+	if *final {
+		fmt.Fprintf(stdout, "%.*f %.*f\n", prec, initial, prec, arrival)
+	} else {
+		fmt.Fprintf(stdout, "%.*f\n", prec, initial)
+	}
+	return 0
+}
+
+// pairUsageNote explains how to pass coordinates that look like flags.
+const pairUsageNote = "Coordinates starting with - must follow a -- argument."
+
+// parsePair parses the flags and the two coordinate arguments of a command.
+// It reports errors on stderr and returns false on failure.
+func parsePair(flags *flag.FlagSet, args []string, stderr io.Writer) (from, to dms.Coordinate, ok bool) {
+	if err := flags.Parse(args); err != nil {
+		return from, to, false
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return from, to, false
+	}
+	var err error
+	if from, _, err = dms.ParseAny(flags.Arg(0)); err != nil {
+		fmt.Fprintf(stderr, "dms %s: from: %v\n", flags.Name(), err)
+		return from, to, false
+	}
+	if to, _, err = dms.ParseAny(flags.Arg(1)); err != nil {
+		fmt.Fprintf(stderr, "dms %s: to: %v\n", flags.Name(), err)
+		return from, to, false
+	}
+	return from, to, true
+}
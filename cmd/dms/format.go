@@ -0,0 +1,75 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mshafiee/dms"
+)
+
+// formatters render a coordinate in an output notation, by name.
+var formatters = map[string]func(c dms.Coordinate) (string, error){
+	"decimal": func(c dms.Coordinate) (string, error) {
+		lat, lon := c.Decimal()
+		return fmt.Sprintf("%.6f, %.6f", lat, lon), nil
+	},
+	"dms": func(c dms.Coordinate) (string, error) {
+		return c.String(), nil
+	},
+	"ddm": func(c dms.Coordinate) (string, error) {
+		return c.Latitude.StringDDM() + " " + c.Longitude.StringDDM(), nil
+	},
+	"compact": func(c dms.Coordinate) (string, error) {
+		return c.StringCompact(), nil
+	},
+	"utm": func(c dms.Coordinate) (string, error) {
+		u, err := c.UTM()
+		if err != nil {
+			return "", err
+		}
+		return u.String(), nil
+	},
+	"mgrs": func(c dms.Coordinate) (string, error) {
+		return c.MGRS()
+	},
+	"geohash": func(c dms.Coordinate) (string, error) {
+		return c.Geohash(9), nil
+	},
+	"maidenhead": func(c dms.Coordinate) (string, error) {
+		return c.Maidenhead(3), nil
+	},
+	"geo-uri": func(c dms.Coordinate) (string, error) {
+		return c.GeoURI(), nil
+	},
+	"all": func(c dms.Coordinate) (string, error) {
+		data, err := json.Marshal(dms.Describe(c))
+		return string(data), err
+	},
+}
+
+// formatterNames returns the names of the output notations, sorted.
+func formatterNames() string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+// Command dms converts geographic coordinates between notations.
+//
+// Usage:
+//
+//	dms <command> [flags] [arguments]
+//
+// The commands are:
+//
+//	bearing   print the bearing from one coordinate to another
+//	convert   convert a file of coordinates, one per line, to another notation
+//	distance  print the distance between two coordinates
+//	repl      parse and display coordinates interactively
+//
+// Run "dms <command> -h" for the flags of a command.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// command is a subcommand of the CLI. It returns the exit status.
+type command struct {
+	summary string
+	run     func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+// commands holds the subcommands by name.
+var commands = map[string]command{
+	"bearing":  {"print the bearing from one coordinate to another", runBearing},
+	"convert":  {"convert a file of coordinates to another notation", runConvert},
+	"distance": {"print the distance between two coordinates", runDistance},
+	"repl":     {"parse and display coordinates interactively", runRepl},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		if os.Args[1] != "-h" && os.Args[1] != "help" {
+			fmt.Fprintf(os.Stderr, "dms: unknown command %q\n", os.Args[1])
+		}
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	os.Exit(cmd.run(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// usage prints the list of commands.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: dms <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
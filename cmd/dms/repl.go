@@ -0,0 +1,153 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mshafiee/dms"
+)
+
+// replHelp lists the commands of the interactive mode.
+const replHelp = `Enter a coordinate in any notation to display it, or a command:
+  set-format <n>     show only notation n, or every notation with "all"
+  history            list the coordinates entered so far
+  !<n>               show entry n of the history again
+  help               show this help
+  quit               leave the interactive mode`
+
+// runRepl implements "dms repl": an interactive mode in which every entered
+// coordinate is parsed and displayed in all representations, or in the
+// notation chosen with the set-format command (or its short form, format).
+func runRepl(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("repl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "all", "initial output `notation`: "+formatterNames())
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	r := repl{out: stdout, format: "all"}
+	if msg := r.setFormat(*format); msg != "" {
+		fmt.Fprintln(stderr, "dms repl:", msg)
+		return 2
+	}
+	fmt.Fprintln(stdout, `Type "help" for the list of commands.`)
+	scanner := bufio.NewScanner(stdin)
+	for {
+		fmt.Fprint(stdout, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(stdout)
+			break
+		}
+		if !r.eval(strings.TrimSpace(scanner.Text())) {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(stderr, "dms repl:", err)
+		return 1
+	}
+	return 0
+}
+
+// repl holds the state of an interactive session.
+type repl struct {
+	out     io.Writer
+	format  string   // Output notation, or "all".
+	history []string // Coordinates entered so far.
+}
+
+// eval runs one line of input. It returns false when the session ends.
+func (r *repl) eval(line string) bool {
+	switch field, arg, _ := strings.Cut(line, " "); {
+	case line == "":
+	case line == "quit" || line == "exit":
+		return false
+	case line == "help":
+		fmt.Fprintln(r.out, replHelp)
+	case line == "history":
+		for i, entry := range r.history {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, entry)
+		}
+	case field == "format" || field == "set-format":
+		if msg := r.setFormat(strings.TrimSpace(arg)); msg != "" {
+			fmt.Fprintln(r.out, msg)
+		}
+	case strings.HasPrefix(line, "!"):
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 1 || n > len(r.history) {
+			fmt.Fprintf(r.out, "No history entry %q\n", line[1:])
+			break
+		}
+		fmt.Fprintln(r.out, r.history[n-1])
+		r.show(r.history[n-1])
+	default:
+		r.history = append(r.history, line)
+		r.show(line)
+	}
+	return true
+}
+
+// setFormat selects the output notation. It returns an error message when
+// the notation is unknown.
+func (r *repl) setFormat(name string) string {
+	if _, ok := formatters[name]; !ok {
+		return fmt.Sprintf("Unknown notation %q, want one of %s", name, formatterNames())
+	}
+	r.format = name
+	return ""
+}
+
+// show parses a coordinate and displays it in the selected notation, or in
+// every notation.
+func (r *repl) show(text string) {
+	c, notation, err := dms.ParseAny(text)
+	if err != nil {
+		fmt.Fprintln(r.out, "Error:", err)
+		return
+	}
+	if r.format != "all" {
+		s, err := formatters[r.format](c)
+		if err != nil {
+			fmt.Fprintln(r.out, "Error:", err)
+			return
+		}
+		fmt.Fprintln(r.out, s)
+		return
+	}
+	d := dms.Describe(c)
+	w := tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "notation\t%s\n", notation)
+	fmt.Fprintf(w, "decimal\t%.7f, %.7f\n", d.Latitude, d.Longitude)
+	if d.Accuracy > 0 {
+		fmt.Fprintf(w, "accuracy\t%.1f m\n", d.Accuracy)
+	}
+	for _, row := range [][2]string{
+		{"dms", d.DMS}, {"ddm", d.DDM}, {"compact", d.Compact}, {"utm", d.UTM}, {"mgrs", d.MGRS},
+		{"geohash", d.Geohash}, {"maidenhead", d.Maidenhead}, {"geo-uri", d.GeoURI},
+	} {
+		if row[1] != "" {
+			fmt.Fprintf(w, "%s\t%s\n", row[0], row[1])
+		}
+	}
+	w.Flush()
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+// Command libdms builds the dms conversions as a C shared library, so
+// applications in other languages reuse the exact same parsing and
+// formatting. Build it with:
+//
+//	go build -buildmode=c-shared -o libdms.so ./cmd/libdms
+//
+// which also writes the libdms.h header. Strings returned by the library are
+// allocated with malloc and must be released with dms_free. Functions that
+// can fail return 0 on success and -1 on failure, storing a message in *err
+// when err is not NULL. Passing NULL for any other pointer argument is a
+// failure.
+//
+// The style argument of dms_format is one of the DMS_STYLE constants of the
+// header, matching dms.FormatStyle: DMS_STYLE_SYMBOLS (0), DMS_STYLE_UNITS
+// (1), DMS_STYLE_WORDS (2), DMS_STYLE_PHONETIC (3) and DMS_STYLE_ASCII (4).
+// Its precision is 0 to 9 decimals of the seconds. Other values fail.
+package main
+
+/*
+#include <stdlib.h>
+
+enum {
+	DMS_STYLE_SYMBOLS,
+	DMS_STYLE_UNITS,
+	DMS_STYLE_WORDS,
+	DMS_STYLE_PHONETIC,
+	DMS_STYLE_ASCII
+};
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/mshafiee/dms"
+)
+
+func main() {}
+
+// errNullArgument is returned for a NULL input or output pointer.
+var errNullArgument = errors.New("NULL argument")
+
+// maxPrecision is the largest number of decimals of the seconds accepted by
+// dms_format, already finer than a millimeter on the ground.
+const maxPrecision = 9
+
+//export dms_parse
+func dms_parse(text *C.char, lat, lon *C.double, err **C.char) C.int {
+	if text == nil || lat == nil || lon == nil {
+		return fail(err, errNullArgument)
+	}
+	c, parseErr := dms.ParseQRPayload(C.GoString(text))
+	if parseErr != nil {
+		return fail(err, parseErr)
+	}
+	goLat, goLon := c.Decimal()
+	*lat, *lon = C.double(goLat), C.double(goLon)
+	return 0
+}
+
+//export dms_format
+func dms_format(lat, lon C.double, style, precision C.int, out, err **C.char) C.int {
+	switch {
+	case out == nil:
+		return fail(err, errNullArgument)
+	case style < C.DMS_STYLE_SYMBOLS || style > C.DMS_STYLE_ASCII:
+		return fail(err, fmt.Errorf("Unknown style %d", int(style)))
+	case precision < 0 || precision > maxPrecision:
+		return fail(err, fmt.Errorf("Precision must be from 0 to %d", maxPrecision))
+	}
+	c, formatErr := dms.NewCoordinate(float64(lat), float64(lon))
+	if formatErr != nil {
+		return fail(err, formatErr)
+	}
+	opts := dms.DefaultFormatOptions()
+	opts.Style = dms.FormatStyle(style)
+	opts.Precision = int(precision)
+	*out = C.CString(c.Format(opts))
+	return 0
+}
+
+//export dms_convert
+func dms_convert(text *C.char, out, err **C.char) C.int {
+	if text == nil || out == nil {
+		return fail(err, errNullArgument)
+	}
+	c, parseErr := dms.ParseQRPayload(C.GoString(text))
+	if parseErr != nil {
+		return fail(err, parseErr)
+	}
+	data, _ := json.Marshal(dms.Describe(c))
+	*out = C.CString(string(data))
+	return 0
+}
+
+//export dms_free
+func dms_free(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+// fail stores the message of e in *err, when err is not NULL, and returns -1.
+func fail(err **C.char, e error) C.int {
+	if err != nil {
+		*err = C.CString(e.Error())
+	}
+	return -1
+}
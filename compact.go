@@ -0,0 +1,99 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Compact fixed-width format
+//
+// The compact format "DDMMSS.sssH" (with three degree digits for longitude)
+// contains only ASCII digits, a decimal point and the direction letter, as
+// expected by many meteorological and maritime systems.
+
+// compactPattern matches a single value in the compact format.
+var compactPattern = regexp.MustCompile(`^(\d{2,3})(\d{2})(\d{2}(?:\.\d+)?)([NSEW])$`)
+
+// StringCompact returns the DMS in the fixed-width "DDMMSS.sssH" format for
+// latitude or "DDDMMSS.sssH" for longitude.
+func (d *DMS) StringCompact() string {
+	width := 2
+	if d.Direction == "E" || d.Direction == "W" {
+		width = 3
+	}
+	// Round to whole milliseconds of arc, carrying into minutes and degrees.
+	millis := uint(math.Round(d.Seconds * 1000))
+	minutes, degree := d.Minutes+millis/60000, d.Degree
+	millis %= 60000
+	degree += minutes / 60
+	minutes %= 60
+	return fmt.Sprintf("%0*d%02d%02d.%03d%s", width, degree, minutes, millis/1000, millis%1000, d.Direction)
+}
+
+// ParseCompact parses a value in the compact "DDMMSS.sssH" or "DDDMMSS.sssH"
+// format. The degree width must match the axis of the direction letter.
+func ParseCompact(s string) (DMS, error) {
+	m := compactPattern.FindStringSubmatch(s)
+	if m == nil {
+		return DMS{}, fmt.Errorf("Invalid compact DMS value %q", s)
+	}
+	latitude := m[4] == "N" || m[4] == "S"
+	if latitude != (len(m[1]) == 2) {
+		return DMS{}, fmt.Errorf("Invalid degree width in compact DMS value %q", s)
+	}
+	degree, _ := strconv.ParseUint(m[1], 10, 32)
+	minutes, _ := strconv.ParseUint(m[2], 10, 32)
+	seconds, _ := strconv.ParseFloat(m[3], 64)
+	result := DMS{Degree: uint(degree), Minutes: uint(minutes), Seconds: seconds, Direction: m[4]}
+	if err := result.Validate(); err != nil {
+		return DMS{}, err
+	}
+	return result, nil
+}
+
+// StringCompact returns the coordinate as a compact latitude immediately
+// followed by a compact longitude, e.g. "402646.302N0795655.903W".
+func (c *Coordinate) StringCompact() string {
+	return c.Latitude.StringCompact() + c.Longitude.StringCompact()
+}
+
+// ParseCompactCoordinate parses a compact latitude followed by a compact
+// longitude, optionally separated by whitespace.
+func ParseCompactCoordinate(s string) (Coordinate, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexAny(s, "NS")
+	if i < 0 {
+		return Coordinate{}, fmt.Errorf("Invalid compact coordinate %q", s)
+	}
+	lat, err := ParseCompact(s[:i+1])
+	if err != nil {
+		return Coordinate{}, err
+	}
+	lon, err := ParseCompact(strings.TrimSpace(s[i+1:]))
+	if err != nil {
+		return Coordinate{}, err
+	}
+	coord := Coordinate{Latitude: lat, Longitude: lon}
+	if err := coord.Validate(); err != nil {
+		return Coordinate{}, err
+	}
+	return coord, nil
+}
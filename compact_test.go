@@ -0,0 +1,98 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompactRoundTrip(t *testing.T) {
+	tests := []struct {
+		c    Coordinate
+		want string
+	}{
+		{coordinateFromDecimal(40.446195, -79.948862), "402646.302N0795655.903W"},
+		{coordinateFromDecimal(-33.8568, 151.2153), "335124.480S1511255.080E"},
+		{coordinateFromDecimal(0, 0), "000000.000N0000000.000E"},
+		{coordinateFromDecimal(-90, -180), "900000.000S1800000.000W"},
+		{Coordinate{DMS{1, 59, 59.9999, "N"}, DMS{2, 59, 59.9996, "E"}, 0}, "020000.000N0030000.000E"},
+	}
+	for _, tt := range tests {
+		s := tt.c.StringCompact()
+		if s != tt.want {
+			t.Errorf("StringCompact(%v) = %q, want %q", tt.c, s, tt.want)
+		}
+		got, err := ParseCompactCoordinate(s)
+		if err != nil {
+			t.Errorf("ParseCompactCoordinate(%q): %v", s, err)
+			continue
+		}
+		if d := Distance(got, tt.c); d > 0.05 {
+			t.Errorf("ParseCompactCoordinate(%q) is %g m from %v", s, d, tt.c)
+		}
+	}
+}
+
+func TestParseCompact(t *testing.T) {
+	tests := []struct {
+		input string
+		want  DMS
+	}{
+		{"402646N", DMS{40, 26, 46, "N"}},
+		{"402646.3N", DMS{40, 26, 46.3, "N"}},
+		{"0795655.903W", DMS{79, 56, 55.903, "W"}},
+		{"1800000E", DMS{180, 0, 0, "E"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCompact(tt.input)
+		if err != nil || got.Degree != tt.want.Degree || got.Minutes != tt.want.Minutes ||
+			math.Abs(got.Seconds-tt.want.Seconds) > 1e-9 || got.Direction != tt.want.Direction {
+			t.Errorf("ParseCompact(%q) = %+v, %v, want %+v", tt.input, got, err, tt.want)
+		}
+	}
+	if c, err := ParseCompactCoordinate(" 402646N 0795656W "); err != nil || c.Longitude.Degree != 79 {
+		t.Errorf("ParseCompactCoordinate with spaces = %+v, %v", c, err)
+	}
+}
+
+func TestParseCompactMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"4026N",
+		"402646",
+		"402646X",
+		"402646n",
+		"402646.N",
+		"0402646N",
+		"795656W",
+		"406046N",
+		"402660N",
+		"1800001E",
+		"910000N",
+		"40 26 46N",
+		"-402646N",
+	} {
+		if d, err := ParseCompact(s); err == nil {
+			t.Errorf("ParseCompact(%q) = %+v, want error", s, d)
+		}
+	}
+	for _, s := range []string{"", "402646N", "0795656W402646N", "402646N795656W", "402646N0795656N", "402646N0795656WX"} {
+		if c, err := ParseCompactCoordinate(s); err == nil {
+			t.Errorf("ParseCompactCoordinate(%q) = %+v, want error", s, c)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"math"
+)
+
+// Coordinate represents a geographical position as a pair of DMS values.
+// The zero value is an unset position, which IsZero tells apart from the
+// position at 0° N 0° E returned by NewCoordinate(0, 0).
+type Coordinate struct {
+	Latitude  DMS     // Latitude part of the position (N, S).
+	Longitude DMS     // Longitude part of the position (E, W).
+	Accuracy  float64 // Horizontal accuracy radius in meters, zero when unknown.
+}
+
+// NewCoordinate creates a new Coordinate for given latitude and longitude.
+func NewCoordinate(lat, lon float64) (Coordinate, error) {
+	latDMS, lonDMS, err := NewDMS(lat, lon)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	return Coordinate{Latitude: latDMS, Longitude: lonDMS}, nil
+}
+
+// String returns the coordinate in an LTR representation.
+func (c *Coordinate) String() string {
+	return c.Latitude.String() + " " + c.Longitude.String()
+}
+
+// Decimal returns the signed decimal latitude and longitude of the coordinate.
+func (c *Coordinate) Decimal() (lat, lon float64) {
+	return signedDecimal(c.Latitude), signedDecimal(c.Longitude)
+}
+
+// IsZero reports whether the coordinate is the zero value, i.e. unset. A
+// position at 0° N 0° E is not zero, as its values have directions.
+func (c *Coordinate) IsZero() bool {
+	return c.Latitude.IsZero() && c.Longitude.IsZero() && c.Accuracy == 0
+}
+
+// IsNullIsland reports whether the coordinate is set to exactly 0° N 0° E, a
+// position often produced by defaulting missing values to zero.
+func (c *Coordinate) IsNullIsland() bool {
+	lat, lon := c.Decimal()
+	return !c.IsZero() && lat == 0 && lon == 0
+}
+
+// Validate checks that the latitude and longitude are valid and lie on the
+// expected axes.
+func (c *Coordinate) Validate() error {
+	if err := c.Latitude.ValidateAs(AxisLatitude); err != nil {
+		return err
+	}
+	if err := c.Longitude.ValidateAs(AxisLongitude); err != nil {
+		return err
+	}
+	if c.Accuracy < 0 || math.IsNaN(c.Accuracy) {
+		return errors.New("Invalid accuracy value")
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CSV schemas
+
+// CSVSchema tells which columns of a CSV file hold the fields of waypoints.
+// Column indices start at 0; -1 marks an absent column.
+type CSVSchema struct {
+	Header      bool // Whether the first row is a header row.
+	Latitude    int  // Latitude column, decimal degrees or DMS.
+	Longitude   int  // Longitude column, decimal degrees or DMS.
+	Name        int  // Name column.
+	Symbol      int  // Symbol column.
+	Description int  // Description column.
+	Time        int  // RFC 3339 time column.
+}
+
+// NewCSVSchema returns a schema with a header row and only the latitude and
+// longitude columns, for overriding the inferred layout of a file.
+func NewCSVSchema(lat, lon int) CSVSchema {
+	return CSVSchema{Header: true, Latitude: lat, Longitude: lon, Name: -1, Symbol: -1, Description: -1, Time: -1}
+}
+
+// csvInferRows is the number of rows read to infer a schema.
+const csvInferRows = 20
+
+// csvColumnNames maps lowercase header names to the fields they name.
+var csvColumnNames = map[string]string{
+	"latitude": "latitude", "lat": "latitude", "lat_dd": "latitude", "y": "latitude", "breite": "latitude",
+	"longitude": "longitude", "lon": "longitude", "long": "longitude", "lng": "longitude", "lon_dd": "longitude",
+	"x": "longitude", "länge": "longitude", "laenge": "longitude",
+	"name": "name", "title": "name", "label": "name",
+	"symbol": "symbol", "sym": "symbol", "icon": "symbol",
+	"description": "description", "desc": "description", "comment": "description", "notes": "description",
+	"time": "time", "timestamp": "time", "datetime": "time",
+}
+
+// column returns the column index of the named field.
+func (s *CSVSchema) column(field string) *int {
+	switch field {
+	case "latitude":
+		return &s.Latitude
+	case "longitude":
+		return &s.Longitude
+	case "name":
+		return &s.Name
+	case "symbol":
+		return &s.Symbol
+	case "description":
+		return &s.Description
+	}
+	return &s.Time
+}
+
+// InferCSVSchema infers the schema of a CSV file from its first rows. Columns
+// are recognized by header names such as "lat", "lng" or "longitude". When
+// the names do not identify both axes, the latitude and longitude columns are
+// chosen among the columns whose values all read as decimal degrees or DMS:
+// by their direction letters, by values beyond ±90° that only longitudes
+// take, and otherwise by their order, columns of record numbers last. A first
+// row with two values that read as coordinates is taken as data rather than
+// a header.
+func InferCSVSchema(rows [][]string) (CSVSchema, error) {
+	if len(rows) == 0 {
+		return CSVSchema{}, errors.New("Empty CSV file")
+	}
+	schema := NewCSVSchema(-1, -1)
+	schema.Header = !csvRowIsData(rows[0])
+	if schema.Header {
+		for i, name := range rows[0] {
+			if field, ok := csvColumnNames[strings.ToLower(strings.TrimSpace(name))]; ok && *schema.column(field) < 0 {
+				*schema.column(field) = i
+			}
+		}
+		rows = rows[1:]
+	}
+	if schema.Latitude >= 0 && schema.Longitude >= 0 {
+		return schema, nil
+	}
+
+	// Fall back to the value ranges of the columns.
+	var candidates []int
+	axes := map[int]Axis{}
+	for col := 0; len(rows) > 0 && col < len(rows[0]); col++ {
+		axis, ok := csvColumnAxis(rows, col)
+		if ok && col != schema.Latitude && col != schema.Longitude {
+			candidates = append(candidates, col)
+			axes[col] = axis
+		}
+	}
+	for _, col := range candidates {
+		switch {
+		case axes[col] == AxisLatitude && schema.Latitude < 0:
+			schema.Latitude = col
+		case axes[col] == AxisLongitude && schema.Longitude < 0:
+			schema.Longitude = col
+		}
+	}
+	// Columns of record numbers are more likely identifiers than positions,
+	// so they are taken last.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return !csvColumnIsCounter(rows, candidates[i]) && csvColumnIsCounter(rows, candidates[j])
+	})
+	for _, col := range candidates {
+		if axes[col] != AxisUnknown || col == schema.Latitude || col == schema.Longitude {
+			continue
+		}
+		switch {
+		case schema.Latitude < 0:
+			schema.Latitude = col
+		case schema.Longitude < 0:
+			schema.Longitude = col
+		}
+	}
+	if schema.Latitude < 0 || schema.Longitude < 0 {
+		return CSVSchema{}, errors.New("Cannot find the latitude and longitude columns")
+	}
+	return schema, nil
+}
+
+// csvColumnAxis reports whether all values of a column read as coordinates,
+// and the axis they must belong to: latitude or longitude when a direction
+// says so, longitude when a value exceeds ±90°, and AxisUnknown otherwise.
+func csvColumnAxis(rows [][]string, col int) (Axis, bool) {
+	axis := AxisUnknown
+	for _, row := range rows {
+		if col >= len(row) {
+			return AxisUnknown, false
+		}
+		value, valueAxis, ok := csvValue(row[col])
+		if !ok {
+			return AxisUnknown, false
+		}
+		if valueAxis == AxisUnknown && value > 90 {
+			valueAxis = AxisLongitude
+		}
+		if valueAxis != AxisUnknown {
+			if axis != AxisUnknown && axis != valueAxis {
+				return AxisUnknown, false
+			}
+			axis = valueAxis
+		}
+	}
+	return axis, true
+}
+
+// csvColumnIsCounter reports whether the values of a column are increasing
+// non-negative integers, as in a column of record numbers.
+func csvColumnIsCounter(rows [][]string, col int) bool {
+	last := -1
+	for _, row := range rows {
+		n, err := strconv.Atoi(strings.TrimSpace(row[col]))
+		if err != nil || n <= last {
+			return false
+		}
+		last = n
+	}
+	return true
+}
+
+// csvRowIsData reports whether a row holds at least two numeric or DMS
+// values, which a header row does not.
+func csvRowIsData(row []string) bool {
+	values := 0
+	for _, cell := range row {
+		if _, _, ok := csvValue(cell); ok {
+			values++
+		}
+	}
+	return values >= 2
+}
+
+// csvValue parses a cell holding decimal degrees or a DMS value, returning
+// its magnitude and the axis given by its direction, if any.
+func csvValue(cell string) (float64, Axis, bool) {
+	cell = strings.TrimSpace(cell)
+	if v, err := strconv.ParseFloat(cell, 64); err == nil {
+		return math.Abs(v), AxisUnknown, v >= -180 && v <= 180
+	}
+	d, err := ParseDMS(cell)
+	if err != nil {
+		return 0, AxisUnknown, false
+	}
+	return DMSToDecimal(d), d.Axis(), true
+}
+
+// csvAxisValue parses a cell of the given axis holding decimal degrees or a
+// DMS value of that axis, returning its signed value.
+func csvAxisValue(cell string, axis Axis) (float64, error) {
+	cell = strings.TrimSpace(cell)
+	if v, err := strconv.ParseFloat(cell, 64); err == nil {
+		return v, nil
+	}
+	d, err := ParseDMS(cell)
+	if err != nil || d.Axis() != axis {
+		return 0, fmt.Errorf("Invalid %s %q", axis, cell)
+	}
+	return signedDecimal(d), nil
+}
+
+// waypoint creates a waypoint from the cells of a record laid out as the
+// schema, with coordinates in decimal degrees or DMS.
+func (s *CSVSchema) waypoint(record []string) (Waypoint, error) {
+	field := func(i int) string {
+		if i >= 0 && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+	lat, err := csvAxisValue(field(s.Latitude), AxisLatitude)
+	if err != nil {
+		return Waypoint{}, err
+	}
+	lon, err := csvAxisValue(field(s.Longitude), AxisLongitude)
+	if err != nil {
+		return Waypoint{}, err
+	}
+	wp, err := NewWaypoint(field(s.Name), lat, lon)
+	if err != nil {
+		return Waypoint{}, err
+	}
+	wp.Symbol, wp.Description = field(s.Symbol), field(s.Description)
+	if wp.Time, err = parseWaypointTime(field(s.Time)); err != nil {
+		return Waypoint{}, err
+	}
+	return wp, nil
+}
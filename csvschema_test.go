@@ -0,0 +1,86 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+// csvRows returns the rows of CSV text.
+func csvRows(t *testing.T, text string) [][]string {
+	t.Helper()
+	rows, err := csv.NewReader(strings.NewReader(text)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestInferCSVSchema(t *testing.T) {
+	tests := []struct {
+		text   string
+		header bool
+		lat    int
+		lon    int
+		name   int
+	}{
+		{"id,lat,lng,name\n1,40.5,-79.5,A\n", true, 1, 2, 3},
+		{"Label,Breite,Länge\nA,40.5,-79.5\n", true, 1, 2, 0},
+		{"id,foo,bar\n1,40.5,-79.5\n2,41,-80\n", true, 1, 2, -1},
+		{"-100.5,40.5\n-79.5,41\n", false, 1, 0, -1},
+		{`"79°56'55"" W","40°26'46"" N"` + "\n" + `"80°0'0"" W","41°0'0"" N"` + "\n", false, 1, 0, -1},
+		{"name,value,y\nA,-120.5,40.5\n", true, 2, 1, 0},
+	}
+	for _, tt := range tests {
+		s, err := InferCSVSchema(csvRows(t, tt.text))
+		if err != nil {
+			t.Errorf("InferCSVSchema(%q) error: %v", tt.text, err)
+			continue
+		}
+		if s.Header != tt.header || s.Latitude != tt.lat || s.Longitude != tt.lon || s.Name != tt.name {
+			t.Errorf("InferCSVSchema(%q) = %+v, want header %v, columns %d, %d, name %d",
+				tt.text, s, tt.header, tt.lat, tt.lon, tt.name)
+		}
+	}
+	for _, text := range []string{"", "a,b\nx,y\n", "lat,name\n40,A\n", "a,b\n200,1\n"} {
+		if s, err := InferCSVSchema(csvRows(t, text)); err == nil {
+			t.Errorf("InferCSVSchema(%q) = %+v, want error", text, s)
+		}
+	}
+}
+
+func TestReadWaypointsCSVWithSchema(t *testing.T) {
+	const text = "station,north,east\nA,40.5,-79.5\n" + `B,"41°0'0"" N","80°30'0"" W"` + "\n"
+	schema := NewCSVSchema(1, 2)
+	schema.Name = 0
+	waypoints, err := ReadWaypointsCSVWithSchema(strings.NewReader(text), schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Waypoint{
+		{Coordinate: coordinateFromDecimal(40.5, -79.5), Name: "A"},
+		{Coordinate: coordinateFromDecimal(41, -80.5), Name: "B"},
+	}
+	checkWaypoints(t, "CSV", waypoints, want)
+
+	// A DMS value of the wrong axis is rejected.
+	const swapped = "station,north,east\n" + `A,"80°30'0"" W","41°0'0"" N"` + "\n"
+	if _, err := ReadWaypointsCSVWithSchema(strings.NewReader(swapped), schema); err == nil {
+		t.Errorf("ReadWaypointsCSVWithSchema(%q) succeeded, want error", swapped)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Geodetic datums
+
+// Ellipsoid is a reference ellipsoid.
+type Ellipsoid struct {
+	Name string
+	A    float64 // Semi-major axis in meters.
+	F    float64 // Flattening.
+}
+
+// Reference ellipsoids.
+var (
+	EllipsoidWGS84             = Ellipsoid{"WGS 84", wgs84A, wgs84F}
+	EllipsoidGRS80             = Ellipsoid{"GRS 1980", 6378137, 1 / 298.257222101}
+	EllipsoidAiry1830          = Ellipsoid{"Airy 1830", 6377563.396, 1 / 299.3249646}
+	EllipsoidClarke1866        = Ellipsoid{"Clarke 1866", 6378206.4, 1 / 294.9786982}
+	EllipsoidInternational1924 = Ellipsoid{"International 1924", 6378388, 1.0 / 297}
+	EllipsoidBessel1841        = Ellipsoid{"Bessel 1841", 6377397.155, 1 / 299.1528128}
+)
+
+// Datum is a geodetic datum: a reference ellipsoid and the seven-parameter
+// Helmert transformation (position vector convention) from the datum to
+// WGS 84.
+type Datum struct {
+	Name       string
+	Ellipsoid  Ellipsoid
+	Tx, Ty, Tz float64 // Translations in meters.
+	Rx, Ry, Rz float64 // Rotations in arc seconds.
+	Scale      float64 // Scale change in parts per million.
+}
+
+// Common datums. Their transformations to WGS 84 are regional averages,
+// accurate to a few meters.
+var (
+	DatumWGS84  = Datum{Name: "WGS 84", Ellipsoid: EllipsoidWGS84}
+	DatumETRS89 = Datum{Name: "ETRS89", Ellipsoid: EllipsoidGRS80}
+	DatumNAD83  = Datum{Name: "NAD83", Ellipsoid: EllipsoidGRS80}
+	DatumNAD27  = Datum{Name: "NAD27", Ellipsoid: EllipsoidClarke1866, Tx: -8, Ty: 160, Tz: 176}
+	DatumED50   = Datum{Name: "ED50", Ellipsoid: EllipsoidInternational1924, Tx: -87, Ty: -98, Tz: -121}
+	DatumOSGB36 = Datum{Name: "OSGB36", Ellipsoid: EllipsoidAiry1830,
+		Tx: 446.448, Ty: -125.157, Tz: 542.060, Rx: 0.1502, Ry: 0.2470, Rz: 0.8421, Scale: -20.4894}
+	DatumTokyo = Datum{Name: "Tokyo", Ellipsoid: EllipsoidBessel1841, Tx: -146.414, Ty: 507.337, Tz: 680.507}
+)
+
+// datums lists the datums known by DatumByName.
+var datums = []*Datum{&DatumWGS84, &DatumETRS89, &DatumNAD83, &DatumNAD27, &DatumED50, &DatumOSGB36, &DatumTokyo}
+
+// DatumByName returns the common datum with the given name, ignoring case,
+// spaces and hyphens, e.g. "wgs84" or "OSGB36".
+func DatumByName(name string) (*Datum, error) {
+	key := datumKey(name)
+	for _, d := range datums {
+		if datumKey(d.Name) == key {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown datum %q", name)
+}
+
+// datumKey returns the datum name normalized for lookups.
+func datumKey(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+}
+
+// arcSecond is one second of arc in radians.
+const arcSecond = math.Pi / 648000
+
+// ShiftDatum converts a coordinate from one datum to another through WGS 84.
+// Heights are taken as zero on the source ellipsoid and the accuracy is kept.
+func ShiftDatum(c Coordinate, from, to *Datum) Coordinate {
+	if *from == *to {
+		return c
+	}
+	lat, lon := c.radians()
+	x, y, z := geodeticToECEF(lat, lon, 0, &from.Ellipsoid)
+	x, y, z = from.helmert(x, y, z, 1)
+	x, y, z = to.helmert(x, y, z, -1)
+	lat, lon, _ = ecefToGeodetic(x, y, z, &to.Ellipsoid)
+	result := coordinateFromDecimal(lat/degToRad, lon/degToRad)
+	result.Accuracy = c.Accuracy
+	return result
+}
+
+// helmert applies the transformation of the datum to WGS 84 to geocentric
+// coordinates, or its inverse when sign is -1. The small-angle approximation
+// makes negating the parameters an accurate inverse.
+func (d *Datum) helmert(x, y, z, sign float64) (float64, float64, float64) {
+	rx, ry, rz := sign*d.Rx*arcSecond, sign*d.Ry*arcSecond, sign*d.Rz*arcSecond
+	s := 1 + sign*d.Scale*1e-6
+	return sign*d.Tx + s*(x-rz*y+ry*z),
+		sign*d.Ty + s*(rz*x+y-rx*z),
+		sign*d.Tz + s*(-ry*x+rx*y+z)
+}
+
+// geodeticToECEF converts a geodetic position in radians and height in
+// meters to Earth-centered, Earth-fixed coordinates in meters.
+func geodeticToECEF(lat, lon, height float64, e *Ellipsoid) (x, y, z float64) {
+	e2 := e.F * (2 - e.F)
+	sinLat, cosLat := math.Sincos(lat)
+	n := e.A / math.Sqrt(1-e2*sinLat*sinLat)
+	return (n + height) * cosLat * math.Cos(lon),
+		(n + height) * cosLat * math.Sin(lon),
+		(n*(1-e2) + height) * sinLat
+}
+
+// ecefToGeodetic converts Earth-centered, Earth-fixed coordinates in meters
+// to a geodetic position in radians and height in meters, using Bowring's
+// method.
+func ecefToGeodetic(x, y, z float64, e *Ellipsoid) (lat, lon, height float64) {
+	e2 := e.F * (2 - e.F)
+	b := e.A * (1 - e.F)
+	ep2 := e2 / (1 - e2)
+	p := math.Hypot(x, y)
+	theta := math.Atan2(z*e.A, p*b)
+	sinTheta, cosTheta := math.Sincos(theta)
+	lat = math.Atan2(z+ep2*b*sinTheta*sinTheta*sinTheta, p-e2*e.A*cosTheta*cosTheta*cosTheta)
+	lon = math.Atan2(y, x)
+	sinLat, cosLat := math.Sincos(lat)
+	n := e.A / math.Sqrt(1-e2*sinLat*sinLat)
+	if math.Abs(cosLat) > 1e-12 {
+		height = p/cosLat - n
+	} else {
+		height = math.Abs(z) - b
+	}
+	return lat, lon, height
+}
@@ -0,0 +1,43 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"time"
+)
+
+// Estimate is a position estimate with a circular uncertainty.
+type Estimate struct {
+	Coordinate  Coordinate // Estimated position.
+	Uncertainty float64    // Radius of the uncertainty circle in meters.
+}
+
+// DeadReckon propagates a last-known position along a great circle, given a
+// speed in meters per second, a heading in degrees from true north and the
+// elapsed time. The uncertainty starts at the accuracy of the last position
+// and grows with the distance run by drift, the expected fractional error of
+// speed and heading (e.g. 0.05 for 5%). It is also set as the accuracy of the
+// estimated coordinate.
+func DeadReckon(last Coordinate, speed, heading float64, elapsed time.Duration, drift float64) Estimate {
+	distance := speed * elapsed.Seconds()
+	e := Estimate{
+		Coordinate:  Destination(last, heading, distance),
+		Uncertainty: last.Accuracy + math.Abs(distance*drift),
+	}
+	e.Coordinate.Accuracy = e.Uncertainty
+	return e
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "math"
+
+// Duplicate detection
+
+// Dedupe returns the coordinates without near duplicates: a coordinate closer
+// than tolerance meters to an earlier kept coordinate is dropped. The order
+// of the kept coordinates is preserved.
+func Dedupe(coords []Coordinate, tolerance float64) []Coordinate {
+	clusters := clusterNear(coords, tolerance)
+	result := make([]Coordinate, len(clusters))
+	for i, members := range clusters {
+		result[i] = coords[members[0]]
+	}
+	return result
+}
+
+// DedupeCentroids merges near duplicates like Dedupe, but replaces each kept
+// coordinate with the centroid of the coordinates merged into it. The
+// centroid is computed on the sphere, so clusters across the antimeridian
+// are merged correctly.
+func DedupeCentroids(coords []Coordinate, tolerance float64) []Coordinate {
+	clusters := clusterNear(coords, tolerance)
+	result := make([]Coordinate, len(clusters))
+	for i, members := range clusters {
+		if len(members) == 1 {
+			result[i] = coords[members[0]]
+			continue
+		}
+		group := make([]Coordinate, len(members))
+		for j, m := range members {
+			group[j] = coords[m]
+		}
+		result[i] = Centroid(group)
+	}
+	return result
+}
+
+// Centroid returns the geographic center of the coordinates: the average of
+// their positions as unit vectors, projected back onto the sphere. It returns
+// the zero Coordinate for an empty slice.
+func Centroid(coords []Coordinate) Coordinate {
+	if len(coords) == 0 {
+		return Coordinate{}
+	}
+	var x, y, z float64
+	for i := range coords {
+		lat, lon := coords[i].radians()
+		x += math.Cos(lat) * math.Cos(lon)
+		y += math.Cos(lat) * math.Sin(lon)
+		z += math.Sin(lat)
+	}
+	lat := math.Atan2(z, math.Hypot(x, y))
+	lon := math.Atan2(y, x)
+	return coordinateFromDecimal(lat/degToRad, lon/degToRad)
+}
+
+// clusterNear groups the indices of coordinates: each coordinate joins the
+// first group whose first member lies within tolerance meters, or starts a
+// new group. Groups are returned in the order of their first member.
+func clusterNear(coords []Coordinate, tolerance float64) [][]int {
+	var clusters [][]int
+	for i := range coords {
+		joined := false
+		for j := range clusters {
+			if Distance(coords[clusters[j][0]], coords[i]) < tolerance {
+				clusters[j] = append(clusters[j], i)
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			clusters = append(clusters, []int{i})
+		}
+	}
+	return clusters
+}
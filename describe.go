@@ -0,0 +1,66 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "time"
+
+// Geohash and Maidenhead lengths used by Describe.
+const (
+	describeGeohashLength   = 9 // About 5 m cells.
+	describeMaidenheadPairs = 3 // The common 6-character locator.
+)
+
+// Description holds a coordinate in every supported representation. Its JSON
+// field names are stable, so it can be used as a machine-readable report.
+// Representations that are undefined for the coordinate, like UTM and MGRS
+// near the poles, are left empty.
+type Description struct {
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Accuracy   float64 `json:"accuracy,omitempty"`
+	DMS        string  `json:"dms"`
+	DDM        string  `json:"ddm"`
+	Compact    string  `json:"compact"`
+	UTM        string  `json:"utm,omitempty"`
+	MGRS       string  `json:"mgrs,omitempty"`
+	Geohash    string  `json:"geohash"`
+	Maidenhead string  `json:"maidenhead"`
+	GeoURI     string  `json:"geo_uri"`
+}
+
+// Describe returns the Description of a coordinate.
+func Describe(c Coordinate) Description {
+	defer observe("describe", time.Now(), nil)
+	lat, lon := c.Decimal()
+	d := Description{
+		Latitude:   lat,
+		Longitude:  lon,
+		Accuracy:   c.Accuracy,
+		DMS:        c.String(),
+		DDM:        c.Latitude.StringDDM() + " " + c.Longitude.StringDDM(),
+		Compact:    c.StringCompact(),
+		Geohash:    c.Geohash(describeGeohashLength),
+		Maidenhead: c.Maidenhead(describeMaidenheadPairs),
+		GeoURI:     c.GeoURI(),
+	}
+	if utm, err := c.UTM(); err == nil {
+		d.UTM = utm.String()
+	}
+	if mgrs, err := c.MGRS(); err == nil {
+		d.MGRS = mgrs
+	}
+	return d
+}
@@ -0,0 +1,185 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dataset reconciliation
+
+// MovedPoint is a point found in both datasets of a Diff at different
+// positions.
+type MovedPoint struct {
+	Name     string     // Key of the point.
+	From     Coordinate // Position in the old dataset.
+	To       Coordinate // Position in the new dataset.
+	Distance float64    // Great-circle distance moved in meters.
+}
+
+// DatasetDiff lists the differences between two datasets of named points,
+// each list sorted by name.
+type DatasetDiff struct {
+	Added     []Waypoint   // Points only in the new dataset.
+	Removed   []Waypoint   // Points only in the old dataset.
+	Moved     []MovedPoint // Points moved by more than the tolerance.
+	Unchanged int          // Number of points moved by at most the tolerance.
+}
+
+// Diff compares two datasets of waypoints keyed by name. Points moved by at
+// most tolerance meters count as unchanged. Names must be unique within each
+// dataset.
+func Diff(old, updated []Waypoint, tolerance float64) (*DatasetDiff, error) {
+	oldByName, err := waypointsByName(old)
+	if err != nil {
+		return nil, fmt.Errorf("Old dataset: %w", err)
+	}
+	newByName, err := waypointsByName(updated)
+	if err != nil {
+		return nil, fmt.Errorf("New dataset: %w", err)
+	}
+	d := &DatasetDiff{}
+	for name, before := range oldByName {
+		after, ok := newByName[name]
+		if !ok {
+			d.Removed = append(d.Removed, before)
+			continue
+		}
+		distance := Distance(before.Coordinate, after.Coordinate)
+		if distance > tolerance {
+			d.Moved = append(d.Moved, MovedPoint{Name: name, From: before.Coordinate, To: after.Coordinate, Distance: distance})
+		} else {
+			d.Unchanged++
+		}
+	}
+	for name, after := range newByName {
+		if _, ok := oldByName[name]; !ok {
+			d.Added = append(d.Added, after)
+		}
+	}
+	sort.Slice(d.Added, func(i, j int) bool { return d.Added[i].Name < d.Added[j].Name })
+	sort.Slice(d.Removed, func(i, j int) bool { return d.Removed[i].Name < d.Removed[j].Name })
+	sort.Slice(d.Moved, func(i, j int) bool { return d.Moved[i].Name < d.Moved[j].Name })
+	return d, nil
+}
+
+// waypointsByName indexes waypoints by name, rejecting duplicates.
+func waypointsByName(waypoints []Waypoint) (map[string]Waypoint, error) {
+	byName := make(map[string]Waypoint, len(waypoints))
+	for _, w := range waypoints {
+		if _, ok := byName[w.Name]; ok {
+			return nil, fmt.Errorf("Duplicate point name %q", w.Name)
+		}
+		byName[w.Name] = w
+	}
+	return byName, nil
+}
+
+// Patch applies the differences to a dataset: removed points are dropped,
+// moved points take their new position and added points are appended.
+// Points are matched by name.
+func (d *DatasetDiff) Patch(points []Waypoint) []Waypoint {
+	removed := make(map[string]bool, len(d.Removed))
+	for _, w := range d.Removed {
+		removed[w.Name] = true
+	}
+	moved := make(map[string]Coordinate, len(d.Moved))
+	for _, m := range d.Moved {
+		moved[m.Name] = m.To
+	}
+	var result []Waypoint
+	for _, w := range points {
+		if removed[w.Name] {
+			continue
+		}
+		if to, ok := moved[w.Name]; ok {
+			w.Coordinate = to
+		}
+		result = append(result, w)
+	}
+	return append(result, d.Added...)
+}
+
+// String returns a human-readable report, one line per change, e.g.
+// `~ BM12 40°26'46.30" N 79°58'56.00" W -> 40°26'46.40" N 79°58'56.00" W (3.09 m)`.
+func (d *DatasetDiff) String() string {
+	var b strings.Builder
+	for _, w := range d.Added {
+		fmt.Fprintf(&b, "+ %s %s\n", w.Name, w.Coordinate.String())
+	}
+	for _, w := range d.Removed {
+		fmt.Fprintf(&b, "- %s %s\n", w.Name, w.Coordinate.String())
+	}
+	for _, m := range d.Moved {
+		fmt.Fprintf(&b, "~ %s %s -> %s (%.2f m)\n", m.Name, m.From.String(), m.To.String(), m.Distance)
+	}
+	fmt.Fprintf(&b, "%d added, %d removed, %d moved, %d unchanged\n",
+		len(d.Added), len(d.Removed), len(d.Moved), d.Unchanged)
+	return b.String()
+}
+
+// jsonDiffPoint is the JSON layout of a point of a diff report.
+type jsonDiffPoint struct {
+	Name      string  `json:"name,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	DMS       string  `json:"dms"`
+}
+
+// jsonMovedPoint is the JSON layout of a moved point of a diff report.
+type jsonMovedPoint struct {
+	Name     string        `json:"name"`
+	From     jsonDiffPoint `json:"from"`
+	To       jsonDiffPoint `json:"to"`
+	Distance float64       `json:"distance"`
+}
+
+// newJSONDiffPoint returns the JSON layout of a named coordinate.
+func newJSONDiffPoint(name string, c Coordinate) jsonDiffPoint {
+	lat, lon := c.Decimal()
+	return jsonDiffPoint{Name: name, Latitude: lat, Longitude: lon, DMS: c.String()}
+}
+
+// MarshalJSON implements json.Marshaler, writing the report with positions
+// in decimal degrees and DMS and distances in meters.
+func (d DatasetDiff) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Added     []jsonDiffPoint  `json:"added"`
+		Removed   []jsonDiffPoint  `json:"removed"`
+		Moved     []jsonMovedPoint `json:"moved"`
+		Unchanged int              `json:"unchanged"`
+	}{
+		Added: []jsonDiffPoint{}, Removed: []jsonDiffPoint{}, Moved: []jsonMovedPoint{}, Unchanged: d.Unchanged,
+	}
+	for _, w := range d.Added {
+		out.Added = append(out.Added, newJSONDiffPoint(w.Name, w.Coordinate))
+	}
+	for _, w := range d.Removed {
+		out.Removed = append(out.Removed, newJSONDiffPoint(w.Name, w.Coordinate))
+	}
+	for _, m := range d.Moved {
+		out.Moved = append(out.Moved, jsonMovedPoint{
+			Name:     m.Name,
+			From:     newJSONDiffPoint("", m.From),
+			To:       newJSONDiffPoint("", m.To),
+			Distance: m.Distance,
+		})
+	}
+	return json.Marshal(out)
+}
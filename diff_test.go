@@ -0,0 +1,109 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDiffPatch(t *testing.T) {
+	point := func(name string, lat, lon float64) Waypoint {
+		w, err := NewWaypoint(name, lat, lon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return w
+	}
+	old := []Waypoint{
+		point("A", 40, -79),
+		point("B", 41, -79),
+		point("C", 42, -79),
+		point("D", 43, -79),
+	}
+	updated := []Waypoint{
+		point("E", 44, -79),
+		point("C", 42.001, -79),
+		point("B", 41.000001, -79),
+		point("A", 40, -79),
+	}
+	d, err := Diff(old, updated, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Added) != 1 || d.Added[0].Name != "E" || len(d.Removed) != 1 || d.Removed[0].Name != "D" ||
+		len(d.Moved) != 1 || d.Moved[0].Name != "C" || d.Unchanged != 2 {
+		t.Fatalf("Diff = %+v, want E added, D removed, C moved and 2 unchanged", d)
+	}
+	if got, want := d.Moved[0].Distance, Distance(old[2].Coordinate, updated[1].Coordinate); got != want {
+		t.Errorf("C moved %v m, want %v m", got, want)
+	}
+
+	patched := d.Patch(old)
+	want := []Waypoint{old[0], old[1], updated[1], updated[0]}
+	checkWaypoints(t, "Patch", patched, want)
+	if again, err := Diff(patched, updated, 1); err != nil || len(again.Added)+len(again.Removed)+len(again.Moved) != 0 {
+		t.Errorf("Diff of the patched dataset = %+v, %v, want no changes", again, err)
+	}
+
+	report := d.String()
+	for _, line := range []string{"+ E 44°0'0.00\" N", "- D 43°0'0.00\" N", "~ C 42°0'0.00\" N", "1 added, 1 removed, 1 moved, 2 unchanged"} {
+		if !strings.Contains(report, line) {
+			t.Errorf("String() = %q, want a line with %q", report, line)
+		}
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Added []struct {
+			Name     string  `json:"name"`
+			Latitude float64 `json:"latitude"`
+			DMS      string  `json:"dms"`
+		} `json:"added"`
+		Moved []struct {
+			From struct{ Latitude float64 } `json:"from"`
+			To   struct{ Latitude float64 } `json:"to"`
+		} `json:"moved"`
+		Unchanged int `json:"unchanged"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Added) != 1 || out.Added[0].Name != "E" || out.Added[0].Latitude != 44 ||
+		len(out.Moved) != 1 || out.Moved[0].From.Latitude != 42 || out.Unchanged != 2 {
+		t.Errorf("MarshalJSON = %s", data)
+	}
+}
+
+func TestDiffDuplicateNames(t *testing.T) {
+	a, _ := NewWaypoint("A", 40, -79)
+	for _, tt := range [][2][]Waypoint{{{a, a}, {a}}, {{a}, {a, a}}} {
+		if d, err := Diff(tt[0], tt[1], 0); err == nil {
+			t.Errorf("Diff with duplicate names = %+v, want error", d)
+		}
+	}
+	empty, err := Diff(nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := json.Marshal(empty); err != nil || string(data) != `{"added":[],"removed":[],"moved":[],"unchanged":0}` {
+		t.Errorf("MarshalJSON of an empty diff = %s, %v", data, err)
+	}
+}
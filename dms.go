@@ -16,12 +16,12 @@
 package dms
 
 import (
-	"errors"
 	"fmt"
 	"math"
 )
 
 // DMS represents a geographical coordinate in Degrees, Minutes, and Seconds format.
+// The zero value, which has no direction, is an unset value; see IsZero.
 type DMS struct {
 	Degree    uint    // Degree part of the coordinate.
 	Minutes   uint    // Minute part of the coordinate.
@@ -29,25 +29,93 @@ type DMS struct {
 	Direction string  // Represents the cardinal direction (N, S, E, W).
 }
 
+// Direction is a cardinal direction indicating the hemisphere of a DMS value.
+type Direction string
+
+// Cardinal directions.
+const (
+	North Direction = "N"
+	South Direction = "S"
+	East  Direction = "E"
+	West  Direction = "W"
+)
+
 // String Representations
 
 // String returns the DMS format in an LTR representation.
 func (d *DMS) String() string {
-	return fmt.Sprintf(`%d°%d'%.02f" %s`, d.Degree, d.Minutes, d.Seconds, d.Direction)
+	r := d.roundedSeconds(2, RoundHalfAwayFromZero)
+	return fmt.Sprintf(`%d°%d'%.02f" %s`, r.Degree, r.Minutes, r.Seconds, r.Direction)
 }
 
 // StringRTL returns the DMS format in an RTL representation.
 func (d *DMS) StringRTL() string {
-	return fmt.Sprintf(`%s "%.02f '%d °%d`, d.Direction, d.Seconds, d.Minutes, d.Degree)
+	r := d.roundedSeconds(2, RoundHalfAwayFromZero)
+	return fmt.Sprintf(`%s "%.02f '%d °%d`, r.Direction, r.Seconds, r.Minutes, r.Degree)
 }
 
 // StringPersian returns the DMS format in Persian language representation.
 func (d *DMS) StringPersian() string {
-	return fmt.Sprintf(`%d درجه %d دقیقه %.02f ثانیه %s`, d.Degree, d.Minutes, d.Seconds, d.Direction)
+	r := d.roundedSeconds(2, RoundHalfAwayFromZero)
+	return fmt.Sprintf(`%d درجه %d دقیقه %.02f ثانیه %s`, r.Degree, r.Minutes, r.Seconds, r.Direction)
+}
+
+// StringDDM returns the coordinate in Degrees and Decimal Minutes format.
+func (d *DMS) StringDDM() string {
+	degree := d.Degree
+	minutes := roundTo(float64(d.Minutes)+d.Seconds/60, 3, RoundHalfAwayFromZero)
+	if minutes >= 60 {
+		minutes -= 60
+		degree++
+	}
+	return fmt.Sprintf(`%d°%.03f' %s`, degree, minutes, d.Direction)
 }
 
 // Rounding methods
 
+// RoundingMode selects how seconds are rounded to a number of decimals.
+type RoundingMode int
+
+const (
+	RoundHalfAwayFromZero RoundingMode = iota // Halves round up: 0.125 becomes 0.13.
+	RoundHalfEven                             // Banker's rounding, halves round to even: 0.125 becomes 0.12.
+	RoundDown                                 // Truncation towards zero: 0.129 becomes 0.12.
+)
+
+// String returns the name of the rounding mode, e.g. "half-even".
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfAwayFromZero:
+		return "half-away-from-zero"
+	case RoundHalfEven:
+		return "half-even"
+	case RoundDown:
+		return "down"
+	}
+	return "unknown"
+}
+
+// RoundSeconds rounds the seconds to the given number of decimals using mode,
+// carrying into minutes and degrees so that Seconds stays below 60.
+func (d *DMS) RoundSeconds(decimals int, mode RoundingMode) {
+	seconds := d.Seconds
+	d.Seconds = roundTo(d.Seconds, decimals, mode)
+	if loss := math.Abs(d.Seconds - seconds); loss > 0 {
+		hooks().PrecisionLoss(*d, loss)
+	}
+	// Update minutes and degrees if needed after rounding.
+	d.updateAfterRounding()
+}
+
+// roundedSeconds returns a copy of the DMS with its seconds rounded, for
+// display. Unlike RoundSeconds, it reports no precision loss.
+func (d *DMS) roundedSeconds(decimals int, mode RoundingMode) DMS {
+	r := *d
+	r.Seconds = roundTo(r.Seconds, decimals, mode)
+	r.updateAfterRounding()
+	return r
+}
+
 // RoundToMinute rounds the coordinate value to the nearest minute.
 func (d *DMS) RoundToMinute() {
 	d.Seconds = roundToWholeNumber(d.Seconds)
@@ -86,16 +154,61 @@ func (d *DMS) updateAfterRounding() {
 // Factory functions
 
 // NewDMS creates new DMS structures for given latitude and longitude.
+// Out-of-range values are handled according to CurrentRangePolicy.
 func NewDMS(lat, lon float64) (DMS, DMS, error) {
 	// Validate the input latitude and longitude.
-	if math.Abs(lat) > 90 || math.Abs(lon) > 180 {
-		return DMS{}, DMS{}, errors.New("Invalid latitude or longitude value")
+	lat, lon, err := CurrentRangePolicy().Apply(lat, lon)
+	if err != nil {
+		return DMS{}, DMS{}, err
 	}
 	latDMS := DecimalToDMS(lat, "N", "S")
 	lonDMS := DecimalToDMS(lon, "E", "W")
 	return latDMS, lonDMS, nil
 }
 
+// NewLatitude creates a new DMS for a signed decimal latitude. Out-of-range
+// values are handled according to CurrentRangePolicy.
+func NewLatitude(dec float64) (DMS, error) {
+	dec, err := CurrentRangePolicy().applyAxis(dec, AxisLatitude)
+	if err != nil {
+		return DMS{}, err
+	}
+	return DecimalToDMS(dec, "N", "S"), nil
+}
+
+// NewLongitude creates a new DMS for a signed decimal longitude. Out-of-range
+// values are handled according to CurrentRangePolicy.
+func NewLongitude(dec float64) (DMS, error) {
+	dec, err := CurrentRangePolicy().applyAxis(dec, AxisLongitude)
+	if err != nil {
+		return DMS{}, err
+	}
+	return DecimalToDMS(dec, "E", "W"), nil
+}
+
+// NewDMSFromComponents creates a new DMS from its components, validating the
+// ranges of minutes and seconds, the direction and the range of its axis.
+func NewDMSFromComponents(deg, min uint, sec float64, dir Direction) (DMS, error) {
+	d := DMS{Degree: deg, Minutes: min, Seconds: sec, Direction: string(dir)}
+	if err := d.Validate(); err != nil {
+		return DMS{}, err
+	}
+	return d, nil
+}
+
+// Validate checks that the DMS components are within range and that the
+// direction is one of N, S, E or W, whose axis sets the range of the value.
+// Errors are of type *ValidationError.
+func (d *DMS) Validate() error {
+	return d.ValidateAs(d.Axis())
+}
+
+// IsZero reports whether the DMS is the zero value, i.e. unset. A value of
+// 0°0'0" has a direction and is not zero.
+func (d *DMS) IsZero() bool {
+	return *d == DMS{}
+}
+
 // DecimalToDMS converts a decimal coordinate to DMS format.
 func DecimalToDMS(decimalDegree float64, positiveIndicator, negativeIndicator string) DMS {
 	degree, minutes, seconds := decimalToDMSComponents(math.Abs(decimalDegree))
@@ -103,11 +216,29 @@ func DecimalToDMS(decimalDegree float64, positiveIndicator, negativeIndicator st
 	return DMS{Degree: degree, Minutes: minutes, Seconds: seconds, Direction: direction}
 }
 
+// DecimalToDMSRounded converts a decimal coordinate to DMS format with the
+// seconds rounded to the given number of decimals using mode.
+func DecimalToDMSRounded(decimalDegree float64, positiveIndicator, negativeIndicator string, decimals int, mode RoundingMode) DMS {
+	dms := DecimalToDMS(decimalDegree, positiveIndicator, negativeIndicator)
+	dms.RoundSeconds(decimals, mode)
+	return dms
+}
+
 // DMSToDecimal converts a DMS format coordinate to its decimal representation.
 func DMSToDecimal(dms DMS) float64 {
 	return float64(dms.Degree) + float64(dms.Minutes)/60.0 + dms.Seconds/3600.0
 }
 
+// signedDecimal converts a DMS coordinate to a signed decimal value, negative
+// for the southern and western hemispheres.
+func signedDecimal(dms DMS) float64 {
+	value := DMSToDecimal(dms)
+	if dms.Direction == "S" || dms.Direction == "W" {
+		return -value
+	}
+	return value
+}
+
 // RoundDecimalToMinute rounds a decimal degree to its nearest minute.
 func RoundDecimalToMinute(decimalDegree float64) float64 {
 	degree := math.Floor(decimalDegree)
@@ -140,9 +271,34 @@ func decimalToDMSComponents(decimalDegree float64) (degree uint, minutes uint, s
 	degree = uint(decimalDegree)
 	minutes = uint((decimalDegree - float64(degree)) * 60)
 	seconds = (decimalDegree - float64(degree) - float64(minutes)/60) * 3600
+	// Correct floating-point error that pushes seconds out of [0, 60).
+	if seconds < 0 {
+		seconds = 0
+	}
+	if seconds >= 60 {
+		seconds -= 60
+		minutes++
+	}
+	if minutes >= 60 {
+		minutes -= 60
+		degree++
+	}
 	return
 }
 
+// roundTo rounds value to the given number of decimals using mode.
+func roundTo(value float64, decimals int, mode RoundingMode) float64 {
+	scale := math.Pow10(decimals)
+	switch mode {
+	case RoundHalfEven:
+		return math.RoundToEven(value*scale) / scale
+	case RoundDown:
+		return math.Trunc(value*scale) / scale
+	default:
+		return math.Round(value*scale) / scale
+	}
+}
+
 // roundToWholeNumber rounds a float to its nearest whole number.
 func roundToWholeNumber(value float64) float64 {
 	return math.Round(value)
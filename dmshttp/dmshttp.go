@@ -0,0 +1,85 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+// Package dmshttp provides net/http helpers for services that accept and
+// return coordinates, such as a conversion endpoint:
+//
+//	mux.Handle("/convert", dmshttp.Handler())
+package dmshttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mshafiee/dms"
+)
+
+// maxBodySize limits the size of a coordinate sent in a request body.
+const maxBodySize = 4 << 10
+
+// errorResponse is the JSON body of a failed request.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns an http.Handler that parses a coordinate in any notation
+// accepted by dms.ParseCoordinate, or a geo URI or map link, and responds with
+// its dms.Description as JSON. The coordinate is read from the "q" query or
+// form parameter, or from a plain-text POST body.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			w.Header().Set("Allow", "GET, POST")
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "Method not allowed"})
+			return
+		}
+		input, err := readInput(w, r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		c, err := dms.ParseQRPayload(input)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, dms.Describe(c))
+	})
+}
+
+// readInput returns the coordinate text of a request.
+func readInput(w http.ResponseWriter, r *http.Request) (string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	if q := r.URL.Query().Get("q"); q != "" || r.Method == http.MethodGet {
+		return q, nil
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if err := r.ParseForm(); err != nil {
+			return "", err
+		}
+		return r.PostForm.Get("q"), nil
+	}
+	body, err := io.ReadAll(r.Body)
+	return strings.TrimSpace(string(body)), err
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
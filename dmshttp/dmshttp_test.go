@@ -0,0 +1,77 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dmshttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mshafiee/dms"
+)
+
+func TestHandler(t *testing.T) {
+	const dmsText = `40°26'46.30" N 79°56'55.90" W`
+	tests := []struct {
+		method      string
+		target      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{http.MethodGet, "/?q=" + url.QueryEscape(dmsText), "", "", http.StatusOK},
+		{http.MethodGet, "/?q=geo:40.446195,-79.948862", "", "", http.StatusOK},
+		{http.MethodPost, "/", "text/plain", dmsText + "\n", http.StatusOK},
+		{http.MethodPost, "/", "application/x-www-form-urlencoded", "q=" + url.QueryEscape(dmsText), http.StatusOK},
+		{http.MethodGet, "/", "", "", http.StatusBadRequest},
+		{http.MethodGet, "/?q=nowhere", "", "", http.StatusBadRequest},
+		{http.MethodPost, "/", "text/plain", strings.Repeat("4", maxBodySize+1), http.StatusBadRequest},
+		{http.MethodDelete, "/", "", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		if tt.contentType != "" {
+			r.Header.Set("Content-Type", tt.contentType)
+		}
+		w := httptest.NewRecorder()
+		Handler().ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("%s %s: status %d, want %d: %s", tt.method, tt.target, w.Code, tt.status, w.Body)
+			continue
+		}
+		if got := w.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+			t.Errorf("%s %s: Content-Type %q", tt.method, tt.target, got)
+		}
+		if tt.status != http.StatusOK {
+			var e errorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &e); err != nil || e.Error == "" {
+				t.Errorf("%s %s: error body %s", tt.method, tt.target, w.Body)
+			}
+			continue
+		}
+		var d dms.Description
+		if err := json.Unmarshal(w.Body.Bytes(), &d); err != nil {
+			t.Errorf("%s %s: body %s: %v", tt.method, tt.target, w.Body, err)
+			continue
+		}
+		if d.Geohash != "dppnhep00" || d.Maidenhead != "FN00ak" {
+			t.Errorf("%s %s: description %+v", tt.method, tt.target, d)
+		}
+	}
+}
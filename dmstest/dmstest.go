@@ -0,0 +1,90 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+// Package dmstest provides helpers for asserting the guarantees of package
+// dms in integration tests.
+package dmstest
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mshafiee/dms"
+)
+
+// RoundTripTolerance is the largest difference in degrees, about 0.1 mm on
+// the ground, accepted as floating-point noise by the round-trip checks.
+const RoundTripTolerance = 1e-9
+
+// stringTolerance is the largest difference in seconds accepted after a
+// round trip through the two-decimal String representation.
+const stringTolerance = 0.005 + 1e-9
+
+// CheckCanonical returns an error describing why d is not canonical, or nil.
+// A canonical DMS has a valid direction, minutes and seconds in [0, 60) and
+// a magnitude within the range of its axis.
+func CheckCanonical(d dms.DMS) error {
+	return d.Validate()
+}
+
+// IsCanonical reports whether d is canonical.
+func IsCanonical(d dms.DMS) bool {
+	return CheckCanonical(d) == nil
+}
+
+// IsCanonicalCoordinate reports whether both parts of c are canonical and lie
+// on the expected axes.
+func IsCanonicalCoordinate(c dms.Coordinate) bool {
+	return c.Validate() == nil
+}
+
+// RoundTripsExactly reports whether the decimal position converts to a
+// canonical Coordinate and back to the same values within RoundTripTolerance.
+func RoundTripsExactly(lat, lon float64) bool {
+	return CheckRoundTrip(lat, lon) == nil
+}
+
+// CheckRoundTrip returns an error describing how the decimal position fails
+// to round-trip through Coordinate, or nil.
+func CheckRoundTrip(lat, lon float64) error {
+	c, err := dms.NewCoordinate(lat, lon)
+	if err != nil {
+		return err
+	}
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("Non-canonical conversion of %v, %v: %v", lat, lon, err)
+	}
+	gotLat, gotLon := c.Decimal()
+	if math.Abs(gotLat-lat) > RoundTripTolerance || math.Abs(gotLon-lon) > RoundTripTolerance {
+		return fmt.Errorf("%v, %v round-tripped to %v, %v", lat, lon, gotLat, gotLon)
+	}
+	return nil
+}
+
+// RoundTripsString reports whether c survives formatting with String and
+// parsing with ParseCoordinate, up to the rounding of the seconds.
+func RoundTripsString(c dms.Coordinate) bool {
+	parsed, err := dms.ParseCoordinate(c.String())
+	if err != nil {
+		return false
+	}
+	return sameWithin(c.Latitude, parsed.Latitude) && sameWithin(c.Longitude, parsed.Longitude)
+}
+
+// sameWithin reports whether a and b differ by at most stringTolerance seconds.
+func sameWithin(a, b dms.DMS) bool {
+	diff := (dms.DMSToDecimal(a) - dms.DMSToDecimal(b)) * 3600
+	return a.Direction == b.Direction && math.Abs(diff) <= stringTolerance
+}
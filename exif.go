@@ -0,0 +1,132 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Photo geotagging
+
+// exifSecondsDecimals is the number of decimals of the seconds written to
+// EXIF, about 3 cm.
+const exifSecondsDecimals = 3
+
+// Rational is an unsigned EXIF RATIONAL value, Num/Den.
+type Rational struct {
+	Num, Den uint32
+}
+
+// Float returns the value of the rational, NaN for a zero denominator.
+func (r Rational) Float() float64 {
+	if r.Den == 0 {
+		return math.NaN()
+	}
+	return float64(r.Num) / float64(r.Den)
+}
+
+// String returns the rational as "Num/Den".
+func (r Rational) String() string {
+	return fmt.Sprintf("%d/%d", r.Num, r.Den)
+}
+
+// EXIFGPS holds the EXIF GPS tags of a geotagged photo, ready to be written by
+// an EXIF library.
+type EXIFGPS struct {
+	LatitudeRef  string      // GPSLatitudeRef, "N" or "S".
+	Latitude     [3]Rational // GPSLatitude: degrees, minutes and seconds.
+	LongitudeRef string      // GPSLongitudeRef, "E" or "W".
+	Longitude    [3]Rational // GPSLongitude: degrees, minutes and seconds.
+	DateStamp    string      // GPSDateStamp in UTC, "YYYY:MM:DD"; empty when unknown.
+	TimeStamp    [3]Rational // GPSTimeStamp in UTC: hours, minutes and seconds.
+}
+
+// exifDMS returns the degrees, minutes and seconds of a DMS as EXIF rationals.
+func exifDMS(d *DMS) [3]Rational {
+	r := d.roundedSeconds(exifSecondsDecimals, RoundHalfAwayFromZero)
+	scale := math.Pow10(exifSecondsDecimals)
+	return [3]Rational{
+		{uint32(r.Degree), 1},
+		{uint32(r.Minutes), 1},
+		{uint32(math.Round(r.Seconds * scale)), uint32(scale)},
+	}
+}
+
+// NewEXIFGPS returns the EXIF GPS tags of a coordinate, with the time stamp
+// of t when it is not zero.
+func NewEXIFGPS(c Coordinate, t time.Time) EXIFGPS {
+	g := EXIFGPS{
+		LatitudeRef:  c.Latitude.Direction,
+		Latitude:     exifDMS(&c.Latitude),
+		LongitudeRef: c.Longitude.Direction,
+		Longitude:    exifDMS(&c.Longitude),
+	}
+	if !t.IsZero() {
+		t = t.UTC()
+		g.DateStamp = t.Format("2006:01:02")
+		g.TimeStamp = [3]Rational{
+			{uint32(t.Hour()), 1},
+			{uint32(t.Minute()), 1},
+			{uint32(t.Second()*1000 + t.Nanosecond()/1e6), 1000},
+		}
+	}
+	return g
+}
+
+// Coordinate returns the coordinate of the EXIF GPS tags.
+func (g *EXIFGPS) Coordinate() (Coordinate, error) {
+	lat, err := exifAxis(g.Latitude, g.LatitudeRef, "N", "S")
+	if err != nil {
+		return Coordinate{}, err
+	}
+	lon, err := exifAxis(g.Longitude, g.LongitudeRef, "E", "W")
+	if err != nil {
+		return Coordinate{}, err
+	}
+	return NewCoordinate(lat, lon)
+}
+
+// exifAxis returns the signed decimal degrees of EXIF GPS rationals.
+func exifAxis(parts [3]Rational, ref, positive, negative string) (float64, error) {
+	value := parts[0].Float() + parts[1].Float()/60 + parts[2].Float()/3600
+	if math.IsNaN(value) {
+		return 0, errors.New("Invalid EXIF GPS rational")
+	}
+	switch ref {
+	case positive:
+		return value, nil
+	case negative:
+		return -value, nil
+	}
+	return 0, fmt.Errorf("Invalid EXIF GPS reference %q", ref)
+}
+
+// GeotagPhoto returns the EXIF GPS tags of a photo taken at the camera time
+// taken, from the position of the track at that time. clockOffset is how far
+// the camera clock is ahead of the true time of the track, and is subtracted
+// from taken; camera times without a time zone should be given in UTC with
+// the zone offset included in clockOffset.
+func GeotagPhoto(track *Track, taken time.Time, clockOffset time.Duration) (EXIFGPS, error) {
+	at := taken.Add(-clockOffset)
+	c, err := track.PositionAt(at)
+	if err != nil {
+		return EXIFGPS{}, err
+	}
+	return NewEXIFGPS(c, at), nil
+}
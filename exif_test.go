@@ -0,0 +1,89 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewEXIFGPS(t *testing.T) {
+	c := Coordinate{Latitude: DMS{40, 26, 46.3025, "N"}, Longitude: DMS{79, 58, 59.9996, "W"}}
+	taken := time.Date(2021, 7, 4, 14, 30, 15, 250e6, time.FixedZone("EDT", -4*3600))
+	g := NewEXIFGPS(c, taken)
+	want := EXIFGPS{
+		LatitudeRef:  "N",
+		Latitude:     [3]Rational{{40, 1}, {26, 1}, {46303, 1000}},
+		LongitudeRef: "W",
+		Longitude:    [3]Rational{{79, 1}, {59, 1}, {0, 1000}},
+		DateStamp:    "2021:07:04",
+		TimeStamp:    [3]Rational{{18, 1}, {30, 1}, {15250, 1000}},
+	}
+	if g != want {
+		t.Errorf("NewEXIFGPS = %+v, want %+v", g, want)
+	}
+
+	back, err := g.Coordinate()
+	if err != nil || Distance(back, c) > 0.05 {
+		t.Errorf("Coordinate() = %v, %v, want %v", back, err, c)
+	}
+	if g := NewEXIFGPS(c, time.Time{}); g.DateStamp != "" || g.TimeStamp != [3]Rational{} {
+		t.Errorf("NewEXIFGPS without time = %+v, want no time stamp", g)
+	}
+}
+
+func TestEXIFGPSCoordinateMalformed(t *testing.T) {
+	valid := NewEXIFGPS(coordinateFromDecimal(40.5, -79.5), time.Time{})
+	tests := []func(g *EXIFGPS){
+		func(g *EXIFGPS) { g.Latitude[2].Den = 0 },
+		func(g *EXIFGPS) { g.Longitude[0].Den = 0 },
+		func(g *EXIFGPS) { g.LatitudeRef = "E" },
+		func(g *EXIFGPS) { g.LongitudeRef = "" },
+		func(g *EXIFGPS) { g.Latitude[0].Num = 91 },
+	}
+	for i, modify := range tests {
+		g := valid
+		modify(&g)
+		if c, err := g.Coordinate(); err == nil {
+			t.Errorf("case %d: Coordinate() of %+v = %v, want error", i, g, c)
+		}
+	}
+	if !math.IsNaN((Rational{1, 0}).Float()) {
+		t.Error("Rational{1, 0}.Float() is not NaN")
+	}
+}
+
+func TestGeotagPhoto(t *testing.T) {
+	track := testTrack(t)
+	start := track.Segments[0][0].Time
+	// The camera clock runs an hour and ten seconds ahead of the track.
+	offset := time.Hour + 10*time.Second
+	g, err := GeotagPhoto(&track, start.Add(30*time.Second+offset), offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := g.Coordinate()
+	if err != nil || Distance(c, coordinateFromDecimal(0, 0.005)) > 0.05 {
+		t.Errorf("GeotagPhoto position = %v, %v, want 0, 0.005", c, err)
+	}
+	if g.TimeStamp != [3]Rational{{12, 1}, {0, 1}, {30000, 1000}} || g.DateStamp != "2021:07:04" {
+		t.Errorf("GeotagPhoto time = %s %v, want 2021:07:04 12:00:30", g.DateStamp, g.TimeStamp)
+	}
+	if _, err := GeotagPhoto(&track, start.Add(offset-time.Minute), offset); err != ErrTimeNotInTrack {
+		t.Errorf("GeotagPhoto before the track error = %v, want ErrTimeNotInTrack", err)
+	}
+}
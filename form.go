@@ -0,0 +1,257 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Form binding
+
+// CoordinateFromValues reads a coordinate from form or query values. With an
+// empty prefix it reads the keys "lat" and "lon", each holding a signed
+// decimal or a DMS string such as `40°26'46" N`. Alternatively, each axis can
+// be split into "lat_deg", "lat_min", "lat_sec" and "lat_dir" fields (and
+// likewise for "lon"). An optional "accuracy" key holds meters. With a
+// prefix such as "pickup", the keys become "pickup_lat", "pickup_lon_deg" and
+// so on. All problems found are reported together.
+func CoordinateFromValues(values url.Values, prefix string) (Coordinate, error) {
+	key := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "_" + name
+	}
+	b := Builder()
+	b.setLat(axisFromValues(values, key("lat"), "N", "S"))
+	b.setLon(axisFromValues(values, key("lon"), "E", "W"))
+	if accuracy := values.Get(key("accuracy")); accuracy != "" {
+		meters, err := strconv.ParseFloat(accuracy, 64)
+		if err != nil {
+			b.errs = append(b.errs, fmt.Errorf("%s: Invalid number %q", key("accuracy"), accuracy))
+		} else {
+			b.Accuracy(meters)
+		}
+	}
+	return b.Build()
+}
+
+// BindValues fills the Coordinate fields of the struct pointed to by dst
+// that carry a `dms:"name"` tag from form or query values, using the tag
+// name as the key prefix of CoordinateFromValues.
+func BindValues(values url.Values, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return errors.New("BindValues needs a pointer to a struct")
+	}
+	v = v.Elem()
+	var errs []error
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, ok := field.Tag.Lookup("dms")
+		if !ok || field.Type != reflect.TypeOf(Coordinate{}) || !v.Field(i).CanSet() {
+			continue
+		}
+		c, err := CoordinateFromValues(values, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		v.Field(i).Set(reflect.ValueOf(c))
+	}
+	return errors.Join(errs...)
+}
+
+// axisFromValues reads one axis from either a single field or split fields.
+// Split fields are validated as Segments, an empty minutes or seconds field
+// counting as zero.
+func axisFromValues(values url.Values, key, positiveIndicator, negativeIndicator string) (DMS, error) {
+	if text := values.Get(key); text != "" {
+		return parseAxis(text, positiveIndicator, negativeIndicator)
+	}
+	s := Segments{values.Get(key + "_deg"), values.Get(key + "_min"), values.Get(key + "_sec"), values.Get(key + "_dir")}
+	if s.Degrees == "" {
+		return DMS{}, fmt.Errorf("Missing %s value", key)
+	}
+	// Without a direction, the degrees may be signed.
+	if strings.TrimSpace(s.Hemisphere) == "" {
+		s.Hemisphere = positiveIndicator
+		if degrees, ok := strings.CutPrefix(strings.TrimSpace(s.Degrees), "-"); ok {
+			s.Degrees, s.Hemisphere = degrees, negativeIndicator
+		}
+	}
+	axis := AxisLongitude
+	if positiveIndicator == "N" {
+		axis = AxisLatitude
+	}
+	return s.Join(axis)
+}
+
+// parseAxis parses a single DMS or signed decimal value on the axis given by
+// its direction indicators. Out-of-range values are handled according to
+// CurrentRangePolicy, as by ParseDMS.
+func parseAxis(s, positiveIndicator, negativeIndicator string) (DMS, error) {
+	tokens, err := tokenizeDMS(s)
+	if err != nil {
+		return DMS{}, err
+	}
+	groups := groupDMSTokens(tokens)
+	if len(groups) != 1 {
+		return DMS{}, fmt.Errorf("Invalid DMS value %q", s)
+	}
+	d, err := dmsFromTokens(groups[0], positiveIndicator, negativeIndicator)
+	if err != nil {
+		return DMS{}, err
+	}
+	if d.Direction != positiveIndicator && d.Direction != negativeIndicator {
+		return DMS{}, fmt.Errorf("Invalid direction %q", d.Direction)
+	}
+	if d, err = fitDMS(d); err != nil {
+		return DMS{}, err
+	}
+	if err := d.ValidateAs(d.Axis()); err != nil {
+		return DMS{}, err
+	}
+	return d, nil
+}
+
+// Segmented fields
+
+// Segments holds the text of the separate input boxes of one axis of a
+// coordinate form.
+type Segments struct {
+	Degrees, Minutes, Seconds, Hemisphere string
+}
+
+// SegmentErrors holds a validation message for each box of Segments, empty
+// for valid boxes.
+type SegmentErrors struct {
+	Degrees, Minutes, Seconds, Hemisphere string
+}
+
+// Error returns the messages of the invalid boxes.
+func (e *SegmentErrors) Error() string {
+	var messages []string
+	for _, m := range []string{e.Degrees, e.Minutes, e.Seconds, e.Hemisphere} {
+		if m != "" {
+			messages = append(messages, m)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// SplitSegments returns the boxes of a DMS value, with the seconds rounded to
+// the given number of decimals.
+func SplitSegments(d DMS, decimals int) Segments {
+	r := d.roundedSeconds(max(decimals, 0), RoundHalfAwayFromZero)
+	return Segments{
+		Degrees:    strconv.FormatUint(uint64(r.Degree), 10),
+		Minutes:    strconv.FormatUint(uint64(r.Minutes), 10),
+		Seconds:    strconv.FormatFloat(r.Seconds, 'f', max(decimals, 0), 64),
+		Hemisphere: r.Direction,
+	}
+}
+
+// Join validates the boxes as a value of axis, or of the axis of the
+// hemisphere with AxisUnknown, and returns the DMS value. On failure, the
+// error is a *SegmentErrors with a message for each invalid box. Minutes and
+// seconds may be left empty; degrees may have decimals when the minutes and
+// seconds are empty, and minutes when the seconds are empty. Persian and
+// Arabic-Indic digits are accepted.
+func (s *Segments) Join(axis Axis) (DMS, error) {
+	var errs SegmentErrors
+	direction := strings.ToUpper(strings.TrimSpace(s.Hemisphere))
+	if len(direction) > 1 {
+		if word, exact, err := directionFromWord(direction); err == nil && exact {
+			direction = word
+		}
+	}
+	d := DMS{Direction: direction}
+	switch {
+	case direction == "":
+		errs.Hemisphere = "Hemisphere is required"
+	case axis == AxisUnknown:
+		axis = d.Axis()
+		if axis == AxisUnknown {
+			errs.Hemisphere = fmt.Sprintf("Invalid hemisphere %q", s.Hemisphere)
+		}
+	case d.Axis() != axis:
+		errs.Hemisphere = fmt.Sprintf("Invalid %s hemisphere %q", axis, s.Hemisphere)
+	}
+	limit := 180.0
+	if axis == AxisLatitude {
+		limit = 90
+	}
+	noMinutes, noSeconds := strings.TrimSpace(s.Minutes) == "", strings.TrimSpace(s.Seconds) == ""
+	degrees, msg := segmentNumber(s.Degrees, "Degrees", limit, noMinutes && noSeconds, true)
+	errs.Degrees = msg
+	minutes, msg := segmentNumber(s.Minutes, "Minutes", 60, noSeconds, false)
+	errs.Minutes = msg
+	seconds, msg := segmentNumber(s.Seconds, "Seconds", 60, true, false)
+	errs.Seconds = msg
+	if errs.Degrees == "" && degrees == limit && (minutes > 0 || seconds > 0) {
+		errs.Degrees = fmt.Sprintf("The %s must not exceed %g°", axis, limit)
+	}
+	if errs != (SegmentErrors{}) {
+		return DMS{}, &errs
+	}
+	// Spread fractional degrees or minutes, allowed only when the following
+	// boxes are empty, into the next unit.
+	whole := math.Floor(degrees)
+	if noMinutes && noSeconds {
+		minutes = (degrees - whole) * 60
+	}
+	wholeMinutes := math.Floor(minutes)
+	if noSeconds {
+		seconds = (minutes - wholeMinutes) * 60
+	}
+	d.Degree, d.Minutes, d.Seconds = uint(whole), uint(wholeMinutes), seconds
+	d.updateAfterRounding()
+	return d, nil
+}
+
+// segmentNumber parses the text of a box, which may be empty unless required,
+// must be below limit (at most limit for degrees) and may have decimals when
+// fractional.
+func segmentNumber(text, name string, limit float64, fractional, required bool) (float64, string) {
+	text = normalizeDigits(strings.TrimSpace(text))
+	if text == "" {
+		if required {
+			return 0, name + " are required"
+		}
+		return 0, ""
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	switch {
+	case err != nil || math.IsNaN(value) || math.IsInf(value, 0):
+		return 0, fmt.Sprintf("%s must be a number", name)
+	case value < 0:
+		return 0, fmt.Sprintf("%s must not be negative", name)
+	case !fractional && value != math.Trunc(value):
+		return 0, fmt.Sprintf("%s must be a whole number", name)
+	case required && value > limit:
+		return 0, fmt.Sprintf("%s must not exceed %g", name, limit)
+	case !required && value >= limit:
+		return 0, fmt.Sprintf("%s must be below %g", name, limit)
+	}
+	return value, ""
+}
@@ -0,0 +1,116 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSegmentsJoinCarry(t *testing.T) {
+	tests := []struct {
+		segments Segments
+		want     DMS
+	}{
+		{Segments{"40", "59", "59.99999999999", "N"}, DMS{Degree: 40, Minutes: 59, Seconds: 59.99999999999, Direction: "N"}},
+		{Segments{"40", "59.9999999999999", "", "N"}, DMS{Degree: 40, Minutes: 59, Seconds: 59.999999999994, Direction: "N"}},
+		{Segments{"40", "26", "46.3", "N"}, DMS{Degree: 40, Minutes: 26, Seconds: 46.3, Direction: "N"}},
+		{Segments{"40", "26.5", "", "N"}, DMS{Degree: 40, Minutes: 26, Seconds: 30, Direction: "N"}},
+		{Segments{"40.5", "", "", "N"}, DMS{Degree: 40, Minutes: 30, Seconds: 0, Direction: "N"}},
+		{Segments{"79", "", "", "W"}, DMS{Degree: 79, Direction: "W"}},
+	}
+	for _, tt := range tests {
+		got, err := tt.segments.Join(AxisUnknown)
+		if err != nil {
+			t.Errorf("%+v: unexpected error %v", tt.segments, err)
+			continue
+		}
+		if got.Degree != tt.want.Degree || got.Minutes != tt.want.Minutes ||
+			got.Direction != tt.want.Direction || math.Abs(got.Seconds-tt.want.Seconds) > 1e-9 {
+			t.Errorf("%+v: got %+v, want %+v", tt.segments, got, tt.want)
+		}
+	}
+}
+
+func TestCoordinateFromValues(t *testing.T) {
+	tests := []struct {
+		query    string
+		prefix   string
+		lat, lon DMS
+		accuracy float64
+	}{
+		{"lat=40.5&lon=-79.5", "", DMS{40, 30, 0, "N"}, DMS{79, 30, 0, "W"}, 0},
+		{"lat=40%C2%B026%2746%22+N&lon=79%C2%B058%2756%22+W&accuracy=5", "", DMS{40, 26, 46, "N"}, DMS{79, 58, 56, "W"}, 5},
+		{"lat_deg=40&lat_min=26&lat_sec=46&lat_dir=S&lon_deg=-79&lon_min=58", "", DMS{40, 26, 46, "S"}, DMS{79, 58, 0, "W"}, 0},
+		{"pickup_lat=-33.5&pickup_lon_deg=151&pickup_lon_min=12.5", "pickup", DMS{33, 30, 0, "S"}, DMS{151, 12, 30, "E"}, 0},
+	}
+	for _, tt := range tests {
+		values, err := url.ParseQuery(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := CoordinateFromValues(values, tt.prefix)
+		if err != nil {
+			t.Errorf("CoordinateFromValues(%q) error: %v", tt.query, err)
+			continue
+		}
+		if c.Latitude != tt.lat || c.Longitude != tt.lon || c.Accuracy != tt.accuracy {
+			t.Errorf("CoordinateFromValues(%q) = %+v, want %v %v ±%v", tt.query, c, tt.lat, tt.lon, tt.accuracy)
+		}
+	}
+	malformed := []string{
+		"",
+		"lat=40.5",
+		"lat=40.5&lon=79.5+N",
+		"lat=95&lon=0",
+		"lat=40.5&lon=0&accuracy=far",
+		"lat_deg=40&lat_min=61&lon=0",
+		"lat=40.5+N+41+N&lon=0",
+	}
+	for _, query := range malformed {
+		values, _ := url.ParseQuery(query)
+		if c, err := CoordinateFromValues(values, ""); err == nil {
+			t.Errorf("CoordinateFromValues(%q) = %v, want error", query, c)
+		}
+	}
+}
+
+func TestBindValues(t *testing.T) {
+	var trip struct {
+		From  Coordinate `dms:"from"`
+		To    Coordinate `dms:"to"`
+		Other Coordinate
+		Name  string `dms:"name"`
+	}
+	values, _ := url.ParseQuery("from_lat=40.5&from_lon=-79.5&to_lat=41&to_lon=-80&name=x")
+	if err := BindValues(values, &trip); err != nil {
+		t.Fatal(err)
+	}
+	if trip.From.Latitude != (DMS{40, 30, 0, "N"}) || trip.To.Longitude != (DMS{80, 0, 0, "W"}) || !trip.Other.IsZero() || trip.Name != "" {
+		t.Errorf("BindValues = %+v", trip)
+	}
+
+	values, _ = url.ParseQuery("from_lat=91&from_lon=0&to_lat=41")
+	err := BindValues(values, &trip)
+	if err == nil || !strings.Contains(err.Error(), "from:") || !strings.Contains(err.Error(), "to:") {
+		t.Errorf("BindValues error = %v, want errors for from and to", err)
+	}
+	if err := BindValues(values, trip); err == nil {
+		t.Error("BindValues of a struct value succeeded, want error")
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Locale selects the language of localized output.
+type Locale string
+
+// Supported locales.
+const (
+	LocaleEnglish Locale = "en"
+	LocalePersian Locale = "fa"
+	LocaleFrench  Locale = "fr"
+	LocaleGerman  Locale = "de"
+	LocaleSpanish Locale = "es"
+)
+
+// localeData holds the words used to format DMS values in a locale.
+type localeData struct {
+	units      [3][2]string      // Singular and plural of degree, minute and second.
+	directions map[string]string // Full names of N, S, E and W.
+	decimalSep string            // Decimal separator.
+}
+
+// locales holds the formatting data of every supported locale.
+var locales = map[Locale]localeData{
+	LocaleEnglish: {
+		units:      [3][2]string{{"degree", "degrees"}, {"minute", "minutes"}, {"second", "seconds"}},
+		directions: map[string]string{"N": "North", "S": "South", "E": "East", "W": "West"},
+		decimalSep: ".",
+	},
+	LocalePersian: {
+		units:      [3][2]string{{"درجه", "درجه"}, {"دقیقه", "دقیقه"}, {"ثانیه", "ثانیه"}},
+		directions: map[string]string{"N": "شمال", "S": "جنوب", "E": "شرق", "W": "غرب"},
+		decimalSep: ".",
+	},
+	LocaleFrench: {
+		units:      [3][2]string{{"degré", "degrés"}, {"minute", "minutes"}, {"seconde", "secondes"}},
+		directions: map[string]string{"N": "Nord", "S": "Sud", "E": "Est", "W": "Ouest"},
+		decimalSep: ",",
+	},
+	LocaleGerman: {
+		units:      [3][2]string{{"Grad", "Grad"}, {"Minute", "Minuten"}, {"Sekunde", "Sekunden"}},
+		directions: map[string]string{"N": "Nord", "S": "Süd", "E": "Ost", "W": "West"},
+		decimalSep: ",",
+	},
+	LocaleSpanish: {
+		units:      [3][2]string{{"grado", "grados"}, {"minuto", "minutos"}, {"segundo", "segundos"}},
+		directions: map[string]string{"N": "Norte", "S": "Sur", "E": "Este", "W": "Oeste"},
+		decimalSep: ",",
+	},
+}
+
+// localeFor returns the formatting data of a locale, defaulting to English.
+func localeFor(locale Locale) localeData {
+	if data, ok := locales[locale]; ok {
+		return data
+	}
+	return locales[LocaleEnglish]
+}
+
+// DirectionName returns the full name of a direction letter (N, S, E, W) in
+// the given locale. Unknown locales fall back to English and unknown
+// directions are returned unchanged.
+func DirectionName(direction string, locale Locale) string {
+	if name, ok := localeFor(locale).directions[direction]; ok {
+		return name
+	}
+	return direction
+}
+
+// FormatStyle selects the layout produced by Format.
+type FormatStyle int
+
+const (
+	// StyleSymbols uses degree, minute and second symbols: 40°26'46.30" N.
+	StyleSymbols FormatStyle = iota
+	// StyleUnits spells out the units in the locale: 40 degrees 26 minutes 46.30 seconds N.
+	StyleUnits
+	// StyleWords spells out the whole value: forty degrees, twenty-six minutes,
+	// forty-six point three seconds north.
+	StyleWords
+	// StylePhonetic reads the value digit by digit for radio transmission:
+	// Fower Zero degrees Two Six minutes Fower Six Decimal Tree Zero seconds North.
+	StylePhonetic
+	// StyleASCII avoids symbols for screen readers and non-Unicode terminals:
+	// 40d 26m 46.30s N.
+	StyleASCII
+)
+
+// FormatOptions controls the output of Format.
+type FormatOptions struct {
+	Style         FormatStyle  // Layout of the output.
+	Locale        Locale       // Language of unit words and direction names, English by default.
+	FullDirection bool         // Spell out the direction ("North") instead of using its letter.
+	Precision     int          // Number of decimals of the seconds.
+	Rounding      RoundingMode // Rounding of the seconds to Precision decimals.
+	ShowAccuracy  bool         // Append the accuracy of a Coordinate, when known, as a ± angle.
+	AutoPrecision bool         // Derive Precision from the accuracy of a Coordinate, when known.
+	// Fraction, when above 1, writes the seconds rounded to a vulgar
+	// fraction of this denominator instead of decimals, as in 46½" or
+	// "46 1/2s" in the ASCII style. It applies to the symbols, units and
+	// ASCII styles.
+	Fraction int
+}
+
+// DefaultFormatOptions returns the options under which Format matches String.
+func DefaultFormatOptions() FormatOptions {
+	return FormatOptions{Style: StyleSymbols, Precision: 2}
+}
+
+// Format returns the DMS formatted according to opts.
+func (d *DMS) Format(opts FormatOptions) string {
+	r := d.roundedSeconds(max(opts.Precision, 0), opts.Rounding)
+	switch opts.Style {
+	case StyleWords:
+		return r.formatWords(opts)
+	case StylePhonetic:
+		return r.formatPhonetic(opts)
+	}
+	loc := localeFor(opts.Locale)
+	seconds := strconv.FormatFloat(r.Seconds, 'f', max(opts.Precision, 0), 64)
+	seconds = strings.Replace(seconds, ".", loc.decimalSep, 1)
+	if opts.Fraction > 1 {
+		r, seconds = fractionalSeconds(d, opts.Fraction, opts.Style == StyleSymbols)
+	}
+	direction := r.Direction
+	if opts.FullDirection {
+		direction = DirectionName(r.Direction, opts.Locale)
+	}
+	switch opts.Style {
+	case StyleUnits:
+		return fmt.Sprintf("%d %s %d %s %s %s %s",
+			r.Degree, unitWord(loc, 0, r.Degree == 1),
+			r.Minutes, unitWord(loc, 1, r.Minutes == 1),
+			seconds, unitWord(loc, 2, seconds == "1"), direction)
+	case StyleASCII:
+		return fmt.Sprintf("%dd %dm %ss %s", r.Degree, r.Minutes, seconds, direction)
+	default:
+		return fmt.Sprintf(`%d°%d'%s" %s`, r.Degree, r.Minutes, seconds, direction)
+	}
+}
+
+// Format returns the coordinate formatted according to opts.
+func (c *Coordinate) Format(opts FormatOptions) string {
+	if opts.AutoPrecision && c.Accuracy > 0 {
+		opts.Precision = PrecisionForAccuracy(c.Accuracy)
+	}
+	s := c.Latitude.Format(opts) + " " + c.Longitude.Format(opts)
+	if opts.ShowAccuracy && c.Accuracy > 0 {
+		s += " " + formatAccuracy(c.Accuracy, opts)
+	}
+	return s
+}
+
+// unitWord returns the singular or plural word of a unit (0 degree, 1 minute,
+// 2 second) in a locale.
+func unitWord(loc localeData, unit int, singular bool) string {
+	if singular {
+		return loc.units[unit][0]
+	}
+	return loc.units[unit][1]
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "testing"
+
+// formatSample is the value formatted by the format tests.
+var formatSample = DMS{Degree: 40, Minutes: 1, Seconds: 46.3, Direction: "N"}
+
+func TestFormatLocales(t *testing.T) {
+	tests := []struct {
+		locale        Locale
+		style         FormatStyle
+		fullDirection bool
+		want          string
+	}{
+		{LocaleEnglish, StyleSymbols, false, `40°1'46.30" N`},
+		{LocaleEnglish, StyleSymbols, true, `40°1'46.30" North`},
+		{LocaleEnglish, StyleUnits, false, `40 degrees 1 minute 46.30 seconds N`},
+		{LocalePersian, StyleUnits, true, `40 درجه 1 دقیقه 46.30 ثانیه شمال`},
+		{LocaleFrench, StyleSymbols, true, `40°1'46,30" Nord`},
+		{LocaleFrench, StyleUnits, false, `40 degrés 1 minute 46,30 secondes N`},
+		{LocaleGerman, StyleUnits, true, `40 Grad 1 Minute 46,30 Sekunden Nord`},
+		{LocaleSpanish, StyleUnits, true, `40 grados 1 minuto 46,30 segundos Norte`},
+		{"xx", StyleUnits, true, `40 degrees 1 minute 46.30 seconds North`},
+	}
+	for _, tt := range tests {
+		opts := DefaultFormatOptions()
+		opts.Locale, opts.Style, opts.FullDirection = tt.locale, tt.style, tt.fullDirection
+		if got := formatSample.Format(opts); got != tt.want {
+			t.Errorf("Format(%s, %d, %v) = %q, want %q", tt.locale, tt.style, tt.fullDirection, got, tt.want)
+		}
+	}
+}
+
+func TestFormatLocalesParse(t *testing.T) {
+	for _, locale := range []Locale{LocaleEnglish, LocalePersian, LocaleFrench, LocaleGerman, LocaleSpanish} {
+		for _, style := range []FormatStyle{StyleSymbols, StyleUnits} {
+			opts := DefaultFormatOptions()
+			opts.Locale, opts.Style, opts.FullDirection = locale, style, true
+			s := formatSample.Format(opts)
+			got, err := ParseDMS(s)
+			if err != nil || got.Direction != "N" || got.Degree != 40 || got.Minutes != 1 {
+				t.Errorf("ParseDMS(%q) = %+v, %v", s, got, err)
+			}
+		}
+	}
+}
+
+func TestDirectionName(t *testing.T) {
+	tests := []struct {
+		direction string
+		locale    Locale
+		want      string
+	}{
+		{"N", LocaleEnglish, "North"},
+		{"W", LocalePersian, "غرب"},
+		{"E", LocaleGerman, "Ost"},
+		{"S", LocaleSpanish, "Sur"},
+		{"X", LocaleEnglish, "X"},
+	}
+	for _, tt := range tests {
+		if got := DirectionName(tt.direction, tt.locale); got != tt.want {
+			t.Errorf("DirectionName(%q, %s) = %q, want %q", tt.direction, tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestFormatASCII(t *testing.T) {
+	tests := []struct {
+		d             DMS
+		precision     int
+		fullDirection bool
+		want          string
+	}{
+		{formatSample, 2, false, "40d 1m 46.30s N"},
+		{formatSample, 2, true, "40d 1m 46.30s North"},
+		{DMS{179, 59, 59, "E"}, 2, false, "179d 59m 59.00s E"},
+		{DMS{8, 2, 29.6, "S"}, 0, false, "8d 2m 30s S"},
+	}
+	for _, tt := range tests {
+		opts := DefaultFormatOptions()
+		opts.Style, opts.Precision, opts.FullDirection = StyleASCII, tt.precision, tt.fullDirection
+		got := tt.d.Format(opts)
+		if got != tt.want {
+			t.Errorf("Format(%+v, StyleASCII) = %q, want %q", tt.d, got, tt.want)
+			continue
+		}
+		for _, r := range got {
+			if r > 0x7f {
+				t.Errorf("Format(%+v, StyleASCII) = %q contains non-ASCII %q", tt.d, got, r)
+			}
+		}
+		if _, err := ParseDMS(got); err != nil {
+			t.Errorf("ParseDMS(%q) error: %v", got, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Vulgar fractions
+//
+// Historic documents write fractional minutes and seconds as vulgar
+// fractions, as in `40°26'46½"` or "46 1/2 seconds".
+
+// vulgarFractions maps the Unicode vulgar fraction characters to their
+// numerators and denominators.
+var vulgarFractions = map[rune][2]int{
+	'½': {1, 2}, '⅓': {1, 3}, '⅔': {2, 3}, '¼': {1, 4}, '¾': {3, 4},
+	'⅕': {1, 5}, '⅖': {2, 5}, '⅗': {3, 5}, '⅘': {4, 5}, '⅙': {1, 6},
+	'⅚': {5, 6}, '⅐': {1, 7}, '⅛': {1, 8}, '⅜': {3, 8}, '⅝': {5, 8},
+	'⅞': {7, 8}, '⅑': {1, 9}, '⅒': {1, 10},
+}
+
+// vulgarFractionRunes maps numerators and denominators to the Unicode vulgar
+// fraction characters.
+var vulgarFractionRunes = func() map[[2]int]rune {
+	runes := make(map[[2]int]rune, len(vulgarFractions))
+	for r, f := range vulgarFractions {
+		runes[f] = r
+	}
+	return runes
+}()
+
+// readFraction reads a fraction such as "1/2" at runes[i], returning its
+// value and the index following it, or false when there is none.
+func readFraction(runes []rune, i int) (value float64, next int, ok bool, err error) {
+	if i < len(runes) {
+		if f, ok := vulgarFractions[runes[i]]; ok {
+			return float64(f[0]) / float64(f[1]), i + 1, true, nil
+		}
+	}
+	j := i
+	for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+		j++
+	}
+	if j == i || j+1 >= len(runes) || runes[j] != '/' || runes[j+1] < '0' || runes[j+1] > '9' {
+		return 0, i, false, nil
+	}
+	k := j + 1
+	for k < len(runes) && runes[k] >= '0' && runes[k] <= '9' {
+		k++
+	}
+	numerator, _ := strconv.Atoi(string(runes[i:j]))
+	denominator, _ := strconv.Atoi(string(runes[j+1 : k]))
+	if denominator == 0 || numerator >= denominator {
+		return 0, i, false, fmt.Errorf("Invalid fraction %q", string(runes[i:k]))
+	}
+	return float64(numerator) / float64(denominator), k, true, nil
+}
+
+// fractionalSeconds returns the DMS with its seconds rounded to a multiple of
+// 1/denominator, and the seconds written as a whole number followed by a
+// vulgar fraction: a Unicode fraction character when one exists and symbols
+// are allowed, as in "46½", or "46 1/2" otherwise.
+func fractionalSeconds(d *DMS, denominator int, symbols bool) (DMS, string) {
+	r := *d
+	units := math.Round(r.Seconds * float64(denominator))
+	r.Seconds = units / float64(denominator)
+	r.updateAfterRounding()
+	units = math.Round(r.Seconds * float64(denominator))
+	whole, numerator := int(units)/denominator, int(units)%denominator
+	seconds := strconv.Itoa(whole)
+	if numerator == 0 {
+		return r, seconds
+	}
+	g := gcd(numerator, denominator)
+	f := [2]int{numerator / g, denominator / g}
+	if c, ok := vulgarFractionRunes[f]; ok && symbols {
+		return r, seconds + string(c)
+	}
+	return r, fmt.Sprintf("%s %d/%d", seconds, f[0], f[1])
+}
+
+// gcd returns the greatest common divisor of two positive integers.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,46 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+// TestFractionRoundTrip formats values with vulgar fractions of seconds and
+// parses them back. The words and phonetic styles ignore Fraction and are not
+// read by ParseDMS.
+func TestFractionRoundTrip(t *testing.T) {
+	styles := []FormatStyle{StyleSymbols, StyleUnits, StyleASCII}
+	for _, style := range styles {
+		for _, fraction := range []int{2, 3, 4, 8, 16} {
+			for _, seconds := range []float64{0, 46.5, 46.25, 59.9, 12 + 1.0/3} {
+				d := DMS{Degree: 40, Minutes: 26, Seconds: seconds, Direction: "N"}
+				opts := DefaultFormatOptions()
+				opts.Style, opts.Fraction = style, fraction
+				s := d.Format(opts)
+				got, err := ParseDMS(s)
+				if err != nil {
+					t.Errorf("ParseDMS(%q): %v", s, err)
+					continue
+				}
+				if diff := math.Abs(DMSToDecimal(got)-DMSToDecimal(d)) * 3600; diff > 0.5/float64(fraction)+1e-9 {
+					t.Errorf("ParseDMS(%q) = %v, %g\" away from %v", s, got, diff, d)
+				}
+			}
+		}
+	}
+}
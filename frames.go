@@ -0,0 +1,107 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "math"
+
+// Cartesian frames
+//
+// Positions are converted on the WGS-84 ellipsoid, with heights in meters
+// above it.
+
+// ECEF is a position in the Earth-centered, Earth-fixed frame, in meters.
+type ECEF struct {
+	X, Y, Z float64
+}
+
+// ENU is a position in a local East-North-Up frame, in meters.
+type ENU struct {
+	East, North, Up float64
+}
+
+// NED is a position in a local North-East-Down frame, in meters.
+type NED struct {
+	North, East, Down float64
+}
+
+// ToECEF returns the ECEF position of c at height meters.
+func ToECEF(c Coordinate, height float64) ECEF {
+	lat, lon := c.radians()
+	x, y, z := geodeticToECEF(lat, lon, height, &EllipsoidWGS84)
+	return ECEF{x, y, z}
+}
+
+// FromECEF returns the coordinate and height in meters of an ECEF position.
+func FromECEF(p ECEF) (Coordinate, float64) {
+	lat, lon, height := ecefToGeodetic(p.X, p.Y, p.Z, &EllipsoidWGS84)
+	return coordinateFromDecimal(lat/degToRad, lon/degToRad), height
+}
+
+// ToENU returns the position of c at height meters in the East-North-Up
+// frame whose origin is ref at refHeight meters.
+func ToENU(ref Coordinate, refHeight float64, c Coordinate, height float64) ENU {
+	o, p := ToECEF(ref, refHeight), ToECEF(c, height)
+	dx, dy, dz := p.X-o.X, p.Y-o.Y, p.Z-o.Z
+	sinLat, cosLat, sinLon, cosLon := frameAngles(ref)
+	return ENU{
+		East:  -sinLon*dx + cosLon*dy,
+		North: -sinLat*cosLon*dx - sinLat*sinLon*dy + cosLat*dz,
+		Up:    cosLat*cosLon*dx + cosLat*sinLon*dy + sinLat*dz,
+	}
+}
+
+// FromENU returns the coordinate and height in meters of a position in the
+// East-North-Up frame whose origin is ref at refHeight meters.
+func FromENU(ref Coordinate, refHeight float64, e ENU) (Coordinate, float64) {
+	o := ToECEF(ref, refHeight)
+	sinLat, cosLat, sinLon, cosLon := frameAngles(ref)
+	return FromECEF(ECEF{
+		X: o.X - sinLon*e.East - sinLat*cosLon*e.North + cosLat*cosLon*e.Up,
+		Y: o.Y + cosLon*e.East - sinLat*sinLon*e.North + cosLat*sinLon*e.Up,
+		Z: o.Z + cosLat*e.North + sinLat*e.Up,
+	})
+}
+
+// ToNED returns the position of c at height meters in the North-East-Down
+// frame whose origin is ref at refHeight meters.
+func ToNED(ref Coordinate, refHeight float64, c Coordinate, height float64) NED {
+	return ToENU(ref, refHeight, c, height).NED()
+}
+
+// FromNED returns the coordinate and height in meters of a position in the
+// North-East-Down frame whose origin is ref at refHeight meters.
+func FromNED(ref Coordinate, refHeight float64, n NED) (Coordinate, float64) {
+	return FromENU(ref, refHeight, n.ENU())
+}
+
+// NED returns the position in the North-East-Down frame.
+func (e ENU) NED() NED {
+	return NED{North: e.North, East: e.East, Down: -e.Up}
+}
+
+// ENU returns the position in the East-North-Up frame.
+func (n NED) ENU() ENU {
+	return ENU{East: n.East, North: n.North, Up: -n.Down}
+}
+
+// frameAngles returns the sines and cosines of the latitude and longitude of
+// the origin of a local frame.
+func frameAngles(ref Coordinate) (sinLat, cosLat, sinLon, cosLon float64) {
+	lat, lon := ref.radians()
+	sinLat, cosLat = math.Sincos(lat)
+	sinLon, cosLon = math.Sincos(lon)
+	return sinLat, cosLat, sinLon, cosLon
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "strings"
+
+// Batch helpers
+
+// Located is implemented by the values that have a position, such as
+// Coordinate and Waypoint.
+type Located interface {
+	Location() Coordinate
+}
+
+// Location returns the coordinate itself, so that Coordinate implements
+// Located.
+func (c Coordinate) Location() Coordinate {
+	return c
+}
+
+// Location returns the coordinate of the waypoint.
+func (w Waypoint) Location() Coordinate {
+	return w.Coordinate
+}
+
+// Map returns the results of f applied to each item.
+func Map[T, U any](items []T, f func(T) U) []U {
+	result := make([]U, len(items))
+	for i, item := range items {
+		result[i] = f(item)
+	}
+	return result
+}
+
+// Filter returns the items for which keep returns true, in order.
+func Filter[T any](items []T, keep func(T) bool) []T {
+	var result []T
+	for _, item := range items {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Chunk splits items into consecutive slices of size items, the last of which
+// may be shorter, e.g. to batch database inserts. The chunks share the
+// backing array of items. It panics if size is not positive.
+func Chunk[T any](items []T, size int) [][]T {
+	if size <= 0 {
+		panic("dms: non-positive chunk size")
+	}
+	var chunks [][]T
+	for len(items) > size {
+		chunks = append(chunks, items[:size:size])
+		items = items[size:]
+	}
+	if len(items) > 0 {
+		chunks = append(chunks, items)
+	}
+	return chunks
+}
+
+// Locations returns the coordinates of located items.
+func Locations[T Located](items []T) []Coordinate {
+	return Map(items, T.Location)
+}
+
+// WithinRadius returns a predicate, for use with Filter, reporting whether an
+// item lies within radius meters (great-circle distance) of center.
+func WithinRadius[T Located](center Coordinate, radius float64) func(T) bool {
+	return func(item T) bool {
+		return Distance(center, item.Location()) <= radius
+	}
+}
+
+// InsideBox returns a predicate, for use with Filter, reporting whether an
+// item lies inside a bounding box.
+func InsideBox[T Located](box BoundingBox) func(T) bool {
+	return func(item T) bool {
+		return box.Contains(item.Location())
+	}
+}
+
+// BoundingBox is an area delimited by parallels and meridians, in decimal
+// degrees. A box with West greater than East crosses the antimeridian.
+type BoundingBox struct {
+	South, West, North, East float64
+}
+
+// Contains reports whether the box contains the coordinate, edges included.
+func (b *BoundingBox) Contains(c Coordinate) bool {
+	lat, lon := c.Decimal()
+	if lat < b.South || lat > b.North {
+		return false
+	}
+	if b.West <= b.East {
+		return lon >= b.West && lon <= b.East
+	}
+	return lon >= b.West || lon <= b.East
+}
+
+// SouthWest returns the south-west corner of the box.
+func (b *BoundingBox) SouthWest() Coordinate {
+	return coordinateFromDecimal(b.South, b.West)
+}
+
+// NorthEast returns the north-east corner of the box.
+func (b *BoundingBox) NorthEast() Coordinate {
+	return coordinateFromDecimal(b.North, b.East)
+}
+
+// String returns the box in decimal degrees as "west,south,east,north", the
+// order used by the bbox parameter of map services.
+func (b *BoundingBox) String() string {
+	return strings.Join([]string{
+		formatDecimal(b.West, geoURIPrecision),
+		formatDecimal(b.South, geoURIPrecision),
+		formatDecimal(b.East, geoURIPrecision),
+		formatDecimal(b.North, geoURIPrecision),
+	}, ",")
+}
+
+// StringDMS returns the south-west and north-east corners of the box in DMS
+// notation.
+func (b *BoundingBox) StringDMS() string {
+	sw, ne := b.SouthWest(), b.NorthEast()
+	return sw.String() + " – " + ne.String()
+}
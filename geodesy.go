@@ -0,0 +1,169 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "math"
+
+// Parameters of the WGS-84 reference ellipsoid.
+const (
+	wgs84A   = 6378137.0             // Semi-major axis in meters.
+	wgs84F   = 1 / 298.257223563     // Flattening.
+	wgs84E2  = wgs84F * (2 - wgs84F) // First eccentricity squared.
+	degToRad = math.Pi / 180         // Degrees to radians factor.
+)
+
+// earthRadius is the mean radius of the Earth in meters.
+const earthRadius = 6371008.8
+
+// Arc length helpers
+
+// MetersPerDegreeLat returns the length in meters of one degree of latitude at
+// the given latitude on the WGS-84 ellipsoid.
+func MetersPerDegreeLat(lat float64) float64 {
+	sin := math.Sin(lat * degToRad)
+	w := 1 - wgs84E2*sin*sin
+	return wgs84A * (1 - wgs84E2) / (w * math.Sqrt(w)) * degToRad
+}
+
+// MetersPerDegreeLon returns the length in meters of one degree of longitude
+// at the given latitude on the WGS-84 ellipsoid.
+func MetersPerDegreeLon(lat float64) float64 {
+	phi := lat * degToRad
+	sin := math.Sin(phi)
+	return wgs84A / math.Sqrt(1-wgs84E2*sin*sin) * math.Cos(phi) * degToRad
+}
+
+// MetersPerSecondOfArc returns the length in meters of one second of arc of
+// latitude and of longitude at the given latitude on the WGS-84 ellipsoid.
+func MetersPerSecondOfArc(lat float64) (north, east float64) {
+	return MetersPerDegreeLat(lat) / 3600, MetersPerDegreeLon(lat) / 3600
+}
+
+// Distance returns the great-circle distance between two coordinates in
+// meters, using the haversine formula on a spherical Earth.
+func Distance(a, b Coordinate) float64 {
+	lat1, lon1 := a.radians()
+	lat2, lon2 := b.radians()
+	return haversine(lat1, lon1, lat2, lon2)
+}
+
+// haversine returns the great-circle distance in meters between two
+// positions given in radians.
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	sinLat := math.Sin((lat2 - lat1) / 2)
+	sinLon := math.Sin((lon2 - lon1) / 2)
+	h := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLon*sinLon
+	return 2 * earthRadius * math.Asin(math.Sqrt(math.Min(h, 1)))
+}
+
+// Bearing returns the initial great-circle bearing from a to b in degrees
+// clockwise from true north, in the range [0, 360).
+func Bearing(a, b Coordinate) float64 {
+	lat1, lon1 := a.radians()
+	lat2, lon2 := b.radians()
+	return Normalize360(initialBearing(lat1, lon1, lat2, lon2) / degToRad)
+}
+
+// initialBearing returns the initial great-circle bearing in radians between
+// two positions given in radians.
+func initialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	y := math.Sin(lon2-lon1) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(lon2-lon1)
+	return math.Atan2(y, x)
+}
+
+// Destination returns the position reached by travelling distance meters
+// from start along the great circle with the given initial bearing in degrees.
+func Destination(start Coordinate, bearing, distance float64) Coordinate {
+	lat1, lon1 := start.radians()
+	theta := bearing * degToRad
+	delta := distance / earthRadius
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lon2 := lon1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1),
+		math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+	return coordinateFromDecimal(lat2/degToRad, lon2/degToRad)
+}
+
+// CrossTrackDistance returns the distance in meters of p from the great circle
+// through a and b. It is positive when p lies to the right of the path from a
+// to b and negative when it lies to the left.
+func CrossTrackDistance(p, a, b Coordinate) float64 {
+	return crossTrackAngle(p, a, b) * earthRadius
+}
+
+// AlongTrackDistance returns the distance in meters from a to the point on
+// the great circle through a and b closest to p. It is negative when that
+// point lies behind a.
+func AlongTrackDistance(p, a, b Coordinate) float64 {
+	delta13 := Distance(a, p) / earthRadius
+	deltaXT := crossTrackAngle(p, a, b)
+	latA, lonA := a.radians()
+	latB, lonB := b.radians()
+	latP, lonP := p.radians()
+	theta12 := initialBearing(latA, lonA, latB, lonB)
+	theta13 := initialBearing(latA, lonA, latP, lonP)
+	along := math.Acos(math.Max(-1, math.Min(1, math.Cos(delta13)/math.Abs(math.Cos(deltaXT)))))
+	if math.Cos(theta12-theta13) < 0 {
+		along = -along
+	}
+	return along * earthRadius
+}
+
+// crossTrackAngle returns the angular cross-track distance of p in radians.
+func crossTrackAngle(p, a, b Coordinate) float64 {
+	latA, lonA := a.radians()
+	latB, lonB := b.radians()
+	latP, lonP := p.radians()
+	delta13 := haversine(latA, lonA, latP, lonP) / earthRadius
+	theta12 := initialBearing(latA, lonA, latB, lonB)
+	theta13 := initialBearing(latA, lonA, latP, lonP)
+	return math.Asin(math.Sin(delta13) * math.Sin(theta13-theta12))
+}
+
+// radians returns the signed latitude and longitude of the coordinate in radians.
+func (c *Coordinate) radians() (lat, lon float64) {
+	lat, lon = c.Decimal()
+	return lat * degToRad, lon * degToRad
+}
+
+// Local offsets
+
+// Offset returns the position east meters east and north meters north of c,
+// negative values pointing west and south, on the WGS-84 ellipsoid. The
+// offset is applied in the local tangent plane at the mean latitude of the
+// move, which is accurate to millimeters for offsets up to a few kilometers,
+// e.g. for sensor lever arms. The accuracy of c is kept.
+func Offset(c Coordinate, east, north float64) Coordinate {
+	lat, lon := c.Decimal()
+	lat2 := lat + north/MetersPerDegreeLat(lat)
+	mid := (lat + lat2) / 2
+	lat2 = lat + north/MetersPerDegreeLat(mid)
+	mid = (lat + lat2) / 2
+	if perLon := MetersPerDegreeLon(mid); perLon > 1e-9 {
+		lon += east / perLon
+	}
+	result := coordinateFromDecimal(lat2, lon)
+	result.Accuracy = c.Accuracy
+	return result
+}
+
+// OffsetRelative returns the position forward meters ahead of c along the
+// heading, in degrees from true north, and right meters to its right, e.g.
+// for an antenna mounted 3 m forward of a vehicle's reference point.
+func OffsetRelative(c Coordinate, heading, forward, right float64) Coordinate {
+	sin, cos := math.Sincos(heading * degToRad)
+	return Offset(c, forward*sin+right*cos, forward*cos-right*sin)
+}
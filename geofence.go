@@ -0,0 +1,195 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// GeofenceKind identifies the shape of a Geofence.
+type GeofenceKind string
+
+// Geofence shapes.
+const (
+	CircleFence  GeofenceKind = "circle"  // Geodesic circle around a center.
+	PolygonFence GeofenceKind = "polygon" // Polygon with great-circle edges.
+)
+
+// Geofence is a circular or polygonal area that positions can be tested
+// against. Fence definitions marshal to JSON with decimal [lat, lon] pairs:
+//
+//	{"name":"yard","type":"circle","center":[40.446,-79.982],"radius":250}
+//	{"name":"lot","type":"polygon","vertices":[[40.1,-79.1],[40.2,-79.1],[40.2,-79.2]]}
+type Geofence struct {
+	Name     string       // Optional name of the fence.
+	Kind     GeofenceKind // Shape of the fence.
+	Center   Coordinate   // Center of a circular fence.
+	Radius   float64      // Radius of a circular fence in meters.
+	Vertices []Coordinate // Vertices of a polygonal fence, without repeating the first.
+}
+
+// NewCircleFence creates a circular geofence.
+func NewCircleFence(name string, center Coordinate, radius float64) (Geofence, error) {
+	g := Geofence{Name: name, Kind: CircleFence, Center: center, Radius: radius}
+	return g, g.Validate()
+}
+
+// NewPolygonFence creates a polygonal geofence.
+func NewPolygonFence(name string, vertices []Coordinate) (Geofence, error) {
+	g := Geofence{Name: name, Kind: PolygonFence, Vertices: vertices}
+	return g, g.Validate()
+}
+
+// Validate checks that the fence is a circle with a valid center and a
+// non-negative radius, or a polygon with at least three valid vertices.
+func (g *Geofence) Validate() error {
+	switch g.Kind {
+	case CircleFence:
+		if g.Radius < 0 || math.IsNaN(g.Radius) {
+			return errors.New("Invalid geofence radius")
+		}
+		return g.Center.Validate()
+	case PolygonFence:
+		if len(g.Vertices) < 3 {
+			return errors.New("Geofence polygon needs at least three vertices")
+		}
+		for i := range g.Vertices {
+			if err := g.Vertices[i].Validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("Invalid geofence type %q", g.Kind)
+}
+
+// Inside reports whether c lies inside the fence or on its edge. Polygon
+// edges are great-circle segments, as measured by EdgeDistance: c is inside
+// when the edges wind around it, as seen from c.
+func (g *Geofence) Inside(c Coordinate) bool {
+	if g.Kind == CircleFence {
+		return Distance(g.Center, c) <= g.Radius
+	}
+	p := unitVector(c)
+	var mean, normal vec3
+	winding := 0.0
+	n := len(g.Vertices)
+	for i := 0; i < n; i++ {
+		a, b := g.Vertices[i], g.Vertices[(i+1)%n]
+		if segmentDistance(c, a, b) < fenceEdgeTolerance {
+			return true
+		}
+		// Signed angle at c from the direction of a to that of b.
+		va, vb := unitVector(a), unitVector(b)
+		winding += math.Atan2(p.dot(va.cross(vb)), va.dot(vb)-va.dot(p)*vb.dot(p))
+		mean, normal = mean.add(va), normal.add(va.cross(vb))
+	}
+	// The edges wind the same way around the points of the polygon, and the
+	// other way around those of its complement, seen from the other side.
+	orientation := 1.0
+	if normal.dot(mean) < 0 {
+		orientation = -1
+	}
+	return winding*orientation > math.Pi
+}
+
+// fenceEdgeTolerance is the distance in meters within which a position is on
+// the edge of a polygonal fence.
+const fenceEdgeTolerance = 1e-3
+
+// EdgeDistance returns the distance in meters from c to the edge of the
+// fence, negative when c is inside the fence.
+func (g *Geofence) EdgeDistance(c Coordinate) float64 {
+	if g.Kind == CircleFence {
+		return Distance(g.Center, c) - g.Radius
+	}
+	distance := math.Inf(1)
+	n := len(g.Vertices)
+	for i := 0; i < n; i++ {
+		distance = math.Min(distance, segmentDistance(c, g.Vertices[i], g.Vertices[(i+1)%n]))
+	}
+	if g.Inside(c) {
+		return -distance
+	}
+	return distance
+}
+
+// segmentDistance returns the distance in meters from p to the great-circle
+// segment between a and b.
+func segmentDistance(p, a, b Coordinate) float64 {
+	along := AlongTrackDistance(p, a, b)
+	if along > 0 && along < Distance(a, b) {
+		return math.Abs(CrossTrackDistance(p, a, b))
+	}
+	return math.Min(Distance(p, a), Distance(p, b))
+}
+
+// jsonGeofence is the JSON layout of a Geofence.
+type jsonGeofence struct {
+	Name     string       `json:"name,omitempty"`
+	Kind     GeofenceKind `json:"type"`
+	Center   *[2]float64  `json:"center,omitempty"`
+	Radius   float64      `json:"radius,omitempty"`
+	Vertices [][2]float64 `json:"vertices,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (g Geofence) MarshalJSON() ([]byte, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	out := jsonGeofence{Name: g.Name, Kind: g.Kind}
+	if g.Kind == CircleFence {
+		lat, lon := g.Center.Decimal()
+		out.Center, out.Radius = &[2]float64{lat, lon}, g.Radius
+	}
+	for i := range g.Vertices {
+		lat, lon := g.Vertices[i].Decimal()
+		out.Vertices = append(out.Vertices, [2]float64{lat, lon})
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and validates the fence.
+func (g *Geofence) UnmarshalJSON(data []byte) error {
+	var in jsonGeofence
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	result := Geofence{Name: in.Name, Kind: in.Kind, Radius: in.Radius}
+	if in.Center != nil {
+		center, err := NewCoordinate(in.Center[0], in.Center[1])
+		if err != nil {
+			return err
+		}
+		result.Center = center
+	}
+	for _, v := range in.Vertices {
+		vertex, err := NewCoordinate(v[0], v[1])
+		if err != nil {
+			return err
+		}
+		result.Vertices = append(result.Vertices, vertex)
+	}
+	if err := result.Validate(); err != nil {
+		return err
+	}
+	*g = result
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeofenceJSON(t *testing.T) {
+	tests := []string{
+		`{"name":"yard","type":"circle","center":[40.446,-79.982],"radius":250}`,
+		`{"name":"lot","type":"polygon","vertices":[[40.1,-79.1],[40.2,-79.1],[40.2,-79.2]]}`,
+		`{"type":"circle","center":[0,0]}`,
+	}
+	for _, s := range tests {
+		var g Geofence
+		if err := json.Unmarshal([]byte(s), &g); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", s, err)
+			continue
+		}
+		data, err := json.Marshal(g)
+		if err != nil {
+			t.Errorf("Marshal(%s) error: %v", s, err)
+			continue
+		}
+		var back Geofence
+		if err := json.Unmarshal(data, &back); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", data, err)
+			continue
+		}
+		if back.Name != g.Name || back.Kind != g.Kind || back.Radius != g.Radius ||
+			Distance(back.Center, g.Center) > 1e-3 || len(back.Vertices) != len(g.Vertices) {
+			t.Errorf("round trip of %s = %+v, want %+v", s, back, g)
+			continue
+		}
+		for i := range g.Vertices {
+			if Distance(back.Vertices[i], g.Vertices[i]) > 1e-3 {
+				t.Errorf("round trip of %s vertex %d = %v, want %v", s, i, back.Vertices[i], g.Vertices[i])
+			}
+		}
+	}
+}
+
+func TestGeofenceJSONMalformed(t *testing.T) {
+	tests := []string{
+		`{"type":"circle","center":[40,-79],"radius":-1}`,
+		`{"type":"circle","center":[91,-79],"radius":10}`,
+		`{"type":"polygon","vertices":[[40.1,-79.1],[40.2,-79.1]]}`,
+		`{"type":"polygon","vertices":[[40.1,-79.1],[40.2,-79.1],[40.2,-181]]}`,
+		`{"type":"square","center":[40,-79],"radius":10}`,
+		`{"type":"circle","center":"40,-79"}`,
+		`[]`,
+	}
+	for _, s := range tests {
+		var g Geofence
+		if err := json.Unmarshal([]byte(s), &g); err == nil {
+			t.Errorf("Unmarshal(%s) = %+v, want error", s, g)
+		}
+	}
+	if _, err := json.Marshal(Geofence{Kind: PolygonFence}); err == nil {
+		t.Error("Marshal(empty polygon) succeeded, want error")
+	}
+}
+
+func TestGeofenceInside(t *testing.T) {
+	center := coordinateFromDecimal(40.446, -79.982)
+	circle, err := NewCircleFence("yard", center, 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	polygon, err := NewPolygonFence("lot", []Coordinate{
+		coordinateFromDecimal(40, -80),
+		coordinateFromDecimal(40, -79),
+		coordinateFromDecimal(41, -79),
+		coordinateFromDecimal(41, -80),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		fence  Geofence
+		c      Coordinate
+		inside bool
+	}{
+		{circle, center, true},
+		{circle, coordinateFromDecimal(40.447, -79.982), true},
+		{circle, coordinateFromDecimal(40.45, -79.982), false},
+		{polygon, coordinateFromDecimal(40.5, -79.5), true},
+		{polygon, coordinateFromDecimal(40, -80), true},
+		{polygon, coordinateFromDecimal(40, -79.5), false}, // South of the great-circle edge.
+		{polygon, coordinateFromDecimal(41.5, -79.5), false},
+		{polygon, coordinateFromDecimal(-40.5, 100.5), false},
+	}
+	for _, tt := range tests {
+		if got := tt.fence.Inside(tt.c); got != tt.inside {
+			t.Errorf("%s.Inside(%v) = %v, want %v", tt.fence.Name, tt.c, got, tt.inside)
+		}
+		if got := tt.fence.EdgeDistance(tt.c); (got <= 0) != tt.inside {
+			t.Errorf("%s.EdgeDistance(%v) = %v, inside %v", tt.fence.Name, tt.c, got, tt.inside)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Geoid models
+//
+// Heights above the WGS-84 ellipsoid, as reported by GNSS receivers and
+// carried by geo URIs, differ from orthometric heights above mean sea level
+// by the geoid undulation, which ranges from about -106 m to +85 m.
+
+// GeoidModel returns the height in meters of the geoid above the WGS-84
+// ellipsoid at a position. Implementations must be safe for concurrent use.
+type GeoidModel interface {
+	Undulation(c Coordinate) float64
+}
+
+// OrthometricHeight converts a height in meters above the ellipsoid at c into
+// a height above mean sea level.
+func OrthometricHeight(m GeoidModel, c Coordinate, ellipsoidal float64) float64 {
+	return ellipsoidal - m.Undulation(c)
+}
+
+// EllipsoidalHeight converts a height in meters above mean sea level at c
+// into a height above the ellipsoid.
+func EllipsoidalHeight(m GeoidModel, c Coordinate, orthometric float64) float64 {
+	return orthometric + m.Undulation(c)
+}
+
+// GeoidGrid is a GeoidModel interpolating bilinearly in a regular grid of
+// undulations. The package does not embed geoid data; load a grid such as
+// the EGM96 15' grid "WW15MGH.GRD" distributed by the NGA with ReadGeoidGrid.
+type GeoidGrid struct {
+	north, west      float64   // Position of the first value in degrees.
+	latStep, lonStep float64   // Grid spacing in degrees.
+	rows, cols       int       // Grid size.
+	global           bool      // Whether the columns wrap around the globe.
+	values           []float64 // Undulations row by row from north to south, west to east.
+}
+
+// NewGeoidGrid creates a grid of rows×cols undulations in meters, given row
+// by row from north to south and west to east, starting at north and west in
+// degrees, with the given spacing in degrees.
+func NewGeoidGrid(north, west, latStep, lonStep float64, rows, cols int, values []float64) (*GeoidGrid, error) {
+	if rows < 2 || cols < 2 || latStep <= 0 || lonStep <= 0 {
+		return nil, errors.New("Invalid geoid grid size")
+	}
+	if len(values) != rows*cols {
+		return nil, fmt.Errorf("Geoid grid has %d values, want %d", len(values), rows*cols)
+	}
+	span := float64(cols-1) * lonStep
+	return &GeoidGrid{
+		north: north, west: west, latStep: latStep, lonStep: lonStep,
+		rows: rows, cols: cols, global: span >= 360-lonStep, values: values,
+	}, nil
+}
+
+// ReadGeoidGrid reads a grid in the NGA ".GRD" text format: a header of the
+// south, north, west and east limits and the latitude and longitude spacing
+// in degrees, followed by the undulations in meters row by row from north to
+// south and west to east.
+func ReadGeoidGrid(r io.Reader) (*GeoidGrid, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	var numbers []float64
+	for scanner.Scan() {
+		v, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid geoid grid value %q", scanner.Text())
+		}
+		numbers = append(numbers, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(numbers) < 6 {
+		return nil, errors.New("Missing geoid grid header")
+	}
+	south, north, west, east, latStep, lonStep := numbers[0], numbers[1], numbers[2], numbers[3], numbers[4], numbers[5]
+	if latStep <= 0 || lonStep <= 0 {
+		return nil, errors.New("Invalid geoid grid size")
+	}
+	rows := int(math.Round((north-south)/latStep)) + 1
+	cols := int(math.Round((east-west)/lonStep)) + 1
+	return NewGeoidGrid(north, west, latStep, lonStep, rows, cols, numbers[6:])
+}
+
+// Undulation implements GeoidModel. Positions outside a regional grid take
+// the value of the nearest edge.
+func (g *GeoidGrid) Undulation(c Coordinate) float64 {
+	lat, lon := c.Decimal()
+	y := (g.north - lat) / g.latStep
+	x := (lon - g.west) / g.lonStep
+	if g.global {
+		x = Normalize360(lon-g.west) / g.lonStep
+	}
+	y = math.Max(0, math.Min(float64(g.rows-1), y))
+	x = math.Max(0, math.Min(float64(g.cols-1), x))
+	row, col := min(int(y), g.rows-2), min(int(x), g.cols-2)
+	fy, fx := y-float64(row), x-float64(col)
+	at := func(r, c int) float64 { return g.values[r*g.cols+c] }
+	top := at(row, col)*(1-fx) + at(row, col+1)*fx
+	bottom := at(row+1, col)*(1-fx) + at(row+1, col+1)*fx
+	return top*(1-fy) + bottom*fy
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// GeoTIFF georeferencing
+//
+// Only the first image of classic (not Big) TIFF files is read, and only
+// rasters in geographic longitude and latitude are supported.
+
+// GeoTIFFCorners holds the positions of the outer corners and the center of
+// a GeoTIFF raster.
+type GeoTIFFCorners struct {
+	UpperLeft  Coordinate
+	UpperRight Coordinate
+	LowerLeft  Coordinate
+	LowerRight Coordinate
+	Center     Coordinate
+}
+
+// TIFF and GeoTIFF tags and values.
+const (
+	tiffImageWidth         = 256
+	tiffImageLength        = 257
+	tiffModelPixelScale    = 33550
+	tiffModelTiepoint      = 33922
+	tiffGeoKeyDirectory    = 34735
+	geoKeyModelType        = 1024
+	geoKeyRasterType       = 1025
+	modelTypeGeographic    = 2
+	rasterTypePixelIsPoint = 2
+	tiffTypeShort          = 3
+	tiffTypeLong           = 4
+	tiffTypeDouble         = 12
+	tiffMaxEntryValueSize  = 4
+)
+
+// ReadGeoTIFFCorners reads the ModelTiepoint and ModelPixelScale tags of a
+// GeoTIFF and returns the positions of the outer edges of its corner pixels.
+// Rasters whose GeoKeys declare a projected model are rejected.
+func ReadGeoTIFFCorners(r io.ReaderAt) (GeoTIFFCorners, error) {
+	tags, err := readTIFFTags(r)
+	if err != nil {
+		return GeoTIFFCorners{}, err
+	}
+	width, height := tags.number(tiffImageWidth), tags.number(tiffImageLength)
+	scale, tiepoint := tags[tiffModelPixelScale], tags[tiffModelTiepoint]
+	if width == 0 || height == 0 {
+		return GeoTIFFCorners{}, errors.New("Missing TIFF image size")
+	}
+	if len(scale) < 2 || len(tiepoint) < 6 {
+		return GeoTIFFCorners{}, errors.New("Missing GeoTIFF tiepoint or pixel scale")
+	}
+	shift := 0.0
+	if keys := tags[tiffGeoKeyDirectory]; len(keys) >= 4 {
+		for i := 4; i+3 < len(keys); i += 4 {
+			switch keys[i] {
+			case geoKeyModelType:
+				if keys[i+3] != modelTypeGeographic {
+					return GeoTIFFCorners{}, errors.New("GeoTIFF is not in geographic coordinates")
+				}
+			case geoKeyRasterType:
+				if keys[i+3] == rasterTypePixelIsPoint {
+					// The tiepoint is at the center of its pixel.
+					shift = 0.5
+				}
+			}
+		}
+	}
+	at := func(i, j float64) (Coordinate, error) {
+		lon := tiepoint[3] + (i-tiepoint[0]-shift)*scale[0]
+		lat := tiepoint[4] - (j-tiepoint[1]-shift)*scale[1]
+		return NewCoordinate(lat, lon)
+	}
+	var corners GeoTIFFCorners
+	for _, c := range []struct {
+		dst  *Coordinate
+		i, j float64
+	}{
+		{&corners.UpperLeft, 0, 0},
+		{&corners.UpperRight, width, 0},
+		{&corners.LowerLeft, 0, height},
+		{&corners.LowerRight, width, height},
+		{&corners.Center, width / 2, height / 2},
+	} {
+		if *c.dst, err = at(c.i, c.j); err != nil {
+			return GeoTIFFCorners{}, fmt.Errorf("GeoTIFF corner out of range: %w", err)
+		}
+	}
+	return corners, nil
+}
+
+// tiffTags holds the numeric values of TIFF tags.
+type tiffTags map[uint16][]float64
+
+// number returns the first value of a tag, or 0.
+func (t tiffTags) number(tag uint16) float64 {
+	if v := t[tag]; len(v) > 0 {
+		return v[0]
+	}
+	return 0
+}
+
+// readTIFFTags reads the short, long and double tags of the first image file
+// directory of a TIFF.
+func readTIFFTags(r io.ReaderAt) (tiffTags, error) {
+	header := make([]byte, 8)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("Invalid TIFF header: %w", err)
+	}
+	var order binary.ByteOrder
+	switch string(header[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("Invalid TIFF byte order")
+	}
+	if order.Uint16(header[2:]) != 42 {
+		return nil, errors.New("Unsupported TIFF version, BigTIFF is not supported")
+	}
+	offset := int64(order.Uint32(header[4:]))
+	countBytes := make([]byte, 2)
+	if _, err := r.ReadAt(countBytes, offset); err != nil {
+		return nil, fmt.Errorf("Invalid TIFF directory: %w", err)
+	}
+	entries := make([]byte, 12*int(order.Uint16(countBytes)))
+	if _, err := r.ReadAt(entries, offset+2); err != nil {
+		return nil, fmt.Errorf("Invalid TIFF directory: %w", err)
+	}
+	tags := make(tiffTags)
+	for e := entries; len(e) >= 12; e = e[12:] {
+		tag, kind, count := order.Uint16(e), order.Uint16(e[2:]), int(order.Uint32(e[4:]))
+		size := map[uint16]int{tiffTypeShort: 2, tiffTypeLong: 4, tiffTypeDouble: 8}[kind]
+		if size == 0 || count > 1<<20 {
+			continue
+		}
+		data := e[8:12]
+		if count*size > tiffMaxEntryValueSize {
+			data = make([]byte, count*size)
+			if _, err := r.ReadAt(data, int64(order.Uint32(e[8:]))); err != nil {
+				return nil, fmt.Errorf("Invalid TIFF tag %d: %w", tag, err)
+			}
+		}
+		values := make([]float64, count)
+		for i := range values {
+			switch kind {
+			case tiffTypeShort:
+				values[i] = float64(order.Uint16(data[2*i:]))
+			case tiffTypeLong:
+				values[i] = float64(order.Uint32(data[4*i:]))
+			case tiffTypeDouble:
+				values[i] = math.Float64frombits(order.Uint64(data[8*i:]))
+			}
+		}
+		tags[tag] = values
+	}
+	return tags, nil
+}
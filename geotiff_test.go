@@ -0,0 +1,147 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// tiffEntry is a tag of a test TIFF.
+type tiffEntry struct {
+	tag    uint16
+	kind   uint16
+	values []float64
+}
+
+// tiffBytes returns a little-endian TIFF with the given tags in its first
+// image file directory and no image data.
+func tiffBytes(entries []tiffEntry) []byte {
+	le := binary.LittleEndian
+	buf := []byte("II")
+	buf = le.AppendUint16(buf, 42)
+	buf = le.AppendUint32(buf, 8)
+	buf = le.AppendUint16(buf, uint16(len(entries)))
+	extra := 8 + 2 + 12*len(entries) + 4
+	var values []byte
+	for _, e := range entries {
+		var data []byte
+		for _, v := range e.values {
+			switch e.kind {
+			case tiffTypeShort:
+				data = le.AppendUint16(data, uint16(v))
+			case tiffTypeLong:
+				data = le.AppendUint32(data, uint32(v))
+			case tiffTypeDouble:
+				data = le.AppendUint64(data, math.Float64bits(v))
+			}
+		}
+		buf = le.AppendUint16(buf, e.tag)
+		buf = le.AppendUint16(buf, e.kind)
+		buf = le.AppendUint32(buf, uint32(len(e.values)))
+		if len(data) <= tiffMaxEntryValueSize {
+			buf = append(buf, append(data, make([]byte, 4-len(data))...)...)
+			continue
+		}
+		buf = le.AppendUint32(buf, uint32(extra+len(values)))
+		values = append(values, data...)
+	}
+	buf = le.AppendUint32(buf, 0) // No next directory.
+	return append(buf, values...)
+}
+
+// geoTIFFEntries returns the tags of a 200×100 raster of 0.01° pixels with
+// its upper left corner at 41° N 10° E, with the given model and raster types.
+func geoTIFFEntries(model, raster float64) []tiffEntry {
+	return []tiffEntry{
+		{tiffImageWidth, tiffTypeLong, []float64{200}},
+		{tiffImageLength, tiffTypeShort, []float64{100}},
+		{tiffModelPixelScale, tiffTypeDouble, []float64{0.01, 0.01, 0}},
+		{tiffModelTiepoint, tiffTypeDouble, []float64{0, 0, 0, 10, 41, 0}},
+		{tiffGeoKeyDirectory, tiffTypeShort, []float64{1, 1, 0, 2, geoKeyModelType, 0, 1, model, geoKeyRasterType, 0, 1, raster}},
+	}
+}
+
+func TestReadGeoTIFFCorners(t *testing.T) {
+	tests := []struct {
+		raster               float64
+		north, west          float64
+		south, east          float64
+		centerLat, centerLon float64
+	}{
+		{1, 41, 10, 40, 12, 40.5, 11},
+		{rasterTypePixelIsPoint, 41.005, 9.995, 40.005, 11.995, 40.505, 10.995},
+	}
+	for _, tt := range tests {
+		data := tiffBytes(geoTIFFEntries(modelTypeGeographic, tt.raster))
+		corners, err := ReadGeoTIFFCorners(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("raster type %g: %v", tt.raster, err)
+		}
+		for _, c := range []struct {
+			name     string
+			got      Coordinate
+			lat, lon float64
+		}{
+			{"upper left", corners.UpperLeft, tt.north, tt.west},
+			{"upper right", corners.UpperRight, tt.north, tt.east},
+			{"lower left", corners.LowerLeft, tt.south, tt.west},
+			{"lower right", corners.LowerRight, tt.south, tt.east},
+			{"center", corners.Center, tt.centerLat, tt.centerLon},
+		} {
+			lat, lon := c.got.Decimal()
+			if math.Abs(lat-c.lat) > 1e-9 || math.Abs(lon-c.lon) > 1e-9 {
+				t.Errorf("raster type %g: %s = %v, %v, want %v, %v", tt.raster, c.name, lat, lon, c.lat, c.lon)
+			}
+		}
+	}
+}
+
+func TestReadGeoTIFFCornersMalformed(t *testing.T) {
+	valid := tiffBytes(geoTIFFEntries(modelTypeGeographic, 1))
+	without := func(tag uint16) []byte {
+		var entries []tiffEntry
+		for _, e := range geoTIFFEntries(modelTypeGeographic, 1) {
+			if e.tag != tag {
+				entries = append(entries, e)
+			}
+		}
+		return tiffBytes(entries)
+	}
+	offWorld := geoTIFFEntries(modelTypeGeographic, 1)
+	offWorld[2].values = []float64{1, 1, 0}
+	notANumber := geoTIFFEntries(modelTypeGeographic, 1)
+	notANumber[2].values = []float64{math.NaN(), 0.01, 0}
+	tests := map[string][]byte{
+		"empty":            nil,
+		"bad byte order":   append([]byte("XX"), valid[2:]...),
+		"BigTIFF":          append([]byte{'I', 'I', 43, 0}, valid[4:]...),
+		"truncated":        valid[:20],
+		"projected":        tiffBytes(geoTIFFEntries(1, 1)),
+		"no width":         without(tiffImageWidth),
+		"no pixel scale":   without(tiffModelPixelScale),
+		"no tiepoint":      without(tiffModelTiepoint),
+		"corner off world": tiffBytes(offWorld),
+		"NaN pixel scale":  tiffBytes(notANumber),
+	}
+	for name, data := range tests {
+		if c, err := ReadGeoTIFFCorners(bytes.NewReader(data)); err == nil {
+			t.Errorf("%s: ReadGeoTIFFCorners = %+v, want error", name, c)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GeoURI represents an RFC 5870 geo URI such as "geo:40.446,-79.982;u=35".
+type GeoURI struct {
+	// Position identified by the URI. Its Accuracy is the uncertainty in
+	// meters (the u parameter), zero when absent.
+	Coordinate  Coordinate
+	Altitude    float64 // Altitude in meters, set when HasAltitude is true.
+	HasAltitude bool    // Whether the URI carries an altitude.
+}
+
+// geoURIPrecision is the number of decimals written for geo URI coordinates.
+const geoURIPrecision = 7
+
+// GeoURI returns the coordinate as an RFC 5870 geo URI.
+func (c *Coordinate) GeoURI() string {
+	g := GeoURI{Coordinate: *c}
+	return g.String()
+}
+
+// String returns the geo URI in its textual "geo:" form.
+func (g *GeoURI) String() string {
+	lat, lon := g.Coordinate.Decimal()
+	var b strings.Builder
+	b.WriteString("geo:")
+	b.WriteString(formatDecimal(lat, geoURIPrecision))
+	b.WriteString(",")
+	b.WriteString(formatDecimal(lon, geoURIPrecision))
+	if g.HasAltitude {
+		b.WriteString(",")
+		b.WriteString(formatDecimal(g.Altitude, 3))
+	}
+	if g.Coordinate.Accuracy > 0 {
+		b.WriteString(";u=")
+		b.WriteString(formatDecimal(g.Coordinate.Accuracy, 3))
+	}
+	return b.String()
+}
+
+// ParseGeoURI parses an RFC 5870 geo URI. Only the default WGS-84 coordinate
+// reference system is supported; unknown parameters are ignored.
+func ParseGeoURI(s string) (GeoURI, error) {
+	if len(s) < 4 || !strings.EqualFold(s[:4], "geo:") {
+		return GeoURI{}, fmt.Errorf("Invalid geo URI %q", s)
+	}
+	parts := strings.Split(s[4:], ";")
+	values := strings.Split(parts[0], ",")
+	if len(values) != 2 && len(values) != 3 {
+		return GeoURI{}, fmt.Errorf("Invalid geo URI coordinates %q", parts[0])
+	}
+	numbers := make([]float64, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return GeoURI{}, fmt.Errorf("Invalid geo URI coordinates %q", parts[0])
+		}
+		numbers[i] = n
+	}
+	coord, err := NewCoordinate(numbers[0], numbers[1])
+	if err != nil {
+		return GeoURI{}, err
+	}
+	g := GeoURI{Coordinate: coord}
+	if len(numbers) == 3 {
+		g.Altitude, g.HasAltitude = numbers[2], true
+	}
+	for _, param := range parts[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		switch strings.ToLower(name) {
+		case "crs":
+			if !strings.EqualFold(value, "wgs84") {
+				return GeoURI{}, fmt.Errorf("Unsupported geo URI crs %q", value)
+			}
+		case "u":
+			u, err := strconv.ParseFloat(value, 64)
+			if err != nil || u < 0 || math.IsNaN(u) || math.IsInf(u, 0) {
+				return GeoURI{}, errors.New("Invalid geo URI uncertainty")
+			}
+			g.Coordinate.Accuracy = u
+		}
+	}
+	return g, nil
+}
+
+// formatDecimal formats value with at most prec decimals, trimming trailing zeros.
+func formatDecimal(value float64, prec int) string {
+	s := strconv.FormatFloat(value, 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
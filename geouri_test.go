@@ -0,0 +1,69 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "testing"
+
+func TestGeoURIRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"geo:40.446195,-79.948862",
+		"geo:-33.8568,151.2153,58.5",
+		"geo:48.8566,2.3522;u=35",
+		"geo:0,180,-10;u=0.5",
+	} {
+		g, err := ParseGeoURI(s)
+		if err != nil {
+			t.Errorf("ParseGeoURI(%q): %v", s, err)
+			continue
+		}
+		if got := g.String(); got != s {
+			t.Errorf("ParseGeoURI(%q).String() = %q", s, got)
+		}
+	}
+}
+
+func TestParseGeoURIParameters(t *testing.T) {
+	g, err := ParseGeoURI("GEO:40.5,-79.9;crs=WGS84;u=12;foo=bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Coordinate.Accuracy != 12 || g.HasAltitude {
+		t.Errorf("ParseGeoURI = %+v, want accuracy 12 without altitude", g)
+	}
+}
+
+func TestParseGeoURIMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"geo",
+		"geo:40.5",
+		"geo:40.5,-79.9,1,2",
+		"geo:40.5,x",
+		"geo:95,10",
+		"geo:40.5,-79.9,NaN",
+		"geo:40.5,-79.9,Inf",
+		"geo:40.5,-79.9;u=-1",
+		"geo:40.5,-79.9;u=abc",
+		"geo:40.5,-79.9;u=NaN",
+		"geo:40.5,-79.9;u=Inf",
+		"geo:40.5,-79.9;u=-Inf",
+		"geo:40.5,-79.9;crs=utm",
+	} {
+		if g, err := ParseGeoURI(s); err == nil {
+			t.Errorf("ParseGeoURI(%q) = %+v, want error", s, g)
+		}
+	}
+}
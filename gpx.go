@@ -0,0 +1,176 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// GPX files
+
+// gpxNamespace is the XML namespace of GPX 1.1.
+const gpxNamespace = "http://www.topografix.com/GPX/1/1"
+
+// GPX holds the contents of a GPX file.
+type GPX struct {
+	Waypoints []Waypoint
+	Routes    []Route
+	Tracks    []Track
+}
+
+// gpxFile is the XML layout of a GPX file.
+type gpxFile struct {
+	XMLName   xml.Name   `xml:"gpx"`
+	Xmlns     string     `xml:"xmlns,attr,omitempty"`
+	Version   string     `xml:"version,attr"`
+	Creator   string     `xml:"creator,attr"`
+	Waypoints []gpxPoint `xml:"wpt"`
+	Routes    []gpxRoute `xml:"rte"`
+	Tracks    []gpxTrack `xml:"trk"`
+}
+
+// gpxRoute is the XML layout of a GPX route.
+type gpxRoute struct {
+	Name   string     `xml:"name,omitempty"`
+	Points []gpxPoint `xml:"rtept"`
+}
+
+// gpxTrack is the XML layout of a GPX track.
+type gpxTrack struct {
+	Name     string       `xml:"name,omitempty"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+// gpxSegment is the XML layout of a GPX track segment.
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+// gpxPoint is the XML layout of a GPX point, in the element order of the
+// GPX schema.
+type gpxPoint struct {
+	Lat         string `xml:"lat,attr"`
+	Lon         string `xml:"lon,attr"`
+	Time        string `xml:"time,omitempty"`
+	Name        string `xml:"name,omitempty"`
+	Description string `xml:"desc,omitempty"`
+	Symbol      string `xml:"sym,omitempty"`
+}
+
+// newGPXPoint returns the GPX point of a waypoint.
+func newGPXPoint(w *Waypoint) gpxPoint {
+	lat, lon := w.Coordinate.Decimal()
+	return gpxPoint{
+		Lat:         formatDecimal(lat, geoURIPrecision),
+		Lon:         formatDecimal(lon, geoURIPrecision),
+		Time:        formatWaypointTime(w.Time),
+		Name:        w.Name,
+		Description: w.Description,
+		Symbol:      w.Symbol,
+	}
+}
+
+// waypoint returns the waypoint of a GPX point.
+func (p *gpxPoint) waypoint() (Waypoint, error) {
+	w, err := newWaypointAt(p.Lat, p.Lon)
+	if err != nil {
+		return Waypoint{}, err
+	}
+	w.Name, w.Description, w.Symbol = p.Name, p.Description, p.Symbol
+	w.Time, err = parseWaypointTime(p.Time)
+	return w, err
+}
+
+// gpxWaypoints returns the waypoints of GPX points.
+func gpxWaypoints(points []gpxPoint) ([]Waypoint, error) {
+	var waypoints []Waypoint
+	for i := range points {
+		w, err := points[i].waypoint()
+		if err != nil {
+			return nil, err
+		}
+		waypoints = append(waypoints, w)
+	}
+	return waypoints, nil
+}
+
+// gpxPoints returns the GPX points of waypoints.
+func gpxPoints(waypoints []Waypoint) []gpxPoint {
+	var points []gpxPoint
+	for i := range waypoints {
+		points = append(points, newGPXPoint(&waypoints[i]))
+	}
+	return points
+}
+
+// ReadGPX reads a GPX 1.0 or 1.1 file.
+func ReadGPX(r io.Reader) (*GPX, error) {
+	var f gpxFile
+	if err := xml.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	g := &GPX{}
+	var err error
+	if g.Waypoints, err = gpxWaypoints(f.Waypoints); err != nil {
+		return nil, err
+	}
+	for _, rte := range f.Routes {
+		route := Route{Name: rte.Name}
+		if route.Waypoints, err = gpxWaypoints(rte.Points); err != nil {
+			return nil, err
+		}
+		g.Routes = append(g.Routes, route)
+	}
+	for _, trk := range f.Tracks {
+		track := Track{Name: trk.Name}
+		for _, seg := range trk.Segments {
+			points, err := gpxWaypoints(seg.Points)
+			if err != nil {
+				return nil, err
+			}
+			track.Segments = append(track.Segments, points)
+		}
+		g.Tracks = append(g.Tracks, track)
+	}
+	return g, nil
+}
+
+// Write writes the GPX file as GPX 1.1.
+func (g *GPX) Write(w io.Writer) error {
+	f := gpxFile{Xmlns: gpxNamespace, Version: "1.1", Creator: "github.com/mshafiee/dms"}
+	f.Waypoints = gpxPoints(g.Waypoints)
+	for _, route := range g.Routes {
+		f.Routes = append(f.Routes, gpxRoute{Name: route.Name, Points: gpxPoints(route.Waypoints)})
+	}
+	for _, track := range g.Tracks {
+		trk := gpxTrack{Name: track.Name}
+		for _, segment := range track.Segments {
+			trk.Segments = append(trk.Segments, gpxSegment{Points: gpxPoints(segment)})
+		}
+		f.Tracks = append(f.Tracks, trk)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(f); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
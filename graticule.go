@@ -0,0 +1,118 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"strconv"
+)
+
+// Graticules
+
+// graticuleIntervals holds the intervals offered by GraticuleInterval, in
+// increasing order.
+var graticuleIntervals = []Resolution{
+	1, 2, 5, 10, 15, 30,
+	ArcMinute, 2 * ArcMinute, 5 * ArcMinute, 10 * ArcMinute, 15 * ArcMinute, 30 * ArcMinute,
+	ArcDegree, 2 * ArcDegree, 5 * ArcDegree, 10 * ArcDegree, 15 * ArcDegree, 30 * ArcDegree, 45 * ArcDegree, 90 * ArcDegree,
+}
+
+// GraticuleLine is a parallel or meridian of a graticule, clipped to a
+// bounding box, with the label to draw at its ends.
+type GraticuleLine struct {
+	Axis       Axis       // AxisLatitude for a parallel, AxisLongitude for a meridian.
+	Value      float64    // Latitude or longitude of the line in decimal degrees.
+	Start, End Coordinate // Ends of the line on the edges of the box: west to east, or south to north.
+	Label      string     // Value of the line in DMS, down to the unit of the interval.
+}
+
+// GraticuleInterval returns the round interval giving about lines lines
+// across the larger side of the box.
+func GraticuleInterval(box BoundingBox, lines int) Resolution {
+	span := math.Max(box.North-box.South, Normalize360(box.East-box.West))
+	if box.East-box.West == 360 {
+		span = 360
+	}
+	want := span * 3600 / float64(max(lines, 1))
+	for _, interval := range graticuleIntervals {
+		if float64(interval) >= want {
+			return interval
+		}
+	}
+	return graticuleIntervals[len(graticuleIntervals)-1]
+}
+
+// Graticule returns the parallels and meridians crossing box every interval,
+// parallels first, from south to north and from west to east. A box with
+// West greater than East crosses the antimeridian.
+func Graticule(box BoundingBox, interval Resolution) []GraticuleLine {
+	if interval <= 0 {
+		return nil
+	}
+	step := interval.Degrees()
+	var lines []GraticuleLine
+	for i := math.Ceil(box.South/step - 1e-9); i*step <= box.North+1e-9; i++ {
+		lat := interval.Snap(i * step)
+		lines = append(lines, GraticuleLine{
+			Axis:  AxisLatitude,
+			Value: lat,
+			Start: coordinateFromDecimal(lat, box.West),
+			End:   coordinateFromDecimal(lat, box.East),
+			Label: graticuleLabel(lat, AxisLatitude, interval),
+		})
+	}
+	east := box.East
+	if east < box.West {
+		east += 360
+	}
+	first := math.Ceil(box.West/step - 1e-9)
+	for i := first; i*step <= east+1e-9; i++ {
+		if (i-first)*step >= 360-1e-9 {
+			break // Back to the first meridian around the globe.
+		}
+		lon := interval.Snap(i * step)
+		if lon != 180 {
+			lon = Normalize180(lon)
+		}
+		lines = append(lines, GraticuleLine{
+			Axis:  AxisLongitude,
+			Value: lon,
+			Start: coordinateFromDecimal(box.South, lon),
+			End:   coordinateFromDecimal(box.North, lon),
+			Label: graticuleLabel(lon, AxisLongitude, interval),
+		})
+	}
+	return lines
+}
+
+// graticuleLabel returns a graticule value in DMS, down to the unit of the
+// interval, e.g. 40° N, 40°30' N or 40°30'15" N. The equator, the prime
+// meridian and the antimeridian have no direction.
+func graticuleLabel(value float64, axis Axis, interval Resolution) string {
+	positive, negative := "N", "S"
+	if axis == AxisLongitude {
+		positive, negative = "E", "W"
+	}
+	d := DecimalToDMS(value, positive, negative)
+	if value == 0 || math.Abs(value) == 180 {
+		d.Direction = ""
+	}
+	decimals := 0
+	if s := strconv.FormatFloat(math.Mod(float64(interval), 1), 'f', -1, 64); len(s) > 2 {
+		decimals = len(s) - 2
+	}
+	return unitLabel(d.roundedSeconds(decimals, RoundHalfAwayFromZero), interval, decimals)
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraticule(t *testing.T) {
+	tests := []struct {
+		box      BoundingBox
+		interval Resolution
+		labels   []string
+	}{
+		{BoundingBox{South: 40.2, West: -80.1, North: 41.1, East: -79.4}, 30 * ArcMinute,
+			[]string{"40°30' N", "41°0' N", "80°0' W", "79°30' W"}},
+		{BoundingBox{South: -1, West: 178, North: 1, East: -178}, ArcDegree,
+			[]string{"1° S", "0°", "1° N", "178° E", "179° E", "180°", "179° W", "178° W"}},
+		{BoundingBox{South: 40.5, West: -79.51, North: 40.51, East: -79.5}, 15,
+			[]string{`40°30'0" N`, `40°30'15" N`, `40°30'30" N`, `79°30'30" W`, `79°30'15" W`, `79°30'0" W`}},
+		{BoundingBox{South: 40.2, West: -80.1, North: 40.3, East: -80}, 0, nil},
+	}
+	for _, tt := range tests {
+		var labels []string
+		for _, l := range Graticule(tt.box, tt.interval) {
+			labels = append(labels, l.Label)
+			lat, lon := l.Start.Decimal()
+			if l.Axis == AxisLatitude && (lat != l.Value || lon != tt.box.West) ||
+				l.Axis == AxisLongitude && (lat != tt.box.South || lon != l.Value && l.Value != 180) {
+				t.Errorf("Graticule(%+v) line %q starts at %v, %v", tt.box, l.Label, lat, lon)
+			}
+		}
+		if !reflect.DeepEqual(labels, tt.labels) {
+			t.Errorf("Graticule(%+v, %v) labels = %q, want %q", tt.box, tt.interval, labels, tt.labels)
+		}
+	}
+}
+
+func TestGraticuleInterval(t *testing.T) {
+	tests := []struct {
+		box   BoundingBox
+		lines int
+		want  Resolution
+	}{
+		{BoundingBox{South: 40.2, West: -80.1, North: 41.1, East: -79.4}, 5, 15 * ArcMinute},
+		{BoundingBox{South: -1, West: 178, North: 1, East: -178}, 5, ArcDegree},
+		{BoundingBox{South: 40.5, West: -79.51, North: 40.51, East: -79.5}, 5, 10},
+		{BoundingBox{South: -90, West: -180, North: 90, East: 180}, 8, 45 * ArcDegree},
+		{BoundingBox{South: -90, West: -180, North: 90, East: 180}, 0, 90 * ArcDegree},
+	}
+	for _, tt := range tests {
+		if got := GraticuleInterval(tt.box, tt.lines); got != tt.want {
+			t.Errorf("GraticuleInterval(%+v, %d) = %v, want %v", tt.box, tt.lines, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"math"
+)
+
+// Grid describes a raster, such as a DEM tile, whose cells are aligned with
+// parallels and meridians. Rows count southwards and columns eastwards from
+// the north-west corner of the grid.
+type Grid struct {
+	Origin     Coordinate // North-west corner of cell (0, 0).
+	CellHeight Resolution // Latitude span of a cell.
+	CellWidth  Resolution // Longitude span of a cell.
+	Rows, Cols int        // Dimensions of the grid in cells.
+}
+
+// Cell returns the row and column of the cell containing c, and false when c
+// lies outside the grid.
+func (g *Grid) Cell(c Coordinate) (row, col int, ok bool) {
+	lat, lon := c.Decimal()
+	lat0, lon0 := g.Origin.Decimal()
+	if g.CellHeight <= 0 || g.CellWidth <= 0 {
+		return 0, 0, false
+	}
+	row = int(math.Floor((lat0-lat)/g.CellHeight.Degrees() + 1e-9))
+	col = int(math.Floor(Normalize360(lon-lon0)/g.CellWidth.Degrees() + 1e-9))
+	if row < 0 || row >= g.Rows || col < 0 || col >= g.Cols {
+		return 0, 0, false
+	}
+	return row, col, true
+}
+
+// CellCorner returns the north-west corner of a cell.
+func (g *Grid) CellCorner(row, col int) (Coordinate, error) {
+	return g.cellPoint(float64(row), float64(col))
+}
+
+// CellCenter returns the center of a cell.
+func (g *Grid) CellCenter(row, col int) (Coordinate, error) {
+	return g.cellPoint(float64(row)+0.5, float64(col)+0.5)
+}
+
+// cellPoint returns the position at fractional row and column offsets.
+func (g *Grid) cellPoint(row, col float64) (Coordinate, error) {
+	if row < 0 || row >= float64(g.Rows) || col < 0 || col >= float64(g.Cols) {
+		return Coordinate{}, errors.New("Grid cell out of range")
+	}
+	lat0, lon0 := g.Origin.Decimal()
+	return NewCoordinate(lat0-row*g.CellHeight.Degrees(), Normalize180(lon0+col*g.CellWidth.Degrees()))
+}
@@ -0,0 +1,195 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Grid reference systems
+
+// geohashAlphabet is the base-32 alphabet of geohashes.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash returns the geohash of the coordinate with the given number of
+// characters, between 1 and 12.
+func (c *Coordinate) Geohash(length int) string {
+	length = min(max(length, 1), 12)
+	lat, lon := c.Decimal()
+	latRange, lonRange := [2]float64{-90, 90}, [2]float64{-180, 180}
+	var b strings.Builder
+	bits, ch, even := 0, 0, true
+	for b.Len() < length {
+		r, value := &latRange, lat
+		if even {
+			r, value = &lonRange, lon
+		}
+		mid := (r[0] + r[1]) / 2
+		ch <<= 1
+		if value >= mid {
+			ch |= 1
+			r[0] = mid
+		} else {
+			r[1] = mid
+		}
+		even = !even
+		if bits++; bits == 5 {
+			b.WriteByte(geohashAlphabet[ch])
+			bits, ch = 0, 0
+		}
+	}
+	return b.String()
+}
+
+// ParseGeohash returns the center of the cell of a geohash, with its accuracy
+// set to the half-diagonal of the cell.
+func ParseGeohash(hash string) (Coordinate, error) {
+	if hash == "" {
+		return Coordinate{}, errors.New("Empty geohash")
+	}
+	latRange, lonRange := [2]float64{-90, 90}, [2]float64{-180, 180}
+	even := true
+	for _, r := range strings.ToLower(hash) {
+		idx := strings.IndexRune(geohashAlphabet, r)
+		if idx < 0 {
+			return Coordinate{}, fmt.Errorf("Invalid geohash %q", hash)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			rng := &latRange
+			if even {
+				rng = &lonRange
+			}
+			mid := (rng[0] + rng[1]) / 2
+			if idx>>bit&1 == 1 {
+				rng[0] = mid
+			} else {
+				rng[1] = mid
+			}
+			even = !even
+		}
+	}
+	c, err := NewCoordinate((latRange[0]+latRange[1])/2, (lonRange[0]+lonRange[1])/2)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	corner, _ := NewCoordinate(latRange[0], lonRange[0])
+	c.Accuracy = Distance(c, corner)
+	return c, nil
+}
+
+// Maidenhead returns the Maidenhead locator of the coordinate, as used by
+// amateur radio operators, with the given number of character pairs between
+// 1 and 5 (3 pairs give the common 6-character locator, e.g. "FN10ak").
+func (c *Coordinate) Maidenhead(pairs int) string {
+	pairs = min(max(pairs, 1), 5)
+	lat, lon := c.Decimal()
+	// Work in degrees from the south-west corner of field AA, keeping the
+	// north pole and antimeridian inside the last field.
+	lon = math.Min(lon+180, 360-1e-9)
+	lat = math.Min(lat+90, 180-1e-9)
+	var b strings.Builder
+	lonSize, latSize := 20.0, 10.0
+	for i := 0; i < pairs; i++ {
+		divisions := 10.0
+		base := byte('0')
+		switch {
+		case i == 0:
+			divisions, base = 18, 'A'
+		case i%2 == 0:
+			divisions, base = 24, 'a'
+		}
+		if i > 0 {
+			lonSize /= divisions
+			latSize /= divisions
+		}
+		lonIdx, latIdx := math.Floor(lon/lonSize), math.Floor(lat/latSize)
+		b.WriteByte(base + byte(lonIdx))
+		b.WriteByte(base + byte(latIdx))
+		lon -= lonIdx * lonSize
+		lat -= latIdx * latSize
+	}
+	return b.String()
+}
+
+// ParseMaidenhead returns the center of the square of a Maidenhead locator of
+// 1 to 5 character pairs, with its accuracy set to the half-diagonal of the
+// square. Letters are accepted in either case.
+func ParseMaidenhead(locator string) (Coordinate, error) {
+	if len(locator) == 0 || len(locator)%2 != 0 || len(locator) > 10 {
+		return Coordinate{}, fmt.Errorf("Invalid Maidenhead locator %q", locator)
+	}
+	upper := strings.ToUpper(locator)
+	lon, lat := -180.0, -90.0
+	lonSize, latSize := 360.0, 180.0
+	for i := 0; i < len(upper); i += 2 {
+		divisions, base := 10.0, byte('0')
+		switch {
+		case i == 0:
+			divisions, base = 18, 'A'
+		case i%4 == 0:
+			divisions, base = 24, 'A'
+		}
+		lonSize /= divisions
+		latSize /= divisions
+		lonIdx, latIdx := float64(upper[i])-float64(base), float64(upper[i+1])-float64(base)
+		if lonIdx < 0 || lonIdx >= divisions || latIdx < 0 || latIdx >= divisions {
+			return Coordinate{}, fmt.Errorf("Invalid Maidenhead locator %q", locator)
+		}
+		lon += lonIdx * lonSize
+		lat += latIdx * latSize
+	}
+	c, err := NewCoordinate(lat+latSize/2, lon+lonSize/2)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	corner, _ := NewCoordinate(lat, lon)
+	c.Accuracy = Distance(c, corner)
+	return c, nil
+}
+
+// MGRS column and row letters of the 100 km squares.
+const (
+	mgrsRowLetters = "ABCDEFGHJKLMNPQRSTUV"
+)
+
+// mgrsColumnLetters holds the column letters of the three zone sets.
+var mgrsColumnLetters = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+
+// MGRS returns the Military Grid Reference System reference of the
+// coordinate at 1 m precision, e.g. "17T NE 89138 77812". Like UTM, it is
+// only defined between 80°S and 84°N.
+func (c *Coordinate) MGRS() (string, error) {
+	u, err := c.UTM()
+	if err != nil {
+		return "", err
+	}
+	column := int(math.Floor(u.Easting / 100000))
+	row := int(math.Floor(u.Northing / 100000))
+	if u.Zone%2 == 0 {
+		row += 5
+	}
+	columns := mgrsColumnLetters[(u.Zone-1)%3]
+	if column < 1 || column > len(columns) {
+		return "", errors.New("Easting outside the MGRS range")
+	}
+	easting := int(math.Floor(math.Mod(u.Easting, 100000)))
+	northing := int(math.Floor(math.Mod(u.Northing, 100000)))
+	return fmt.Sprintf("%d%c %c%c %05d %05d", u.Zone, u.Band,
+		columns[column-1], mgrsRowLetters[row%len(mgrsRowLetters)], easting, northing), nil
+}
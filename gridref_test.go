@@ -0,0 +1,66 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGridReferences(t *testing.T) {
+	tests := []struct {
+		lat, lon   float64
+		geohash    string
+		maidenhead string
+		mgrs       string
+	}{
+		{40.446195, -79.948862, "dppnhep00", "FN00ak", "17T NE 89138 77812"},
+		{57.64911, 10.40744, "u4pruydqq", "JO57ep", "32V NJ 84001 90517"},
+		{89, 10, "upz479cy8", "JR59aa", ""},
+	}
+	for _, tt := range tests {
+		c := coordinateFromDecimal(tt.lat, tt.lon)
+		d := Describe(c)
+		if d.Geohash != tt.geohash || d.Maidenhead != tt.maidenhead || d.MGRS != tt.mgrs {
+			t.Errorf("Describe(%v, %v) = %q, %q, %q, want %q, %q, %q", tt.lat, tt.lon,
+				d.Geohash, d.Maidenhead, d.MGRS, tt.geohash, tt.maidenhead, tt.mgrs)
+		}
+		if math.Abs(d.Latitude-tt.lat) > 1e-9 || math.Abs(d.Longitude-tt.lon) > 1e-9 || d.DMS != c.String() {
+			t.Errorf("Describe(%v, %v) = %+v", tt.lat, tt.lon, d)
+		}
+		g, err := ParseGeohash(tt.geohash)
+		if err != nil || Distance(g, c) > g.Accuracy {
+			t.Errorf("ParseGeohash(%q) = %v, %v, want within %v m of %v", tt.geohash, g, err, g.Accuracy, c)
+		}
+		m, err := ParseMaidenhead(tt.maidenhead)
+		if err != nil || Distance(m, c) > m.Accuracy {
+			t.Errorf("ParseMaidenhead(%q) = %v, %v, want within %v m of %v", tt.maidenhead, m, err, m.Accuracy, c)
+		}
+	}
+}
+
+func TestParseGridReferencesMalformed(t *testing.T) {
+	for _, s := range []string{"", "dppn!", "dppna"} {
+		if c, err := ParseGeohash(s); err == nil {
+			t.Errorf("ParseGeohash(%q) = %v, want error", s, c)
+		}
+	}
+	for _, s := range []string{"", "F", "FN0", "SN00", "FNA0", "FN00yy", "FN00ak99aaxx"} {
+		if c, err := ParseMaidenhead(s); err == nil {
+			t.Errorf("ParseMaidenhead(%q) = %v, want error", s, c)
+		}
+	}
+}
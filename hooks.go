@@ -0,0 +1,93 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// Hooks receives data-quality events, e.g. to count how many incoming
+// coordinates required fixing. Implementations must be safe for concurrent
+// use; embed NopHooks to implement only some of the events.
+type Hooks interface {
+	// ParseFallback reports that ParseAny only recognized input as a
+	// grid locator or geohash after failing to read it as latitude and
+	// longitude.
+	ParseFallback(input string, notation Notation)
+	// Correction reports a correction applied to input while parsing, such
+	// as an OCR fix or a misspelled hemisphere word.
+	Correction(input string, c Correction)
+	// PrecisionLoss reports that RoundSeconds changed a value by loss
+	// seconds of arc.
+	PrecisionLoss(d DMS, loss float64)
+}
+
+// NopHooks ignores every event.
+type NopHooks struct{}
+
+func (NopHooks) ParseFallback(input string, notation Notation) {}
+func (NopHooks) Correction(input string, c Correction)         {}
+func (NopHooks) PrecisionLoss(d DMS, loss float64)             {}
+
+// hooksHolder wraps the installed hooks for atomic replacement.
+type hooksHolder struct {
+	Hooks
+}
+
+// installedHooks holds the hooks set by SetHooks.
+var installedHooks atomic.Pointer[hooksHolder]
+
+// SetHooks installs the hooks receiving data-quality events from the
+// package. A nil h restores the default, which ignores them.
+func SetHooks(h Hooks) {
+	if h == nil {
+		installedHooks.Store(nil)
+		return
+	}
+	installedHooks.Store(&hooksHolder{h})
+}
+
+// hooks returns the installed hooks.
+func hooks() Hooks {
+	if h := installedHooks.Load(); h != nil {
+		return h.Hooks
+	}
+	return NopHooks{}
+}
+
+// SlogHooks returns hooks that log every event to logger at debug level.
+func SlogHooks(logger *slog.Logger) Hooks {
+	return slogHooks{logger}
+}
+
+// slogHooks logs events to a structured logger.
+type slogHooks struct {
+	logger *slog.Logger
+}
+
+func (h slogHooks) ParseFallback(input string, notation Notation) {
+	h.logger.Debug("dms: parse fallback", "input", input, "notation", notation.String())
+}
+
+func (h slogHooks) Correction(input string, c Correction) {
+	h.logger.Debug("dms: input corrected", "input", input, "offset", c.Offset,
+		"original", c.Original, "replacement", c.Replacement)
+}
+
+func (h slogHooks) PrecisionLoss(d DMS, loss float64) {
+	h.logger.Debug("dms: precision loss", "value", d, "seconds", loss)
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "math"
+
+// Horizon and line of sight
+//
+// Atmospheric refraction bends rays toward the ground, which is modeled by
+// an Earth radius scaled by a k-factor.
+
+// Earth radius k-factors for horizon computations.
+const (
+	GeometricHorizon = 1.0     // No refraction, the geometric horizon.
+	VisualHorizon    = 7.0 / 6 // Standard refraction of visible light.
+	RadioHorizon     = 4.0 / 3 // Standard refraction of VHF and UHF radio waves.
+)
+
+// HorizonDistance returns the great-circle distance in meters to the horizon
+// seen from height meters above the surface, for the k-factor k, e.g. about
+// 5 km at 1.7 m with VisualHorizon.
+func HorizonDistance(height, k float64) float64 {
+	if height <= 0 {
+		return 0
+	}
+	r := k * earthRadius
+	return r * math.Acos(r/(r+height))
+}
+
+// HiddenHeight returns the height in meters of the part of a target at the
+// given distance in meters that is hidden below the horizon of an observer at
+// observerHeight meters, for the k-factor k. It is zero for targets before
+// the horizon.
+func HiddenHeight(observerHeight, distance, k float64) float64 {
+	beyond := distance - HorizonDistance(observerHeight, k)
+	if beyond <= 0 {
+		return 0
+	}
+	r := k * earthRadius
+	return r/math.Cos(beyond/r) - r
+}
+
+// BeyondHorizon reports whether a target at targetHeight meters above target
+// is hidden by the curvature of the Earth from an observer at observerHeight
+// meters above observer, for the k-factor k. Terrain is not taken into account.
+func BeyondHorizon(observer Coordinate, observerHeight float64, target Coordinate, targetHeight, k float64) bool {
+	return Distance(observer, target) > HorizonDistance(observerHeight, k)+HorizonDistance(targetHeight, k)
+}
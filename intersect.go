@@ -0,0 +1,143 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"math"
+)
+
+// Intersection and trilateration
+
+// Errors returned by Intersection and Trilaterate.
+var (
+	ErrNoIntersection     = errors.New("Bearings do not intersect at a unique point")
+	ErrTooFewRanges       = errors.New("Trilateration requires at least three points and distances")
+	ErrDegenerateGeometry = errors.New("Points lie on one great circle, position is ambiguous")
+)
+
+// Intersection returns the point where the great circle leaving a with
+// bearing1 crosses the great circle leaving b with bearing2, bearings in
+// degrees from true north. It returns ErrNoIntersection when the points
+// coincide, the bearings run along the same great circle or diverge.
+func Intersection(a Coordinate, bearing1 float64, b Coordinate, bearing2 float64) (Coordinate, error) {
+	lat1, lon1 := a.radians()
+	lat2, lon2 := b.radians()
+	delta12 := haversine(lat1, lon1, lat2, lon2) / earthRadius
+	if delta12 == 0 {
+		return Coordinate{}, ErrNoIntersection
+	}
+
+	thetaA := math.Acos(clamp1((math.Sin(lat2) - math.Sin(lat1)*math.Cos(delta12)) / (math.Sin(delta12) * math.Cos(lat1))))
+	thetaB := math.Acos(clamp1((math.Sin(lat1) - math.Sin(lat2)*math.Cos(delta12)) / (math.Sin(delta12) * math.Cos(lat2))))
+	theta12, theta21 := 2*math.Pi-thetaA, thetaB
+	if math.Sin(lon2-lon1) > 0 {
+		theta12, theta21 = thetaA, 2*math.Pi-thetaB
+	}
+
+	alpha1 := bearing1*degToRad - theta12
+	alpha2 := theta21 - bearing2*degToRad
+	if math.Sin(alpha1) == 0 && math.Sin(alpha2) == 0 || math.Sin(alpha1)*math.Sin(alpha2) < 0 {
+		return Coordinate{}, ErrNoIntersection
+	}
+	cosAlpha3 := -math.Cos(alpha1)*math.Cos(alpha2) + math.Sin(alpha1)*math.Sin(alpha2)*math.Cos(delta12)
+	delta13 := math.Atan2(math.Sin(delta12)*math.Sin(alpha1)*math.Sin(alpha2), math.Cos(alpha2)+math.Cos(alpha1)*cosAlpha3)
+	return Destination(a, bearing1, delta13*earthRadius), nil
+}
+
+// Trilaterate returns the position at the given great-circle distances in
+// meters from three or more known points. With more than three points, or
+// with inconsistent distances, it returns the least-squares estimate. It
+// returns ErrDegenerateGeometry when the points lie on one great circle.
+func Trilaterate(points []Coordinate, distances []float64) (Coordinate, error) {
+	if len(points) < 3 || len(points) != len(distances) {
+		return Coordinate{}, ErrTooFewRanges
+	}
+	// Every point p at angular distance δ from the unknown unit vector n
+	// satisfies n·p = cos δ; solve the normal equations of these linear
+	// equations for n.
+	var ata [3][3]float64
+	var atb [3]float64
+	for i := range points {
+		p := unitVector(points[i])
+		cos := math.Cos(distances[i] / earthRadius)
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				ata[r][c] += p[r] * p[c]
+			}
+			atb[r] += p[r] * cos
+		}
+	}
+	n, ok := solve3(ata, atb)
+	if !ok {
+		return Coordinate{}, ErrDegenerateGeometry
+	}
+	return coordinateFromVector(n), nil
+}
+
+// vec3 is a vector in the Earth-centered frame.
+type vec3 [3]float64
+
+// unitVector returns the unit vector pointing from the Earth's center to c on
+// a spherical Earth.
+func unitVector(c Coordinate) vec3 {
+	lat, lon := c.radians()
+	return vec3{math.Cos(lat) * math.Cos(lon), math.Cos(lat) * math.Sin(lon), math.Sin(lat)}
+}
+
+// coordinateFromVector returns the coordinate in the direction of v.
+func coordinateFromVector(v vec3) Coordinate {
+	lat := math.Atan2(v[2], math.Hypot(v[0], v[1]))
+	lon := math.Atan2(v[1], v[0])
+	return coordinateFromDecimal(lat/degToRad, lon/degToRad)
+}
+
+// solve3 solves the 3×3 linear system m x = b by Cramer's rule, reporting
+// false when m is singular.
+func solve3(m [3][3]float64, b [3]float64) (vec3, bool) {
+	det := det3(m)
+	scale := 0.0
+	for _, row := range m {
+		for _, v := range row {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	if math.Abs(det) <= 1e-12*scale*scale*scale {
+		return vec3{}, false
+	}
+	var x vec3
+	for i := range x {
+		mi := m
+		for r := range mi {
+			mi[r][i] = b[r]
+		}
+		x[i] = det3(mi) / det
+	}
+	return x, true
+}
+
+// det3 returns the determinant of a 3×3 matrix.
+func det3(m [3][3]float64) float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// clamp1 clamps x into [-1, 1], guarding inverse trigonometric functions
+// against rounding errors.
+func clamp1(x float64) float64 {
+	return math.Max(-1, math.Min(1, x))
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// KML files
+
+// kmlNamespace is the XML namespace of KML 2.2.
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+// KML holds the contents of a KML document.
+type KML struct {
+	Name      string
+	Waypoints []Waypoint // Point placemarks.
+	Routes    []Route    // Line string placemarks, whose vertices carry no metadata.
+}
+
+// kmlFile is the XML layout of a KML file.
+type kmlFile struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr,omitempty"`
+	Document kmlDocument `xml:"Document"`
+}
+
+// kmlDocument is the XML layout of a KML document.
+type kmlDocument struct {
+	Name       string         `xml:"name,omitempty"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+// kmlPlacemark is the XML layout of a KML placemark.
+type kmlPlacemark struct {
+	Name        string        `xml:"name,omitempty"`
+	Description string        `xml:"description,omitempty"`
+	TimeStamp   *kmlTimeStamp `xml:"TimeStamp"`
+	StyleURL    string        `xml:"styleUrl,omitempty"`
+	Point       *kmlPoint     `xml:"Point"`
+	LineString  *kmlPoint     `xml:"LineString"`
+}
+
+// kmlTimeStamp is the XML layout of a KML time stamp.
+type kmlTimeStamp struct {
+	When string `xml:"when"`
+}
+
+// kmlPoint is the XML layout of a KML point or line string.
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// newKMLPlacemark returns the point placemark of a waypoint. The symbol is
+// written as a reference to a shared style.
+func newKMLPlacemark(w *Waypoint) kmlPlacemark {
+	lat, lon := w.Coordinate.Decimal()
+	p := kmlPlacemark{
+		Name:        w.Name,
+		Description: w.Description,
+		Point:       &kmlPoint{Coordinates: formatDecimal(lon, geoURIPrecision) + "," + formatDecimal(lat, geoURIPrecision)},
+	}
+	if w.Symbol != "" {
+		p.StyleURL = "#" + w.Symbol
+	}
+	if !w.Time.IsZero() {
+		p.TimeStamp = &kmlTimeStamp{When: formatWaypointTime(w.Time)}
+	}
+	return p
+}
+
+// newKMLLine returns the line string placemark of a route.
+func newKMLLine(r *Route) kmlPlacemark {
+	tuples := make([]string, len(r.Waypoints))
+	for i := range r.Waypoints {
+		lat, lon := r.Waypoints[i].Coordinate.Decimal()
+		tuples[i] = formatDecimal(lon, geoURIPrecision) + "," + formatDecimal(lat, geoURIPrecision)
+	}
+	return kmlPlacemark{Name: r.Name, LineString: &kmlPoint{Coordinates: strings.Join(tuples, " ")}}
+}
+
+// parseKMLCoordinates parses the whitespace-separated lon,lat[,alt] tuples of
+// a KML coordinates element.
+func parseKMLCoordinates(s string) ([]Waypoint, error) {
+	var waypoints []Waypoint
+	for _, tuple := range strings.Fields(s) {
+		fields := strings.Split(tuple, ",")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("Invalid KML coordinates %q", tuple)
+		}
+		w, err := newWaypointAt(fields[1], fields[0])
+		if err != nil {
+			return nil, err
+		}
+		waypoints = append(waypoints, w)
+	}
+	return waypoints, nil
+}
+
+// waypoint returns the waypoint of a point placemark.
+func (p *kmlPlacemark) waypoint() (Waypoint, error) {
+	points, err := parseKMLCoordinates(p.Point.Coordinates)
+	if err != nil {
+		return Waypoint{}, err
+	}
+	if len(points) != 1 {
+		return Waypoint{}, fmt.Errorf("Invalid KML point %q", p.Point.Coordinates)
+	}
+	w := points[0]
+	w.Name, w.Description = p.Name, p.Description
+	w.Symbol = strings.TrimPrefix(p.StyleURL, "#")
+	if p.TimeStamp != nil {
+		w.Time, err = parseWaypointTime(p.TimeStamp.When)
+	}
+	return w, err
+}
+
+// ReadKML reads the point and line string placemarks of a KML document.
+// Placemarks with other geometries are skipped.
+func ReadKML(r io.Reader) (*KML, error) {
+	var f kmlFile
+	if err := xml.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	k := &KML{Name: f.Document.Name}
+	for i := range f.Document.Placemarks {
+		p := &f.Document.Placemarks[i]
+		switch {
+		case p.Point != nil:
+			w, err := p.waypoint()
+			if err != nil {
+				return nil, err
+			}
+			k.Waypoints = append(k.Waypoints, w)
+		case p.LineString != nil:
+			points, err := parseKMLCoordinates(p.LineString.Coordinates)
+			if err != nil {
+				return nil, err
+			}
+			k.Routes = append(k.Routes, Route{Name: p.Name, Waypoints: points})
+		}
+	}
+	return k, nil
+}
+
+// Write writes the KML document as KML 2.2.
+func (k *KML) Write(w io.Writer) error {
+	f := kmlFile{Xmlns: kmlNamespace, Document: kmlDocument{Name: k.Name}}
+	for i := range k.Waypoints {
+		f.Document.Placemarks = append(f.Document.Placemarks, newKMLPlacemark(&k.Waypoints[i]))
+	}
+	for i := range k.Routes {
+		f.Document.Placemarks = append(f.Document.Placemarks, newKMLLine(&k.Routes[i]))
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(f); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// LegTableOptions controls the columns of a leg table.
+type LegTableOptions struct {
+	Unit      DistanceUnit // Unit of the distance columns.
+	Speed     Speed        // Planned speed; the ETE column is omitted when zero.
+	Magnetic  bool         // Add a magnetic course column, corrected for Variation.
+	Variation float64      // Magnetic variation in degrees, east positive.
+	CourseDMS bool         // Write courses as degrees, minutes and seconds instead of decimal degrees.
+}
+
+// RenderLegTable writes the navigation leg table of a route in the given
+// format: for each leg, the names of its waypoints, its true course (and
+// magnetic course), its distance, the cumulative distance and, when a speed
+// is given, the estimated time en route. Unnamed waypoints are labeled by
+// their coordinates.
+func RenderLegTable(w io.Writer, route *Route, format TableFormat, opts LegTableOptions) error {
+	header := []string{"From", "To", "True course"}
+	if opts.Magnetic {
+		header = append(header, "Magnetic course")
+	}
+	header = append(header, "Distance ("+opts.Unit.String()+")", "Cumulative ("+opts.Unit.String()+")")
+	if opts.Speed > 0 {
+		header = append(header, "ETE")
+	}
+	rows := [][]string{header}
+	cumulative := 0.0
+	for _, leg := range route.Legs() {
+		cumulative += leg.Distance
+		row := []string{waypointLabel(&leg.From), waypointLabel(&leg.To), opts.formatCourse(leg.Bearing)}
+		if opts.Magnetic {
+			row = append(row, opts.formatCourse(leg.Bearing-opts.Variation))
+		}
+		row = append(row,
+			strconv.FormatFloat(opts.Unit.FromMeters(leg.Distance), 'f', 1, 64),
+			strconv.FormatFloat(opts.Unit.FromMeters(cumulative), 'f', 1, 64))
+		if opts.Speed > 0 {
+			ete := time.Duration(leg.Distance / opts.Speed.MetersPerSecond() * float64(time.Second))
+			row = append(row, formatETE(ete))
+		}
+		rows = append(rows, row)
+	}
+	return writeTable(w, rows, format)
+}
+
+// formatCourse returns a course in degrees from north, wrapped to [0, 360).
+func (opts *LegTableOptions) formatCourse(course float64) string {
+	course = Normalize360(course)
+	if opts.CourseDMS {
+		a := NewAngle(course, Wrap360)
+		return a.String()
+	}
+	// Courses are customarily written with three integer digits.
+	return fmt.Sprintf("%05.1f°", math.Mod(math.Round(course*10)/10, 360))
+}
+
+// formatETE returns a time en route as hours and minutes, e.g. "1:05".
+func formatETE(d time.Duration) string {
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// waypointLabel returns the name of a waypoint, or its coordinates when it
+// has none.
+func waypointLabel(w *Waypoint) string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.Coordinate.String()
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MapProvider identifies a web map service.
+type MapProvider int
+
+const (
+	GoogleMaps    MapProvider = iota // Google Maps (google.com/maps).
+	AppleMaps                        // Apple Maps (maps.apple.com).
+	OpenStreetMap                    // OpenStreetMap (openstreetmap.org).
+	BingMaps                         // Bing Maps (bing.com/maps).
+)
+
+// mapProviderNames maps map providers to their names.
+var mapProviderNames = map[MapProvider]string{
+	GoogleMaps:    "google",
+	AppleMaps:     "apple",
+	OpenStreetMap: "osm",
+	BingMaps:      "bing",
+}
+
+// String returns the name of the provider, e.g. "osm".
+func (p MapProvider) String() string {
+	if name, ok := mapProviderNames[p]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseMapProvider returns the provider with the given name, as returned by
+// MapProvider.String.
+func ParseMapProvider(name string) (MapProvider, error) {
+	for p, s := range mapProviderNames {
+		if strings.EqualFold(s, name) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown map provider %q", name)
+}
+
+// defaultMapZoom is the zoom level used when MapsURL is given zero.
+const defaultMapZoom = 15
+
+// MapsURL returns a link showing the coordinate on the given provider's map.
+// A zero zoom selects a street-level default.
+func MapsURL(c Coordinate, provider MapProvider, zoom int) string {
+	if zoom <= 0 {
+		zoom = defaultMapZoom
+	}
+	lat, lon := c.Decimal()
+	ll := formatDecimal(lat, geoURIPrecision) + "," + formatDecimal(lon, geoURIPrecision)
+	switch provider {
+	case AppleMaps:
+		return fmt.Sprintf("https://maps.apple.com/?ll=%s&q=%s&z=%d", ll, ll, zoom)
+	case OpenStreetMap:
+		mlat, mlon := formatDecimal(lat, geoURIPrecision), formatDecimal(lon, geoURIPrecision)
+		return fmt.Sprintf("https://www.openstreetmap.org/?mlat=%s&mlon=%s#map=%d/%s/%s", mlat, mlon, zoom, mlat, mlon)
+	case BingMaps:
+		blat, blon := formatDecimal(lat, geoURIPrecision), formatDecimal(lon, geoURIPrecision)
+		return fmt.Sprintf("https://www.bing.com/maps?cp=%s~%s&lvl=%d&sp=point.%s_%s_", blat, blon, zoom, blat, blon)
+	default:
+		return fmt.Sprintf("https://www.google.com/maps/search/?api=1&query=%s&zoom=%d", ll, zoom)
+	}
+}
+
+// MapsURLFor parses a coordinate in any notation accepted by ParseAny and
+// returns a link showing it on the given provider's map.
+func MapsURLFor(s string, provider MapProvider, zoom int) (string, error) {
+	c, _, err := ParseAny(s)
+	if err != nil {
+		return "", err
+	}
+	return MapsURL(c, provider, zoom), nil
+}
+
+// ParseMapsURL extracts the coordinate from a Google Maps, Apple Maps,
+// OpenStreetMap or Bing Maps link, or from a geo URI.
+func ParseMapsURL(s string) (Coordinate, error) {
+	if strings.HasPrefix(strings.ToLower(s), "geo:") {
+		g, err := ParseGeoURI(s)
+		return g.Coordinate, err
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	query := u.Query()
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case strings.Contains(host, "openstreetmap.org") || host == "osm.org":
+		if query.Get("mlat") != "" {
+			return parseLatLonPair(query.Get("mlat"), query.Get("mlon"))
+		}
+		// Fragment of the form "map=zoom/lat/lon".
+		if fragment, ok := strings.CutPrefix(u.Fragment, "map="); ok {
+			parts := strings.Split(fragment, "/")
+			if len(parts) == 3 {
+				return parseLatLonPair(parts[1], parts[2])
+			}
+		}
+	case host == "maps.apple.com":
+		for _, key := range []string{"ll", "coordinate", "q", "sll"} {
+			if c, err := parseLatLonList(query.Get(key)); err == nil {
+				return c, nil
+			}
+		}
+	case strings.HasSuffix(host, "bing.com"):
+		if lat, lon, ok := strings.Cut(query.Get("cp"), "~"); ok {
+			return parseLatLonPair(lat, lon)
+		}
+		// Pushpin of the form "point.lat_lon_title".
+		if point, ok := strings.CutPrefix(query.Get("sp"), "point."); ok {
+			parts := strings.Split(point, "_")
+			if len(parts) >= 2 {
+				return parseLatLonPair(parts[0], parts[1])
+			}
+		}
+	case strings.Contains(host, "google.") || host == "maps.app.goo.gl":
+		for _, key := range []string{"query", "q", "ll", "center", "destination"} {
+			if c, err := parseLatLonList(query.Get(key)); err == nil {
+				return c, nil
+			}
+		}
+		// Path segment of the form "@lat,lon,zoomz".
+		if i := strings.Index(u.Path, "/@"); i >= 0 {
+			parts := strings.Split(u.Path[i+2:], ",")
+			if len(parts) >= 2 {
+				return parseLatLonPair(parts[0], parts[1])
+			}
+		}
+	}
+	return Coordinate{}, fmt.Errorf("No coordinate found in map URL %q", s)
+}
+
+// parseLatLonList parses a "lat,lon" string.
+func parseLatLonList(s string) (Coordinate, error) {
+	lat, lon, ok := strings.Cut(s, ",")
+	if !ok {
+		return Coordinate{}, fmt.Errorf("Invalid latitude/longitude %q", s)
+	}
+	return parseLatLonPair(lat, lon)
+}
+
+// parseLatLonPair parses separate signed decimal latitude and longitude strings.
+func parseLatLonPair(lat, lon string) (Coordinate, error) {
+	latValue, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return Coordinate{}, fmt.Errorf("Invalid latitude %q", lat)
+	}
+	lonValue, err := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if err != nil {
+		return Coordinate{}, fmt.Errorf("Invalid longitude %q", lon)
+	}
+	return NewCoordinate(latValue, lonValue)
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+// mapProviders holds the providers whose links MapsURL writes.
+var mapProviders = []MapProvider{GoogleMaps, AppleMaps, OpenStreetMap}
+
+func TestMapsURLRoundTrip(t *testing.T) {
+	for _, c := range []Coordinate{
+		coordinateFromDecimal(40.446195, -79.948862),
+		coordinateFromDecimal(-33.8568, 151.2153),
+		coordinateFromDecimal(0, 0),
+	} {
+		for _, provider := range mapProviders {
+			link := MapsURL(c, provider, 0)
+			got, err := ParseMapsURL(link)
+			if err != nil {
+				t.Errorf("ParseMapsURL(%q): %v", link, err)
+				continue
+			}
+			if d := Distance(got, c); d > 0.01 {
+				t.Errorf("ParseMapsURL(%q) is %g m from %v", link, d, c)
+			}
+		}
+	}
+}
+
+func TestParseMapsURL(t *testing.T) {
+	tests := []struct {
+		link     string
+		lat, lon float64
+	}{
+		{"https://www.google.com/maps?q=40.446195,-79.948862", 40.446195, -79.948862},
+		{"https://www.google.com/maps/@40.446195,-79.948862,15z", 40.446195, -79.948862},
+		{"https://www.google.co.uk/maps/dir/?api=1&destination=51.5007,-0.1246", 51.5007, -0.1246},
+		{"https://maps.apple.com/?sll=48.8584,2.2945", 48.8584, 2.2945},
+		{"https://www.openstreetmap.org/#map=17/-33.8568/151.2153", -33.8568, 151.2153},
+		{"https://osm.org/?mlat=1.5&mlon=2.5", 1.5, 2.5},
+		{"GEO:40.5,-79.9;u=10", 40.5, -79.9},
+	}
+	for _, tt := range tests {
+		c, err := ParseMapsURL(tt.link)
+		if err != nil {
+			t.Errorf("ParseMapsURL(%q): %v", tt.link, err)
+			continue
+		}
+		if lat, lon := c.Decimal(); math.Abs(lat-tt.lat) > 1e-9 || math.Abs(lon-tt.lon) > 1e-9 {
+			t.Errorf("ParseMapsURL(%q) = %v, %v", tt.link, lat, lon)
+		}
+	}
+}
+
+func TestParseMapsURLMalformed(t *testing.T) {
+	for _, link := range []string{
+		"",
+		"https://example.com/?q=40.5,-79.9",
+		"https://www.google.com/maps?q=Pittsburgh",
+		"https://www.google.com/maps?q=95,10",
+		"https://www.google.com/maps?q=NaN,10",
+		"https://www.google.com/maps?q=40.5,Inf",
+		"https://maps.apple.com/?ll=40.5",
+		"https://www.openstreetmap.org/#map=17/x/151.2153",
+		"https://www.openstreetmap.org/?mlat=40.5",
+		"geo:40.5",
+		"http://[::1",
+	} {
+		if c, err := ParseMapsURL(link); err == nil {
+			t.Errorf("ParseMapsURL(%q) = %v, want error", link, c)
+		}
+	}
+}
+
+func TestBingMapsURL(t *testing.T) {
+	c := coordinateFromDecimal(47.6205, -122.3493)
+	link := MapsURL(c, BingMaps, 12)
+	if want := "https://www.bing.com/maps?cp=47.6205~-122.3493&lvl=12&sp=point.47.6205_-122.3493_"; link != want {
+		t.Errorf("MapsURL = %q, want %q", link, want)
+	}
+	for _, link := range []string{link, "https://bing.com/maps?sp=point.47.6205_-122.3493_Space%20Needle"} {
+		got, err := ParseMapsURL(link)
+		if err != nil || Distance(got, c) > 0.01 {
+			t.Errorf("ParseMapsURL(%q) = %v, %v", link, got, err)
+		}
+	}
+	for _, link := range []string{"https://www.bing.com/maps?cp=47.6205", "https://www.bing.com/maps?sp=point.47.6205"} {
+		if got, err := ParseMapsURL(link); err == nil {
+			t.Errorf("ParseMapsURL(%q) = %v, want error", link, got)
+		}
+	}
+}
+
+func TestMapsURLFor(t *testing.T) {
+	for _, provider := range []MapProvider{GoogleMaps, AppleMaps, OpenStreetMap, BingMaps} {
+		name := provider.String()
+		if p, err := ParseMapProvider(name); err != nil || p != provider {
+			t.Errorf("ParseMapProvider(%q) = %v, %v", name, p, err)
+		}
+		link, err := MapsURLFor(`40°26'46.3" N 79°56'55.9" W`, provider, 0)
+		if err != nil {
+			t.Fatalf("MapsURLFor %s: %v", name, err)
+		}
+		if c, err := ParseMapsURL(link); err != nil || math.Abs(DMSToDecimal(c.Latitude)-40.446194) > 1e-6 {
+			t.Errorf("MapsURLFor %s = %q, parsed as %v, %v", name, link, c, err)
+		}
+	}
+	if _, err := MapsURLFor("nowhere", GoogleMaps, 0); err == nil {
+		t.Error("MapsURLFor accepted an invalid coordinate")
+	}
+	if _, err := ParseMapProvider("yahoo"); err == nil {
+		t.Error("ParseMapProvider accepted an unknown provider")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DistanceMatrix returns the N×N matrix of great-circle distances in meters
+// between all points. The rows are computed in parallel across all CPUs.
+func DistanceMatrix(points []Coordinate) [][]float64 {
+	n := len(points)
+	cells := make([]float64, n*n)
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = cells[i*n : (i+1)*n]
+	}
+	DistanceMatrixFunc(points, func(i, j int, distance float64) {
+		matrix[i][j] = distance
+		matrix[j][i] = distance
+	})
+	return matrix
+}
+
+// DistanceMatrixFunc computes the great-circle distance in meters between
+// every pair of points i < j and passes it to fn, without holding the matrix
+// in memory. Rows are computed in parallel, so fn is called concurrently from
+// several goroutines, but never concurrently for the same row i.
+func DistanceMatrixFunc(points []Coordinate, fn func(i, j int, distance float64)) {
+	n := len(points)
+	lats, lons := make([]float64, n), make([]float64, n)
+	for i := range points {
+		lats[i], lons[i] = points[i].radians()
+	}
+
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.NumCPU(), n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				for j := i + 1; j < n; j++ {
+					fn(i, j, haversine(lats[i], lons[i], lats[j], lons[j]))
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		rows <- i
+	}
+	close(rows)
+	wg.Wait()
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"expvar"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Parse failure reasons reported to Metrics.
+const (
+	FailureSyntax    = "syntax"    // Unexpected characters or malformed numbers.
+	FailureDirection = "direction" // Missing, repeated or unknown direction.
+	FailureRange     = "range"     // Values outside their valid range.
+)
+
+// Metrics receives instrumentation of the package, to be bound to a
+// monitoring system such as Prometheus. Implementations must be safe for
+// concurrent use.
+type Metrics interface {
+	// ObserveConversion records one conversion, such as "parse",
+	// "describe" or "pipeline", and its latency.
+	ObserveConversion(operation string, latency time.Duration)
+	// CountParseFailure records a failed parse, with one of the Failure
+	// reasons.
+	CountParseFailure(reason string)
+}
+
+// NopMetrics discards all instrumentation.
+type NopMetrics struct{}
+
+func (NopMetrics) ObserveConversion(operation string, latency time.Duration) {}
+func (NopMetrics) CountParseFailure(reason string)                           {}
+
+// metricsHolder wraps the installed metrics for atomic replacement.
+type metricsHolder struct {
+	Metrics
+}
+
+// installedMetrics holds the metrics set by SetMetrics.
+var installedMetrics atomic.Pointer[metricsHolder]
+
+// SetMetrics installs the metrics receiving the instrumentation of the
+// package. A nil m restores the default, which discards it.
+func SetMetrics(m Metrics) {
+	if m == nil {
+		installedMetrics.Store(nil)
+		return
+	}
+	installedMetrics.Store(&metricsHolder{m})
+}
+
+// observe records a conversion started at start, and the reason of the parse
+// failure when err is a parse error.
+func observe(operation string, start time.Time, parseErr error) {
+	h := installedMetrics.Load()
+	if h == nil {
+		return
+	}
+	h.ObserveConversion(operation, time.Since(start))
+	if parseErr != nil {
+		h.CountParseFailure(failureReason(parseErr))
+	}
+}
+
+// failureReason classifies a parse error.
+func failureReason(err error) string {
+	var ve *ValidationError
+	switch {
+	case errors.As(err, &ve) && ve.Rule == RuleDirection:
+		return FailureDirection
+	case errors.As(err, &ve):
+		return FailureRange
+	case strings.Contains(strings.ToLower(err.Error()), "direction"):
+		return FailureDirection
+	}
+	return FailureSyntax
+}
+
+// ExpvarMetrics is a Metrics implementation publishing its counters with the
+// expvar package, under /debug/vars of the default HTTP server.
+type ExpvarMetrics struct {
+	Conversions   *expvar.Map // Conversions by operation.
+	LatencySecond *expvar.Map // Total conversion latency in seconds by operation.
+	ParseFailures *expvar.Map // Parse failures by reason.
+}
+
+// NewExpvarMetrics publishes the expvar maps prefix_conversions,
+// prefix_latency_seconds and prefix_parse_failures. Like expvar.Publish, it
+// panics if the names are already in use.
+func NewExpvarMetrics(prefix string) *ExpvarMetrics {
+	return &ExpvarMetrics{
+		Conversions:   expvar.NewMap(prefix + "_conversions"),
+		LatencySecond: expvar.NewMap(prefix + "_latency_seconds"),
+		ParseFailures: expvar.NewMap(prefix + "_parse_failures"),
+	}
+}
+
+// ObserveConversion implements Metrics.
+func (m *ExpvarMetrics) ObserveConversion(operation string, latency time.Duration) {
+	m.Conversions.Add(operation, 1)
+	m.LatencySecond.AddFloat(operation, latency.Seconds())
+}
+
+// CountParseFailure implements Metrics.
+func (m *ExpvarMetrics) CountParseFailure(reason string) {
+	m.ParseFailures.Add(reason, 1)
+}
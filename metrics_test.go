@@ -0,0 +1,93 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics counts the instrumentation it receives.
+type recordingMetrics struct {
+	mu          sync.Mutex
+	conversions map[string]int
+	failures    map[string]int
+}
+
+func newRecordingMetrics(t *testing.T) *recordingMetrics {
+	m := &recordingMetrics{conversions: map[string]int{}, failures: map[string]int{}}
+	SetMetrics(m)
+	t.Cleanup(func() { SetMetrics(nil) })
+	return m
+}
+
+func (m *recordingMetrics) ObserveConversion(operation string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.conversions[operation]++
+}
+
+func (m *recordingMetrics) CountParseFailure(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[reason]++
+}
+
+func (m *recordingMetrics) totalFailures() int {
+	total := 0
+	for _, n := range m.failures {
+		total += n
+	}
+	return total
+}
+
+func TestPipelineMetrics(t *testing.T) {
+	m := newRecordingMetrics(t)
+	p := NewPipeline(ParseStage(), FormatStage(DefaultFormatOptions()))
+	if _, err := p.Run("40.5 X 79.9 Y"); err == nil {
+		t.Fatal("Run: want error")
+	}
+	if m.conversions["parse"] != 1 || m.conversions["pipeline"] != 1 || m.totalFailures() != 1 {
+		t.Errorf("bad input: conversions %v, failures %v", m.conversions, m.failures)
+	}
+
+	m = newRecordingMetrics(t)
+	if _, _, err := p.RunAudited("40.5 X 79.9 Y"); err == nil {
+		t.Fatal("RunAudited: want error")
+	}
+	if m.conversions["parse"] != 1 || m.conversions["pipeline"] != 1 || m.totalFailures() != 1 {
+		t.Errorf("audited bad input: conversions %v, failures %v", m.conversions, m.failures)
+	}
+
+	m = newRecordingMetrics(t)
+	diskFull := StageFunc(func(*PipelineState) error { return errors.New("Disk full") })
+	if _, err := p.Then(diskFull).Run("40.5 N 79.9 W"); err == nil {
+		t.Fatal("Run: want error")
+	}
+	if m.totalFailures() != 0 {
+		t.Errorf("stage error counted as parse failure: %v", m.failures)
+	}
+
+	m = newRecordingMetrics(t)
+	if _, _, err := NewPipeline(FormatStage(DefaultFormatOptions())).RunCoordinate(coordinateFromDecimal(40.5, -79.9)); err != nil {
+		t.Fatal(err)
+	}
+	if m.conversions["pipeline"] != 1 {
+		t.Errorf("RunCoordinate: conversions %v", m.conversions)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Lunar position
+//
+// The position of the Moon follows the low-precision series of the
+// Astronomical Almanac, accurate to about 0.3°.
+
+// sunDistance is the mean distance from the Earth to the Sun in meters.
+const sunDistance = 1.495978707e11
+
+// moonEquatorial returns the geocentric right ascension and declination of
+// the Moon in degrees, and its distance in meters.
+func moonEquatorial(t time.Time) (ra, dec, distance float64) {
+	_, c := julianCenturies(t)
+	sin := func(a, b float64) float64 { return math.Sin((a + b*c) * degToRad) }
+	cos := func(a, b float64) float64 { return math.Cos((a + b*c) * degToRad) }
+	lambda := 218.32 + 481267.881*c +
+		6.29*sin(135.0, 477198.87) - 1.27*sin(259.3, -413335.36) +
+		0.66*sin(235.7, 890534.22) + 0.21*sin(269.9, 954397.74) -
+		0.19*sin(357.5, 35999.05) - 0.11*sin(186.5, 966404.03)
+	beta := 5.13*sin(93.3, 483202.02) + 0.28*sin(228.2, 960400.89) -
+		0.28*sin(318.3, 6003.15) - 0.17*sin(217.6, -407332.21)
+	parallax := 0.9508 + 0.0518*cos(135.0, 477198.87) + 0.0095*cos(259.3, -413335.36) +
+		0.0078*cos(235.7, 890534.22) + 0.0028*cos(269.9, 954397.74)
+	epsilon, _ := obliquity(c)
+	ra, dec = equatorialFromEcliptic(Normalize360(lambda), beta, epsilon)
+	return ra, dec, EllipsoidWGS84.A / math.Sin(parallax*degToRad)
+}
+
+// SublunarPoint returns the point of the Earth where the Moon is at the
+// zenith at t.
+func SublunarPoint(t time.Time) Coordinate {
+	ra, dec, _ := moonEquatorial(t)
+	return subPoint(t, ra, dec)
+}
+
+// MoonPosition returns the direction of the center of the Moon seen from c at
+// t, corrected for parallax and without atmospheric refraction.
+func MoonPosition(c Coordinate, t time.Time) Horizontal {
+	ra, dec, distance := moonEquatorial(t)
+	h := horizontalAt(c, t, ra, dec)
+	elevation := h.Elevation.Decimal() * degToRad
+	parallax := math.Asin(EllipsoidWGS84.A / distance * math.Cos(elevation))
+	h.Elevation = NewAngle((elevation-parallax)/degToRad, WrapNone)
+	return h
+}
+
+// LunarPhase describes the illumination of the Moon.
+type LunarPhase struct {
+	PhaseAngle  Angle   // Sun-Moon-Earth angle: 180° at new moon, 0° at full moon.
+	Illuminated float64 // Illuminated fraction of the disk, from 0 to 1.
+	Waxing      bool    // Whether the illuminated fraction is increasing.
+}
+
+// String returns the phase angle, illuminated percentage and trend.
+func (p LunarPhase) String() string {
+	trend := "waning"
+	if p.Waxing {
+		trend = "waxing"
+	}
+	return fmt.Sprintf("%s %.0f%% %s", p.PhaseAngle.String(), p.Illuminated*100, trend)
+}
+
+// MoonPhase returns the phase of the Moon at t.
+func MoonPhase(t time.Time) LunarPhase {
+	sunRA, sunDec, _ := sunEquatorial(t)
+	moonRA, moonDec, distance := moonEquatorial(t)
+	sd, md := sunDec*degToRad, moonDec*degToRad
+	elongation := math.Acos(clamp1(math.Sin(sd)*math.Sin(md) +
+		math.Cos(sd)*math.Cos(md)*math.Cos((sunRA-moonRA)*degToRad)))
+	phase := math.Atan2(sunDistance*math.Sin(elongation), distance-sunDistance*math.Cos(elongation))
+	return LunarPhase{
+		PhaseAngle:  NewAngle(phase/degToRad, WrapNone),
+		Illuminated: (1 + math.Cos(phase)) / 2,
+		Waxing:      Normalize180(moonRA-sunRA) > 0,
+	}
+}
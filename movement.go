@@ -0,0 +1,51 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "time"
+
+// Fix is a coordinate observed at a point in time, such as a GPS position report.
+type Fix struct {
+	Coordinate Coordinate // Reported position.
+	Time       time.Time  // Time of the report.
+}
+
+// Movement describes the displacement between two fixes.
+type Movement struct {
+	Distance float64       // Great-circle distance in meters.
+	Bearing  float64       // Initial bearing in degrees from true north.
+	Elapsed  time.Duration // Time between the fixes.
+	Speed    Speed         // Average speed, zero when no time elapsed.
+}
+
+// Displacement returns the distance, bearing, elapsed time and average speed
+// from one fix to the next.
+func Displacement(from, to Fix) Movement {
+	m := Movement{
+		Distance: Distance(from.Coordinate, to.Coordinate),
+		Bearing:  Bearing(from.Coordinate, to.Coordinate),
+		Elapsed:  to.Time.Sub(from.Time),
+	}
+	m.Speed = SpeedOver(m.Distance, m.Elapsed)
+	return m
+}
+
+// HasMoved reports whether the displacement is larger than the given GPS
+// noise radius in meters. Displacements within the noise radius are
+// considered jitter of a stationary receiver.
+func (m *Movement) HasMoved(noise float64) bool {
+	return m.Distance > noise
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NMEA 0183 sentences
+
+// splitNMEA verifies the checksum of an NMEA sentence, when present, and
+// returns its comma-separated fields, starting with the address such as
+// "GPGGA". Sentences may start with $ or ! (encapsulated data, like AIS).
+func splitNMEA(sentence string) ([]string, error) {
+	sentence = strings.TrimSpace(sentence)
+	if sentence == "" || (sentence[0] != '$' && sentence[0] != '!') {
+		return nil, fmt.Errorf("Invalid NMEA sentence %q", sentence)
+	}
+	body := sentence[1:]
+	if i := strings.LastIndexByte(body, '*'); i >= 0 {
+		want, err := strconv.ParseUint(body[i+1:], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid NMEA checksum in %q", sentence)
+		}
+		body = body[:i]
+		if got := nmeaChecksum(body); got != byte(want) {
+			return nil, fmt.Errorf("NMEA checksum mismatch in %q: got %02X", sentence, got)
+		}
+	}
+	return strings.Split(body, ","), nil
+}
+
+// nmeaChecksum returns the XOR of the bytes of a sentence body, between the
+// leading $ and the *.
+func nmeaChecksum(body string) byte {
+	var sum byte
+	for i := 0; i < len(body); i++ {
+		sum ^= body[i]
+	}
+	return sum
+}
+
+// parseNMEAPosition parses an NMEA latitude ("4026.7717", "N") or longitude
+// ("07958.9300", "W") in degrees and decimal minutes.
+func parseNMEAPosition(value, hemisphere string, axis Axis) (DMS, error) {
+	degreeDigits := 2
+	if axis == AxisLongitude {
+		degreeDigits = 3
+	}
+	if len(value) < degreeDigits+2 {
+		return DMS{}, fmt.Errorf("Invalid NMEA position %q", value)
+	}
+	degree, err := strconv.ParseUint(value[:degreeDigits], 10, 32)
+	if err != nil {
+		return DMS{}, fmt.Errorf("Invalid NMEA position %q", value)
+	}
+	minutes, err := strconv.ParseFloat(value[degreeDigits:], 64)
+	if err != nil || minutes >= 60 {
+		return DMS{}, fmt.Errorf("Invalid NMEA position %q", value)
+	}
+	d := DecimalToDMS(float64(degree)+minutes/60, hemisphere, hemisphere)
+	if err := d.ValidateAs(axis); err != nil {
+		return DMS{}, err
+	}
+	return d, nil
+}
+
+// parseNMEATime parses an NMEA UTC time of day (hhmmss.ss) on the given date.
+func parseNMEATime(value string, date time.Time) (time.Time, error) {
+	if len(value) < 6 {
+		return time.Time{}, fmt.Errorf("Invalid NMEA time %q", value)
+	}
+	t, err := time.Parse("150405", value[:6])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid NMEA time %q", value)
+	}
+	var frac float64
+	if len(value) > 6 {
+		if frac, err = strconv.ParseFloat("0"+value[6:], 64); err != nil {
+			return time.Time{}, fmt.Errorf("Invalid NMEA time %q", value)
+		}
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(),
+		int(frac*1e9), time.UTC), nil
+}
+
+// errNoFix is returned for position sentences that report no valid fix.
+var errNoFix = errors.New("NMEA sentence without a valid fix")
+
+// ParseNMEA parses the position of a GGA, RMC or GLL NMEA sentence from any
+// talker (GP, GN, GL...). The time of the fix has a date only for RMC
+// sentences; for the others, it is on January 1, year 0. Sentences reporting
+// no valid fix return an error.
+func ParseNMEA(sentence string) (Fix, error) {
+	fields, err := splitNMEA(sentence)
+	if err != nil {
+		return Fix{}, err
+	}
+	if len(fields[0]) < 5 {
+		return Fix{}, fmt.Errorf("Invalid NMEA sentence %q", sentence)
+	}
+	var lat, latH, lon, lonH, clock, date string
+	switch kind := fields[0][len(fields[0])-3:]; {
+	case kind == "GGA" && len(fields) >= 7:
+		if fields[6] == "0" || fields[6] == "" {
+			return Fix{}, errNoFix
+		}
+		clock, lat, latH, lon, lonH = fields[1], fields[2], fields[3], fields[4], fields[5]
+	case kind == "RMC" && len(fields) >= 10:
+		if fields[2] != "A" {
+			return Fix{}, errNoFix
+		}
+		clock, lat, latH, lon, lonH, date = fields[1], fields[3], fields[4], fields[5], fields[6], fields[9]
+	case kind == "GLL" && len(fields) >= 7:
+		if fields[6] != "A" {
+			return Fix{}, errNoFix
+		}
+		lat, latH, lon, lonH, clock = fields[1], fields[2], fields[3], fields[4], fields[5]
+	default:
+		return Fix{}, fmt.Errorf("Unsupported NMEA sentence %q", fields[0])
+	}
+	var fix Fix
+	if fix.Coordinate.Latitude, err = parseNMEAPosition(lat, latH, AxisLatitude); err != nil {
+		return Fix{}, err
+	}
+	if fix.Coordinate.Longitude, err = parseNMEAPosition(lon, lonH, AxisLongitude); err != nil {
+		return Fix{}, err
+	}
+	day := time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+	if date != "" {
+		if day, err = time.Parse("020106", date); err != nil {
+			return Fix{}, fmt.Errorf("Invalid NMEA date %q", date)
+		}
+	}
+	if clock != "" {
+		if fix.Time, err = parseNMEATime(clock, day); err != nil {
+			return Fix{}, err
+		}
+	}
+	return fix, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Notation identifies the notation of a coordinate string.
+type Notation int
+
+// Notations recognized by ParseAny.
+const (
+	NotationDecimal    Notation = iota // 40.446195, -79.948862
+	NotationDDM                        // 40°26.772' N 79°56.932' W
+	NotationDMS                        // 40°26'46.30" N 79°56'55.90" W
+	NotationCompact                    // 402646.30N0795655.90W
+	NotationGeoURI                     // geo:40.446195,-79.948862
+	NotationMapURL                     // https://www.google.com/maps?q=40.446195,-79.948862
+	NotationGeohash                    // dppnhep00
+	NotationMaidenhead                 // FN00ak
+)
+
+// notationNames holds the names of the notations.
+var notationNames = [...]string{
+	NotationDecimal:    "decimal",
+	NotationDDM:        "ddm",
+	NotationDMS:        "dms",
+	NotationCompact:    "compact",
+	NotationGeoURI:     "geo-uri",
+	NotationMapURL:     "map-url",
+	NotationGeohash:    "geohash",
+	NotationMaidenhead: "maidenhead",
+}
+
+// String returns the name of the notation.
+func (n Notation) String() string {
+	if n >= 0 && int(n) < len(notationNames) {
+		return notationNames[n]
+	}
+	return fmt.Sprintf("Notation(%d)", int(n))
+}
+
+// ParseNotation returns the notation with the given name, as returned by
+// Notation.String.
+func ParseNotation(name string) (Notation, error) {
+	for n, s := range notationNames {
+		if strings.EqualFold(s, name) {
+			return Notation(n), nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown notation %q", name)
+}
+
+// ParseAny parses a coordinate in any supported notation and reports which
+// notation it was written in. Strings that could be read in several
+// notations are tried in the order: geo URI or map link, compact, decimal,
+// DDM or DMS, Maidenhead locator, then geohash.
+func ParseAny(s string) (c Coordinate, n Notation, err error) {
+	defer func(start time.Time) { observe("parse", start, err) }(time.Now())
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasPrefix(lower, "geo:"):
+		c, err := ParseMapsURL(s)
+		return c, NotationGeoURI, err
+	case strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://"):
+		c, err := ParseMapsURL(s)
+		return c, NotationMapURL, err
+	}
+	var invalid *ValidationError
+	c, err = ParseCompactCoordinate(s)
+	if err == nil {
+		return c, NotationCompact, nil
+	}
+	if errors.As(err, &invalid) {
+		return Coordinate{}, 0, err
+	}
+	c, err = ParseCoordinate(s)
+	if err == nil {
+		return c, notationOf(s), nil
+	}
+	// Values rejected by validation, or written with hemisphere letters, are
+	// latitude/longitude pairs and not locators or geohashes.
+	if !errors.As(err, &invalid) && !hemispherePattern.MatchString(s) && !strings.ContainsAny(s, " \t,") {
+		if c, mhErr := ParseMaidenhead(s); mhErr == nil {
+			hooks().ParseFallback(s, NotationMaidenhead)
+			return c, NotationMaidenhead, nil
+		}
+		if c, ghErr := ParseGeohash(s); ghErr == nil {
+			hooks().ParseFallback(s, NotationGeohash)
+			return c, NotationGeohash, nil
+		}
+	}
+	return Coordinate{}, 0, err
+}
+
+// hemispherePattern matches a latitude and longitude written without spaces
+// with hemisphere letters, such as "95N10E" or "N40.5W79.9".
+var hemispherePattern = regexp.MustCompile(`^(?i:[0-9.]+[NS][0-9.]+[EW]|[NS][0-9.]+[EW][0-9.]+)$`)
+
+// notationOf returns the notation of a string accepted by ParseCoordinate,
+// from the number of numbers given for its first value.
+func notationOf(s string) Notation {
+	tokens, _ := tokenizeDMS(s)
+	groups := groupDMSTokens(tokens)
+	numbers := 0
+	for _, t := range groups[0] {
+		if t.direction == "" && !t.separator {
+			numbers++
+		}
+	}
+	switch numbers {
+	case 1:
+		var d DMS
+		for _, t := range groups[0] {
+			if t.direction != "" {
+				d.Direction = t.direction
+			}
+		}
+		for _, t := range groups[0] {
+			if t.direction == "" && !t.separator && isPacked(t, d.Axis()) {
+				if t.digits >= 6 {
+					return NotationDMS
+				}
+				return NotationDDM
+			}
+		}
+		return NotationDecimal
+	case 2:
+		return NotationDDM
+	default:
+		return NotationDMS
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "testing"
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		input    string
+		notation Notation
+	}{
+		{"40.446195, -79.948862", NotationDecimal},
+		{"40°26.772' N 79°56.932' W", NotationDDM},
+		{`40°26'46.30" N 79°56'55.90" W`, NotationDMS},
+		{"402646.302N0795655.903W", NotationCompact},
+		{"geo:40.446195,-79.948862", NotationGeoURI},
+		{"https://www.google.com/maps?q=40.446195,-79.948862", NotationMapURL},
+		{"dppnhep00", NotationGeohash},
+		{"FN00ak", NotationMaidenhead},
+	}
+	want := coordinateFromDecimal(40.446195, -79.948862)
+	for _, tt := range tests {
+		c, n, err := ParseAny(tt.input)
+		if err != nil || n != tt.notation {
+			t.Errorf("ParseAny(%q) = %v, %v, want %v", tt.input, n, err, tt.notation)
+			continue
+		}
+		if c.Accuracy == 0 && Distance(c, want) > 1 || Distance(c, want) > c.Accuracy+1 {
+			t.Errorf("ParseAny(%q) = %v, want %v", tt.input, c, want)
+		}
+	}
+	for _, s := range []string{"", "nowhere", "95N10E", "geo:95,0", "https://example.com/", "40.5, -79.5, 3"} {
+		if c, n, err := ParseAny(s); err == nil {
+			t.Errorf("ParseAny(%q) = %v, %v, want error", s, c, n)
+		}
+	}
+}
+
+func TestParseNotation(t *testing.T) {
+	for n := NotationDecimal; n <= NotationMaidenhead; n++ {
+		if got, err := ParseNotation(n.String()); err != nil || got != n {
+			t.Errorf("ParseNotation(%q) = %v, %v, want %v", n.String(), got, err, n)
+		}
+	}
+	if got, err := ParseNotation("GEO-URI"); err != nil || got != NotationGeoURI {
+		t.Errorf("ParseNotation(GEO-URI) = %v, %v", got, err)
+	}
+	if _, err := ParseNotation("braille"); err == nil {
+		t.Error("ParseNotation(braille) succeeded, want error")
+	}
+	if got := Notation(42).String(); got != "Notation(42)" {
+		t.Errorf("Notation(42).String() = %q", got)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Nullable coordinates
+
+// NullCoordinate represents a coordinate that may be missing, like
+// sql.NullString. It is stored in SQL as text such as "40.446195,-79.982222"
+// or NULL, and written to JSON as an object with decimal latitude, longitude
+// and accuracy, or null.
+type NullCoordinate struct {
+	Coordinate Coordinate // The position, meaningful only when Valid.
+	Valid      bool       // Whether the position is present.
+}
+
+// NewNullCoordinate returns a valid NullCoordinate holding c.
+func NewNullCoordinate(c Coordinate) NullCoordinate {
+	return NullCoordinate{Coordinate: c, Valid: true}
+}
+
+// jsonCoordinate is the JSON layout of a present NullCoordinate.
+type jsonCoordinate struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Accuracy  float64 `json:"accuracy,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullCoordinate) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	if err := n.Coordinate.Validate(); err != nil {
+		return nil, err
+	}
+	lat, lon := n.Coordinate.Decimal()
+	return json.Marshal(jsonCoordinate{Latitude: lat, Longitude: lon, Accuracy: n.Coordinate.Accuracy})
+}
+
+// UnmarshalJSON implements json.Unmarshaler and validates the position.
+func (n *NullCoordinate) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullCoordinate{}
+		return nil
+	}
+	var in jsonCoordinate
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	c, err := NewCoordinate(in.Latitude, in.Longitude)
+	if err != nil {
+		return err
+	}
+	c.Accuracy = in.Accuracy
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	*n = NewNullCoordinate(c)
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullCoordinate) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if err := n.Coordinate.Validate(); err != nil {
+		return nil, err
+	}
+	lat, lon := n.Coordinate.Decimal()
+	return strconv.FormatFloat(lat, 'f', -1, 64) + "," + strconv.FormatFloat(lon, 'f', -1, 64), nil
+}
+
+// Scan implements sql.Scanner. It accepts NULL and text in any notation
+// read by ParseAny.
+func (n *NullCoordinate) Scan(value interface{}) error {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		*n = NullCoordinate{}
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("Cannot scan %T into NullCoordinate", value)
+	}
+	c, _, err := ParseAny(text)
+	if err != nil {
+		return err
+	}
+	*n = NewNullCoordinate(c)
+	return nil
+}
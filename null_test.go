@@ -0,0 +1,96 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullCoordinateJSON(t *testing.T) {
+	c := coordinateFromDecimal(40.446195, -79.982222)
+	c.Accuracy = 5
+	tests := []struct {
+		n    NullCoordinate
+		want string
+	}{
+		{NullCoordinate{}, `null`},
+		{NewNullCoordinate(c), `{"latitude":40.446195,"longitude":-79.982222,"accuracy":5}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.n)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("Marshal(%+v) = %s, %v, want %s", tt.n, data, err, tt.want)
+			continue
+		}
+		var back NullCoordinate
+		if err := json.Unmarshal(data, &back); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", data, err)
+			continue
+		}
+		if back.Valid != tt.n.Valid || back.Coordinate.Accuracy != tt.n.Coordinate.Accuracy ||
+			Distance(back.Coordinate, tt.n.Coordinate) > 1e-3 {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", data, back, tt.n)
+		}
+	}
+
+	// Unmarshaling null clears a previous value.
+	n := NewNullCoordinate(c)
+	if err := json.Unmarshal([]byte(" null "), &n); err != nil || n.Valid {
+		t.Errorf("Unmarshal(null) = %+v, %v, want invalid", n, err)
+	}
+	for _, s := range []string{`{"latitude":91,"longitude":0}`, `{"latitude":0,"longitude":0,"accuracy":-1}`, `"40,-79"`, `{`} {
+		if err := json.Unmarshal([]byte(s), &n); err == nil {
+			t.Errorf("Unmarshal(%s) = %+v, want error", s, n)
+		}
+	}
+}
+
+func TestNullCoordinateSQL(t *testing.T) {
+	c := coordinateFromDecimal(40.446195, -79.982222)
+	v, err := NewNullCoordinate(c).Value()
+	if err != nil || v != "40.446195,-79.982222" {
+		t.Errorf("Value() = %v, %v, want 40.446195,-79.982222", v, err)
+	}
+	if v, err := (NullCoordinate{}).Value(); err != nil || v != nil {
+		t.Errorf("Value() of an invalid NullCoordinate = %v, %v, want nil", v, err)
+	}
+
+	tests := []struct {
+		value interface{}
+		valid bool
+	}{
+		{nil, false},
+		{"40.446195,-79.982222", true},
+		{[]byte(`40°26'46.30" N 79°58'56.00" W`), true},
+	}
+	for _, tt := range tests {
+		n := NewNullCoordinate(c)
+		if err := n.Scan(tt.value); err != nil || n.Valid != tt.valid {
+			t.Errorf("Scan(%v) = %+v, %v, want valid %v", tt.value, n, err, tt.valid)
+			continue
+		}
+		if tt.valid && Distance(n.Coordinate, c) > 1 {
+			t.Errorf("Scan(%v) = %v, want %v", tt.value, n.Coordinate, c)
+		}
+	}
+	for _, value := range []interface{}{42, "somewhere", []byte("91,0")} {
+		var n NullCoordinate
+		if err := n.Scan(value); err == nil {
+			t.Errorf("Scan(%v) = %+v, want error", value, n)
+		}
+	}
+}
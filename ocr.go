@@ -0,0 +1,135 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Correction describes a change applied to OCR output by ParseOCR.
+type Correction struct {
+	Offset      int    // Offset, in runes, of the corrected text in the input.
+	Original    string // Text read by the OCR engine.
+	Replacement string // Text it was replaced with.
+}
+
+// String returns a human-readable description of the correction.
+func (c *Correction) String() string {
+	return fmt.Sprintf("%q -> %q at %d", c.Original, c.Replacement, c.Offset)
+}
+
+// ocrDigits maps letters commonly misread for digits to these digits.
+var ocrDigits = map[rune]rune{
+	'O': '0', 'o': '0', 'Q': '0', 'D': '0',
+	'l': '1', 'I': '1', '|': '1', 'i': '1',
+	'Z': '2', 'z': '2',
+	'S': '5', 's': '5',
+	'G': '6', 'b': '6',
+	'B': '8',
+	'g': '9', 'q': '9',
+}
+
+// ocrDegreeSigns lists characters commonly misread for the degree sign.
+const ocrDegreeSigns = "*^"
+
+// ParseOCR parses a coordinate from OCR output, tolerating common recognition
+// errors: letters misread for digits inside numbers (O for 0, l for 1, S for
+// 5...), a lowercase o, asterisk or caret in place of the degree sign, two
+// apostrophes in place of the second sign, a middle dot in place of the
+// decimal point, and missing symbols. It returns the best-guess coordinate
+// along with the corrections it applied, which callers should surface for
+// review.
+func ParseOCR(s string) (Coordinate, []Correction, error) {
+	text, corrections := correctOCR(s)
+	for _, c := range corrections {
+		hooks().Correction(s, c)
+	}
+	c, err := ParseCoordinate(text)
+	if err != nil {
+		return Coordinate{}, corrections, err
+	}
+	return c, corrections, nil
+}
+
+// correctOCR returns s with OCR errors corrected and the applied corrections.
+func correctOCR(s string) (string, []Correction) {
+	runes := []rune(s)
+	var corrections []Correction
+	var b strings.Builder
+	replace := func(i, n int, replacement string) {
+		corrections = append(corrections, Correction{Offset: i, Original: string(runes[i : i+n]), Replacement: replacement})
+		b.WriteString(replacement)
+	}
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case isNumberRune(r) || ocrDigits[r] != 0:
+			// Correct a run of digits and look-alike letters as one number.
+			j := i
+			for j < len(runes) && (isNumberRune(runes[j]) || ocrDigits[runes[j]] != 0) {
+				j++
+			}
+			start, end := ocrNumber(runes[i:j])
+			b.WriteString(string(runes[i : i+start]))
+			for k := i + start; k < i+end; k++ {
+				if d := ocrDigits[runes[k]]; d != 0 {
+					replace(k, 1, string(d))
+				} else {
+					b.WriteRune(runes[k])
+				}
+			}
+			if end < j-i && runes[i+end] == 'o' && end == j-i-1 && start < end {
+				// "40o 26'": a raised o read for the degree sign.
+				replace(i+end, 1, "°")
+				end++
+			}
+			b.WriteString(string(runes[i+end : j]))
+			i = j - 1
+		case (r == '\'' || r == '′' || r == '’') && i+1 < len(runes) && runes[i+1] == r:
+			replace(i, 2, `"`)
+			i++
+		case r == '·' && i > 0 && isNumberRune(runes[i-1]):
+			replace(i, 1, ".")
+		case strings.ContainsRune(ocrDegreeSigns, r) && i > 0 && isNumberRune(runes[i-1]):
+			replace(i, 1, "°")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), corrections
+}
+
+// ocrNumber returns the bounds of the number within a run of digits and
+// look-alike letters. Direction letters (S) at either end of the run and a
+// trailing o, which stands for a degree sign, are left out; the bounds are
+// empty when the run holds no actual digit, as in the words "Sol" or "Oslo".
+func ocrNumber(run []rune) (start, end int) {
+	start, end = 0, len(run)
+	for start < end && unicode.ToUpper(run[start]) == 'S' {
+		start++
+	}
+	for end > start && (unicode.ToUpper(run[end-1]) == 'S' || end == len(run) && run[end-1] == 'o') {
+		end--
+	}
+	for _, r := range run[start:end] {
+		if r >= '0' && r <= '9' {
+			return start, end
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOCR(t *testing.T) {
+	tests := []struct {
+		s           string
+		lat, lon    DMS
+		corrections []Correction
+	}{
+		{`40°26'46" N 79°56'55" W`, DMS{40, 26, 46, "N"}, DMS{79, 56, 55, "W"}, nil},
+		{`4O°26'46.3" N 79°56'55.9" W`, DMS{40, 26, 46.3, "N"}, DMS{79, 56, 55.9, "W"},
+			[]Correction{{1, "O", "0"}}},
+		{`40*26'46''N 79^56'55''W`, DMS{40, 26, 46, "N"}, DMS{79, 56, 55, "W"},
+			[]Correction{{2, "*", "°"}, {8, "''", `"`}, {14, "^", "°"}, {20, "''", `"`}}},
+		{`40o 26' 46·3" S 79° 56' 55.9" E`, DMS{40, 26, 46.3, "S"}, DMS{79, 56, 55.9, "E"},
+			[]Correction{{2, "o", "°"}, {10, "·", "."}}},
+		{`l2°3O'0O" S lO5°O5' E`, DMS{12, 30, 0, "S"}, DMS{105, 5, 0, "E"},
+			[]Correction{{0, "l", "1"}, {4, "O", "0"}, {7, "O", "0"}, {12, "l", "1"}, {13, "O", "0"}, {16, "O", "0"}}},
+	}
+	for _, tt := range tests {
+		c, corrections, err := ParseOCR(tt.s)
+		if err != nil {
+			t.Errorf("ParseOCR(%q) error: %v", tt.s, err)
+			continue
+		}
+		if c.Latitude != tt.lat || c.Longitude != tt.lon {
+			t.Errorf("ParseOCR(%q) = %v, want %v %v", tt.s, c, tt.lat, tt.lon)
+		}
+		if !reflect.DeepEqual(corrections, tt.corrections) {
+			t.Errorf("ParseOCR(%q) corrections = %v, want %v", tt.s, corrections, tt.corrections)
+		}
+	}
+}
+
+func TestParseOCRMalformed(t *testing.T) {
+	for _, s := range []string{``, `Oslo`, `4O°26'46" X 79°56'55" W`, `40°26'46" N`} {
+		if c, _, err := ParseOCR(s); err == nil {
+			t.Errorf("ParseOCR(%q) = %v, want error", s, c)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"time"
+)
+
+// Circular orbits
+
+const (
+	earthGM       = 3.986004418e14 // Gravitational parameter of the Earth in m³/s².
+	earthJ2       = 1.08262668e-3  // Second zonal harmonic of the Earth.
+	earthRotation = 7.2921150e-5   // Rotation rate of the Earth in rad/s.
+	// Mean motion of the Sun in rad/s, the nodal precession rate of
+	// sun-synchronous orbits.
+	sunMeanMotion = 2 * math.Pi / (365.2421897 * 86400)
+)
+
+// CircularOrbit is a circular satellite orbit, with the regression of its
+// node due to the oblateness of the Earth (J2) and no other perturbation.
+type CircularOrbit struct {
+	Altitude      float64   // Altitude above the equatorial radius in meters.
+	Inclination   float64   // Inclination in degrees, above 90 for retrograde orbits.
+	AscendingNode float64   // Geographic longitude of the ascending node at Epoch in degrees.
+	Phase         float64   // Argument of latitude at Epoch: degrees traveled since the ascending node.
+	Epoch         time.Time // Reference time of AscendingNode and Phase.
+}
+
+// SunSynchronousInclination returns the inclination in degrees of a circular
+// sun-synchronous orbit at the given altitude in meters, or NaN when no
+// inclination makes the orbit sun-synchronous.
+func SunSynchronousInclination(altitude float64) float64 {
+	a := EllipsoidWGS84.A + altitude
+	r := EllipsoidWGS84.A / a
+	cos := -sunMeanMotion / (1.5 * math.Sqrt(earthGM/(a*a*a)) * earthJ2 * r * r)
+	if cos < -1 {
+		return math.NaN()
+	}
+	return math.Acos(cos) / degToRad
+}
+
+// rates returns the mean motion and the nodal precession rate of the orbit
+// in rad/s.
+func (o *CircularOrbit) rates() (motion, precession float64) {
+	a := EllipsoidWGS84.A + o.Altitude
+	r := EllipsoidWGS84.A / a
+	motion = math.Sqrt(earthGM / (a * a * a))
+	return motion, -1.5 * motion * earthJ2 * r * r * math.Cos(o.Inclination*degToRad)
+}
+
+// Period returns the orbital period.
+func (o *CircularOrbit) Period() time.Duration {
+	motion, _ := o.rates()
+	return time.Duration(2 * math.Pi / motion * float64(time.Second))
+}
+
+// PositionAt returns the subsatellite point at t.
+func (o *CircularOrbit) PositionAt(t time.Time) Coordinate {
+	motion, precession := o.rates()
+	dt := t.Sub(o.Epoch).Seconds()
+	u := o.Phase*degToRad + motion*dt
+	node := o.AscendingNode*degToRad + (precession-earthRotation)*dt
+	inclination := o.Inclination * degToRad
+	lat := math.Asin(math.Sin(inclination) * math.Sin(u))
+	lon := node + math.Atan2(math.Cos(inclination)*math.Sin(u), math.Cos(u))
+	return coordinateFromDecimal(lat/degToRad, Normalize180(lon/degToRad))
+}
+
+// GroundTrack returns the subsatellite points from start over duration, every
+// step, both ends included.
+func (o *CircularOrbit) GroundTrack(start time.Time, duration, step time.Duration) []Coordinate {
+	if step <= 0 || duration < 0 {
+		return nil
+	}
+	var track []Coordinate
+	for offset := time.Duration(0); offset <= duration; offset += step {
+		track = append(track, o.PositionAt(start.Add(offset)))
+	}
+	return track
+}
@@ -0,0 +1,547 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parsing functions
+
+// dmsSeparators lists the symbols that may separate the parts of a DMS string.
+const dmsSeparators = `°º˚'"′″‘’“”+:`
+
+// dmsUnitSymbols lists the degree, minute and second symbols.
+const dmsUnitSymbols = `°º˚'"′″‘’“”`
+
+// dmsToken is a lexical element of a DMS string.
+type dmsToken struct {
+	value     float64 // Value of a number token.
+	integer   bool    // Number was written without a fractional part.
+	digits    int     // Number of digits of the integer part of a number.
+	negative  bool    // Number was preceded by a minus sign.
+	direction string  // Direction (N, S, E, W) of a direction token.
+	separator bool    // Token is a comma or semicolon between two values.
+	rtl       bool    // Number follows its unit symbol, as in StringRTL output.
+}
+
+// ParseDMS parses a single DMS value such as `40°26'46.30" N`, `N 40 26 46.3`,
+// `40°26.772' N` or `40.446195N`. The direction is required; it may also be
+// spelled out in any supported locale, as in `40 26 46.3 North` or `40 26 46,3 Ost`.
+// The right-to-left layout of StringRTL, `N "46.30 '26 °40`, is accepted too,
+// as are values packed by GPS receivers, such as `4026.77N` or `402646N`, and
+// vulgar fractions of archival documents, as in `40°26'46½" N` or
+// "40 26 46 1/2 N". As in NMEA sentences, packed values have two degree
+// digits for latitude and three for longitude, followed by two minute digits
+// and optionally two second digits, so `0040.5N` is 0°40.5' N and
+// `00130.00E` is 1°30' E; a single number of any other width is decimal
+// degrees.
+func ParseDMS(s string) (DMS, error) {
+	tokens, err := tokenizeDMS(s)
+	if err != nil {
+		return DMS{}, err
+	}
+	groups := groupDMSTokens(tokens)
+	if len(groups) != 1 {
+		return DMS{}, fmt.Errorf("Invalid DMS value %q", s)
+	}
+	d, err := dmsFromTokens(groups[0], "", "")
+	if err != nil {
+		return DMS{}, err
+	}
+	return fitDMS(d)
+}
+
+// ParseCoordinate parses a latitude/longitude pair such as
+// `40°26'46.30" N 79°58'56.00" W`, `N40 26 46 W79 58 56` or `40.446, -79.982`.
+// Signed values without directions are taken as latitude followed by longitude.
+// Values with directions may be packed as by GPS receivers, degrees and
+// minutes (and seconds) without separators, as in `4026.77N 07958.93W`.
+func ParseCoordinate(s string) (Coordinate, error) {
+	tokens, err := tokenizeDMS(s)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	groups := groupDMSTokens(tokens)
+	if len(groups) != 2 {
+		return Coordinate{}, fmt.Errorf("Invalid coordinate %q", s)
+	}
+	lat, err := dmsFromTokens(groups[0], "N", "S")
+	if err != nil {
+		return Coordinate{}, err
+	}
+	lon, err := dmsFromTokens(groups[1], "E", "W")
+	if err != nil {
+		return Coordinate{}, err
+	}
+	if lat.Direction == "E" || lat.Direction == "W" {
+		lat, lon = lon, lat
+	}
+	coord, err := fitCoordinate(Coordinate{Latitude: lat, Longitude: lon})
+	if err != nil {
+		return Coordinate{}, err
+	}
+	if err := coord.Validate(); err != nil {
+		return Coordinate{}, err
+	}
+	return coord, nil
+}
+
+// tokenizeDMS splits a DMS string into numbers, directions and value separators.
+// Persian and Arabic-Indic digits are read like ASCII digits, and unit words
+// such as "degrees" or "درجه" like unit symbols.
+func tokenizeDMS(s string) ([]dmsToken, error) {
+	var tokens []dmsToken
+	runes := []rune(normalizeDigits(s))
+	negative := false
+	// whole is the index of a whole number token that a following vulgar
+	// fraction adds to, as in "46 1/2", or -1.
+	whole := -1
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		fraction, next, ok, err := readFraction(runes, i)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if whole >= 0 {
+				tokens[whole].value += fraction
+				tokens[whole].integer = false
+			} else {
+				tokens = append(tokens, dmsToken{value: fraction, negative: negative, rtl: unitBefore(runes, i)})
+				negative = false
+			}
+			whole, i = -1, next
+			// Skip a unit marker directly following the fraction, as in "46 1/2s".
+			if i < len(runes) && strings.ContainsRune("dms", runes[i]) && !isLetterAt(runes, i+1) {
+				i++
+			}
+			continue
+		}
+		if !unicode.IsSpace(r) && !unicode.Is(unicode.Cf, r) {
+			whole = -1
+		}
+		switch {
+		case isNumberRune(r):
+			j := i
+			for j < len(runes) && isNumberRune(runes[j]) {
+				j++
+			}
+			text := string(runes[i:j])
+			if k, ok := decimalComma(runes, j); ok && !strings.Contains(text, ".") {
+				text += "." + string(runes[j+1:k])
+				j = k
+			}
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid number %q", text)
+			}
+			digits := strings.IndexByte(text, '.')
+			if digits < 0 {
+				digits = len(text)
+			}
+			tokens = append(tokens, dmsToken{value: value, integer: digits == len(text), digits: digits, negative: negative, rtl: unitBefore(runes, i)})
+			negative = false
+			i = j
+			if digits == len(text) {
+				whole = len(tokens) - 1
+			}
+			// Skip a unit marker directly following the number, as in "40d 26m 46.3s".
+			if i < len(runes) && strings.ContainsRune("dms", runes[i]) && !isLetterAt(runes, i+1) {
+				whole = -1
+				i++
+			}
+		case r == '-' || r == '−':
+			negative = true
+			i++
+		case strings.ContainsRune("NSEWnsew", r) && !isLetterAt(runes, i+1):
+			tokens = append(tokens, dmsToken{direction: string(unicode.ToUpper(r))})
+			i++
+		case unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && unicode.IsLetter(runes[j]) {
+				j++
+			}
+			word := string(runes[i:j])
+			i = j
+			if fillerWords[strings.ToLower(word)] {
+				continue
+			}
+			direction, exact, err := directionFromWord(word)
+			if err != nil {
+				return nil, err
+			}
+			if !exact {
+				hooks().Correction(s, Correction{Offset: i, Original: word, Replacement: DirectionName(direction, LocaleEnglish)})
+			}
+			tokens = append(tokens, dmsToken{direction: direction})
+		case r == ',' || r == ';' || r == '،' || r == '؛':
+			tokens = append(tokens, dmsToken{separator: true})
+			i++
+		case unicode.IsSpace(r) || unicode.Is(unicode.Cf, r) || strings.ContainsRune(dmsSeparators, r):
+			i++
+		default:
+			return nil, fmt.Errorf("Unexpected character %q in %q", r, s)
+		}
+	}
+	return tokens, nil
+}
+
+// groupDMSTokens splits tokens into one group per DMS value. Directions
+// delimit the values when present, either as prefixes or as suffixes;
+// otherwise values are delimited by separators or are single numbers.
+func groupDMSTokens(tokens []dmsToken) [][]dmsToken {
+	var groups [][]dmsToken
+	var current []dmsToken
+	flush := func() {
+		if len(current) > 0 {
+			groups = append(groups, current)
+			current = nil
+		}
+	}
+	hasDirection, prefix := false, false
+	for _, t := range tokens {
+		if t.direction != "" {
+			hasDirection = true
+			break
+		}
+	}
+	for _, t := range tokens {
+		if !t.separator {
+			prefix = t.direction != ""
+			break
+		}
+	}
+	switch {
+	case hasDirection:
+		for _, t := range tokens {
+			switch {
+			case t.separator:
+			case t.direction != "" && prefix:
+				flush()
+				current = append(current, t)
+			case t.direction != "":
+				current = append(current, t)
+				flush()
+			default:
+				current = append(current, t)
+			}
+		}
+	case hasSeparator(tokens):
+		for _, t := range tokens {
+			if t.separator {
+				flush()
+			} else {
+				current = append(current, t)
+			}
+		}
+	case len(tokens) == 2:
+		// Two bare numbers, as in "40.446 -79.982".
+		return [][]dmsToken{tokens[:1], tokens[1:]}
+	default:
+		current = tokens
+	}
+	flush()
+	return groups
+}
+
+// dmsFromTokens builds a DMS from the numbers and direction of a token group.
+// Without a direction, the sign of the value selects the positive or negative
+// indicator; an empty indicator means a direction is required.
+func dmsFromTokens(group []dmsToken, positiveIndicator, negativeIndicator string) (DMS, error) {
+	var numbers []dmsToken
+	direction := ""
+	negative := false
+	for _, t := range rtlOrder(group) {
+		if t.direction != "" {
+			if direction != "" {
+				return DMS{}, errors.New("Multiple directions in DMS value")
+			}
+			direction = t.direction
+			continue
+		}
+		if t.negative {
+			if len(numbers) > 0 {
+				return DMS{}, errors.New("Misplaced minus sign in DMS value")
+			}
+			negative = true
+		}
+		numbers = append(numbers, t)
+	}
+	explicit := direction != ""
+	switch {
+	case direction != "" && negative:
+		return DMS{}, errors.New("Signed DMS value with a direction")
+	case direction == "" && positiveIndicator == "":
+		return DMS{}, errors.New("Missing direction in DMS value")
+	case direction == "" && negative:
+		direction = negativeIndicator
+	case direction == "":
+		direction = positiveIndicator
+	}
+	result := DMS{Direction: direction}
+	switch len(numbers) {
+	case 1:
+		if explicit && isPacked(numbers[0], result.Axis()) {
+			result = unpackDMS(numbers[0])
+			result.Direction = direction
+			break
+		}
+		result.Degree, result.Minutes, result.Seconds = decimalToDMSComponents(numbers[0].value)
+	case 2:
+		if !numbers[0].integer {
+			return DMS{}, errors.New("Fractional degrees followed by minutes")
+		}
+		minutes := math.Floor(numbers[1].value)
+		result.Degree = uint(numbers[0].value)
+		result.Minutes = uint(minutes)
+		result.Seconds = (numbers[1].value - minutes) * 60
+	case 3:
+		if !numbers[0].integer || !numbers[1].integer {
+			return DMS{}, errors.New("Fractional degrees or minutes followed by seconds")
+		}
+		result.Degree = uint(numbers[0].value)
+		result.Minutes = uint(numbers[1].value)
+		result.Seconds = numbers[2].value
+	default:
+		return DMS{}, errors.New("Invalid number of DMS components")
+	}
+	if err := result.Validate(); err != nil && !rangeTolerated(err) {
+		return DMS{}, err
+	}
+	return result, nil
+}
+
+// isPacked reports whether a number is a value of the axis packed in the
+// style of GPS receivers: DDMM.mm or DDDMM.mm with degrees and minutes, and
+// DDMMSS.ss or DDDMMSS.ss with seconds too, as in "4026.77N 07958.93W". The
+// degree width is fixed by the axis, as in parseNMEAPosition.
+func isPacked(t dmsToken, axis Axis) bool {
+	degreeDigits := 2
+	switch axis {
+	case AxisLongitude:
+		degreeDigits = 3
+	case AxisUnknown:
+		return false
+	}
+	return !t.negative && (t.digits == degreeDigits+2 || t.digits == degreeDigits+4)
+}
+
+// unpackDMS splits a packed number into degrees, minutes and seconds.
+func unpackDMS(t dmsToken) DMS {
+	var d DMS
+	value := t.value
+	if t.digits >= 6 {
+		whole := math.Floor(value / 100)
+		d.Seconds = value - whole*100
+		value = whole
+	} else {
+		d.Seconds = math.Mod(value, 1) * 60
+		value = math.Floor(value)
+	}
+	d.Degree, d.Minutes = uint(value/100), uint(math.Mod(value, 100))
+	return d
+}
+
+// rtlOrder returns group with its numbers in reading order. When a number of
+// the group follows its unit symbol, as in `N "46.30 '26 °40`, the numbers
+// were laid out right to left and are reversed.
+func rtlOrder(group []dmsToken) []dmsToken {
+	rtl := false
+	for _, t := range group {
+		rtl = rtl || t.rtl
+	}
+	if !rtl {
+		return group
+	}
+	ordered := make([]dmsToken, len(group))
+	copy(ordered, group)
+	for i, j := 0, len(ordered)-1; i < j; {
+		switch {
+		case ordered[i].direction != "":
+			i++
+		case ordered[j].direction != "":
+			j--
+		default:
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+			i++
+			j--
+		}
+	}
+	return ordered
+}
+
+// unitBefore reports whether the number starting at index i directly follows
+// a unit symbol that does not itself follow a number, as in "°40" but not in
+// "40°26".
+func unitBefore(runes []rune, i int) bool {
+	return i > 0 && strings.ContainsRune(dmsUnitSymbols, runes[i-1]) &&
+		(i == 1 || !isNumberRune(runes[i-2]))
+}
+
+// hasSeparator reports whether tokens contain a value separator.
+func hasSeparator(tokens []dmsToken) bool {
+	for _, t := range tokens {
+		if t.separator {
+			return true
+		}
+	}
+	return false
+}
+
+// decimalComma reports whether the comma at index i of runes is the decimal
+// separator of French, German or Spanish output, as in `46,30" N` or
+// "46,3 Ost", and returns the index after the digits following it. The comma
+// must join two numbers and the number after it be followed by a unit or a
+// direction, so that "40,79" stays a pair of values.
+func decimalComma(runes []rune, i int) (int, bool) {
+	if i+1 >= len(runes) || runes[i] != ',' || runes[i+1] < '0' || runes[i+1] > '9' {
+		return 0, false
+	}
+	k := i + 1
+	for k < len(runes) && runes[k] >= '0' && runes[k] <= '9' {
+		k++
+	}
+	m := k
+	for m < len(runes) && unicode.IsSpace(runes[m]) {
+		m++
+	}
+	if m == len(runes) || !unicode.IsLetter(runes[m]) && !strings.ContainsRune(dmsUnitSymbols, runes[m]) {
+		return 0, false
+	}
+	return k, true
+}
+
+// isNumberRune reports whether r can be part of a number.
+func isNumberRune(r rune) bool {
+	return r >= '0' && r <= '9' || r == '.'
+}
+
+// isLetterAt reports whether runes has a letter at index i.
+func isLetterAt(runes []rune, i int) bool {
+	return i < len(runes) && unicode.IsLetter(runes[i])
+}
+
+// normalizeDigits replaces Persian and Arabic-Indic digits with ASCII digits
+// and the Arabic decimal separator with a point, rune for rune. The Arabic
+// yeh and kaf are replaced with their Persian forms, so words typed on an
+// Arabic keyboard match the Persian locale.
+func normalizeDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '۰' && r <= '۹':
+			return '0' + r - '۰'
+		case r >= '٠' && r <= '٩':
+			return '0' + r - '٠'
+		case r == '٫':
+			return '.'
+		case r == 'ي':
+			return 'ی'
+		case r == 'ك':
+			return 'ک'
+		}
+		return r
+	}, s)
+}
+
+// fillerWords holds the lowercase words skipped by the parser: unit words in
+// every locale, their English abbreviations and the Persian "and", as in
+// "۳۵ درجه و ۴۱ دقیقه".
+var fillerWords = func() map[string]bool {
+	words := map[string]bool{"deg": true, "degs": true, "min": true, "mins": true, "sec": true, "secs": true, "و": true}
+	for _, loc := range locales {
+		for _, unit := range loc.units {
+			words[strings.ToLower(unit[0])] = true
+			words[strings.ToLower(unit[1])] = true
+		}
+	}
+	return words
+}()
+
+// directionAliases maps hemisphere words missing from the locale data, such
+// as the Persian adjectives, to directions.
+var directionAliases = map[string]string{
+	"شمالی": "N", "جنوبی": "S", "شرقی": "E", "غربی": "W",
+}
+
+// directionFromWord returns the direction (N, S, E, W) named by a hemisphere
+// word in any supported locale, such as "North", "sud" or "Ost". Words of four
+// letters or more may contain one misspelling, seven letters or more two, as
+// long as the closest names agree on the direction; exact reports whether the
+// word needed no correction.
+func directionFromWord(word string) (direction string, exact bool, err error) {
+	word = strings.ToLower(word)
+	if direction, ok := directionAliases[word]; ok {
+		return direction, true, nil
+	}
+	best, bestDistance := "", -1
+	for _, loc := range locales {
+		for direction, name := range loc.directions {
+			distance := editDistance(word, strings.ToLower(name))
+			switch {
+			case bestDistance < 0 || distance < bestDistance:
+				best, bestDistance = direction, distance
+			case distance == bestDistance && direction != best:
+				best = ""
+			}
+		}
+	}
+	allowed := 0
+	switch n := utf8.RuneCountInString(word); {
+	case n >= 7:
+		allowed = 2
+	case n >= 4:
+		allowed = 1
+	}
+	if best == "" || bestDistance > allowed {
+		return "", false, fmt.Errorf("Unknown direction %q", word)
+	}
+	return best, bestDistance == 0, nil
+}
+
+// editDistance returns the Damerau-Levenshtein distance (optimal string
+// alignment) between two strings, in runes: transposed letters count as a
+// single edit, like insertions, deletions and substitutions.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseDMSPacked(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"0040.5N", 40.5 / 60},
+		{"00040.5E", 40.5 / 60},
+		{"00130.00E", 1.5},
+		{"00007.50W", 7.5 / 60},
+		{"5130.12N", 51 + 30.12/60},
+		{"40.5N", 40.5},
+		{"079.5E", 79.5},
+		{"4026.77N", 40 + 26.77/60},
+		{"07958.93W", 79 + 58.93/60},
+		{"402646N", 40 + 26.0/60 + 46.0/3600},
+		{"0795856W", 79 + 58.0/60 + 56.0/3600},
+	}
+	for _, tt := range tests {
+		d, err := ParseDMS(tt.input)
+		if err != nil {
+			t.Errorf("ParseDMS(%q): %v", tt.input, err)
+			continue
+		}
+		if got := DMSToDecimal(d); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ParseDMS(%q) = %v, want %g°", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseCoordinatePacked(t *testing.T) {
+	c, err := ParseCoordinate("5130.12N 00007.50W")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lat, lon := c.Decimal()
+	if math.Abs(lat-(51+30.12/60)) > 1e-9 || math.Abs(lon+7.5/60) > 1e-9 {
+		t.Errorf("ParseCoordinate(%q) = %v, %v", "5130.12N 00007.50W", lat, lon)
+	}
+	if _, n, err := ParseAny("5130.12N 00007.50W"); err != nil || n != NotationDDM {
+		t.Errorf("ParseAny(%q) = %v, %v, want %v", "5130.12N 00007.50W", n, err, NotationDDM)
+	}
+}
+
+func TestParseDMSDecimalComma(t *testing.T) {
+	tests := []struct {
+		input string
+		want  DMS
+	}{
+		{"40 26 46,3 Ost", DMS{40, 26, 46.3, "E"}},
+		{`40°1'46,30" Nord`, DMS{40, 1, 46.3, "N"}},
+		{"40 Grad 1 Minute 46,30 Sekunden Nord", DMS{40, 1, 46.3, "N"}},
+		{"40 grados 1 minuto 46,30 segundos Norte", DMS{40, 1, 46.3, "N"}},
+		{"40,5° N", DMS{40, 30, 0, "N"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDMS(tt.input)
+		if err != nil || got.Degree != tt.want.Degree || got.Minutes != tt.want.Minutes ||
+			math.Abs(got.Seconds-tt.want.Seconds) > 1e-9 || got.Direction != tt.want.Direction {
+			t.Errorf("ParseDMS(%q) = %+v, %v, want %+v", tt.input, got, err, tt.want)
+		}
+	}
+	// A comma between numbers not followed by a unit or direction separates values.
+	c, err := ParseCoordinate("40,79")
+	if lat, lon := c.Decimal(); err != nil || lat != 40 || lon != 79 {
+		t.Errorf("ParseCoordinate(%q) = %v, %v, %v", "40,79", lat, lon, err)
+	}
+}
+
+func TestParseDMSHemisphereWords(t *testing.T) {
+	tests := []struct {
+		input     string
+		direction string
+	}{
+		{"40 26 46 North", "N"},
+		{"40 26 46 south", "S"},
+		{"40 26 46 Ouest", "W"},
+		{"40 26 46 Nrth", "N"},
+		{"40 26 46 Soutth", "S"},
+		{"79 56 55 Westt", "W"},
+		{"79 56 55 Ostt", "E"},
+		{"40 26 46 شمال", "N"},
+	}
+	for _, tt := range tests {
+		got, err := ParseDMS(tt.input)
+		if err != nil || got.Direction != tt.direction {
+			t.Errorf("ParseDMS(%q) = %+v, %v, want direction %s", tt.input, got, err, tt.direction)
+		}
+	}
+	for _, s := range []string{"40 26 46 Nowhere", "40 26 46 Ny", "40 26 46 Xyzzy"} {
+		if got, err := ParseDMS(s); err == nil {
+			t.Errorf("ParseDMS(%q) = %+v, want error", s, got)
+		}
+	}
+}
+
+func TestParseDMSPersianDigits(t *testing.T) {
+	tests := []struct {
+		input string
+		want  DMS
+	}{
+		{"۳۵ درجه و ۴۱ دقیقه و ۲۴٫۵ ثانیه شمالی", DMS{35, 41, 24.5, "N"}},
+		{"٣٥° ٤١' ٢٤٫٥\" N", DMS{35, 41, 24.5, "N"}},
+		{"۵۱°۲۴'۳۲\" غربی", DMS{51, 24, 32, "W"}},
+		{"۵۱ درجه ۲۴ دقيقه شرقی", DMS{51, 24, 0, "E"}},
+		{"35 degrees 41 minutes 24.5 seconds north", DMS{35, 41, 24.5, "N"}},
+		{"35 deg 41 min 24.5 sec S", DMS{35, 41, 24.5, "S"}},
+	}
+	for _, tt := range tests {
+		if got, err := ParseDMS(tt.input); err != nil || got != tt.want {
+			t.Errorf("ParseDMS(%q) = %+v, %v, want %+v", tt.input, got, err, tt.want)
+		}
+	}
+	const pair = "۳۵ درجه ۴۱ دقیقه شمال، ۵۱ درجه ۲۴ دقیقه شرق"
+	c, err := ParseCoordinate(pair)
+	if err != nil || c.Latitude != (DMS{35, 41, 0, "N"}) || c.Longitude != (DMS{51, 24, 0, "E"}) {
+		t.Errorf("ParseCoordinate(%q) = %v, %v", pair, c, err)
+	}
+	for _, s := range []string{"۳۵ درجه ۹۱ دقیقه شمال", "۳۵ درجه ۴۱ دقیقه ۷۰ ثانیه شمال"} {
+		if got, err := ParseDMS(s); err == nil {
+			t.Errorf("ParseDMS(%q) = %+v, want error", s, got)
+		}
+	}
+}
+
+func TestParseDMSRTL(t *testing.T) {
+	values := []DMS{
+		{40, 26, 46.3, "N"},
+		{179, 59, 59.99, "W"},
+		{0, 0, 0.5, "S"},
+		{8, 0, 0, "E"},
+	}
+	for _, d := range values {
+		for _, mode := range []BidiMode{BidiNone, BidiEmbedding, BidiIsolate, BidiMark} {
+			s := d.StringRTLBidi(mode)
+			if got, err := ParseDMS(s); err != nil || got != d {
+				t.Errorf("ParseDMS(%q) = %+v, %v, want %+v", s, got, err, d)
+			}
+		}
+	}
+	tests := []struct {
+		input string
+		want  DMS
+	}{
+		{`N "46.30 '26 °40`, DMS{40, 26, 46.3, "N"}},
+		{`N '26 °40`, DMS{40, 26, 0, "N"}},
+		{`40°26'46.30" N`, DMS{40, 26, 46.3, "N"}},
+	}
+	for _, tt := range tests {
+		if got, err := ParseDMS(tt.input); err != nil || got != tt.want {
+			t.Errorf("ParseDMS(%q) = %+v, %v, want %+v", tt.input, got, err, tt.want)
+		}
+	}
+	for _, s := range []string{`N "46.30 '61 °40`, `N "46.30 '26 °95`} {
+		if got, err := ParseDMS(s); err == nil {
+			t.Errorf("ParseDMS(%q) = %+v, want error", s, got)
+		}
+	}
+}
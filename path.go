@@ -0,0 +1,152 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"sort"
+)
+
+// Great-circle paths
+
+// PathPoints returns n points evenly spaced along the great circle from a to
+// b, including both ends. It returns nil when n is less than 2.
+func PathPoints(a, b Coordinate, n int) []Coordinate {
+	if n < 2 {
+		return nil
+	}
+	va, vb := unitVector(a), unitVector(b)
+	delta := math.Acos(clamp1(va.dot(vb)))
+	points := make([]Coordinate, n)
+	points[0], points[n-1] = a, b
+	for i := 1; i < n-1; i++ {
+		if delta == 0 {
+			points[i] = a
+			continue
+		}
+		f := float64(i) / float64(n-1)
+		wa, wb := math.Sin((1-f)*delta)/math.Sin(delta), math.Sin(f*delta)/math.Sin(delta)
+		points[i] = coordinateFromVector(va.scale(wa).add(vb.scale(wb)))
+	}
+	return points
+}
+
+// ParallelCrossings returns the points, in path order, where the great-circle
+// path from a to b crosses the parallel of latitude lat in degrees. A path
+// crosses a parallel at most twice. Ends lying on the parallel are not
+// reported, so consecutive legs do not report a shared point twice.
+func ParallelCrossings(a, b Coordinate, lat float64) []Coordinate {
+	va, u, delta, ok := pathFrame(a, b)
+	if !ok {
+		return nil
+	}
+	// Along the path z(θ) = va.z cos θ + u.z sin θ = r cos(θ - α).
+	r, alpha := math.Hypot(va[2], u[2]), math.Atan2(u[2], va[2])
+	z := math.Sin(lat * degToRad)
+	if r == 0 || math.Abs(z) > r {
+		return nil
+	}
+	spread := math.Acos(z / r)
+	var crossings []float64
+	for _, theta := range []float64{alpha - spread, alpha + spread} {
+		theta = math.Mod(theta+2*math.Pi, 2*math.Pi)
+		if theta > 0 && theta < delta && !containsAngle(crossings, theta) {
+			crossings = append(crossings, theta)
+		}
+	}
+	sort.Float64s(crossings)
+	result := make([]Coordinate, len(crossings))
+	for i, theta := range crossings {
+		p := va.scale(math.Cos(theta)).add(u.scale(math.Sin(theta)))
+		result[i] = coordinateFromDecimal(lat, math.Atan2(p[1], p[0])/degToRad)
+	}
+	return result
+}
+
+// EquatorCrossings returns the points where the great-circle path from a to
+// b crosses the equator.
+func EquatorCrossings(a, b Coordinate) []Coordinate {
+	return ParallelCrossings(a, b, 0)
+}
+
+// MeridianCrossings returns the points, in path order, where the great-circle
+// path from a to b crosses the meridian of longitude lon in degrees. Ends
+// lying on the meridian are not reported.
+func MeridianCrossings(a, b Coordinate, lon float64) []Coordinate {
+	va, u, delta, ok := pathFrame(a, b)
+	if !ok {
+		return nil
+	}
+	// The meridian half-plane has normal m and points toward east.
+	m := vec3{-math.Sin(lon * degToRad), math.Cos(lon * degToRad), 0}
+	east := vec3{math.Cos(lon * degToRad), math.Sin(lon * degToRad), 0}
+	theta0 := math.Atan2(-va.dot(m), u.dot(m))
+	var result []Coordinate
+	for _, theta := range []float64{theta0, theta0 + math.Pi, theta0 + 2*math.Pi} {
+		if theta <= 0 || theta >= delta {
+			continue
+		}
+		p := va.scale(math.Cos(theta)).add(u.scale(math.Sin(theta)))
+		if p.dot(east) > 0 {
+			result = append(result, coordinateFromDecimal(math.Atan2(p[2], math.Hypot(p[0], p[1]))/degToRad, lon))
+		}
+	}
+	return result
+}
+
+// AntimeridianCrossings returns the points where the great-circle path from a
+// to b crosses the 180th meridian.
+func AntimeridianCrossings(a, b Coordinate) []Coordinate {
+	return MeridianCrossings(a, b, 180)
+}
+
+// pathFrame returns the unit vector of a, the unit vector perpendicular to it
+// in the direction of b and the angular length of the path from a to b. It
+// reports false when a and b coincide or are antipodal.
+func pathFrame(a, b Coordinate) (va, u vec3, delta float64, ok bool) {
+	va, vb := unitVector(a), unitVector(b)
+	n := va.cross(vb)
+	norm := n.norm()
+	if norm < 1e-15 {
+		return va, u, 0, false
+	}
+	u = n.cross(va).scale(1 / norm)
+	return va, u, math.Atan2(norm, va.dot(vb)), true
+}
+
+// containsAngle reports whether angles holds theta up to rounding.
+func containsAngle(angles []float64, theta float64) bool {
+	for _, a := range angles {
+		if math.Abs(a-theta) < 1e-12 {
+			return true
+		}
+	}
+	return false
+}
+
+// Vector arithmetic
+
+func (v vec3) add(w vec3) vec3 { return vec3{v[0] + w[0], v[1] + w[1], v[2] + w[2]} }
+
+func (v vec3) scale(f float64) vec3 { return vec3{v[0] * f, v[1] * f, v[2] * f} }
+
+func (v vec3) dot(w vec3) float64 { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] }
+
+func (v vec3) cross(w vec3) vec3 {
+	return vec3{v[1]*w[2] - v[2]*w[1], v[2]*w[0] - v[0]*w[2], v[0]*w[1] - v[1]*w[0]}
+}
+
+func (v vec3) norm() float64 { return math.Sqrt(v.dot(v)) }
@@ -0,0 +1,160 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"time"
+)
+
+// Pipelines
+
+// PipelineState is the value passed through the stages of a Pipeline.
+type PipelineState struct {
+	Input      string     // Text read by a parse stage.
+	Notation   Notation   // Notation of the parsed text.
+	Coordinate Coordinate // Coordinate being processed.
+	Output     string     // Text produced by a format stage.
+	// Record receives the transformations applied by the stages when set,
+	// as by Pipeline.RunAudited.
+	Record *ConversionRecord
+}
+
+// record adds a step to the audit record of the state, if any.
+func (s *PipelineState) record(stage, detail string) {
+	if s.Record != nil {
+		s.Record.add(s, stage, detail)
+	}
+}
+
+// Stage is a step of a Pipeline.
+type Stage interface {
+	Process(s *PipelineState) error
+}
+
+// StageFunc adapts a function to the Stage interface.
+type StageFunc func(s *PipelineState) error
+
+// Process calls f(s).
+func (f StageFunc) Process(s *PipelineState) error {
+	return f(s)
+}
+
+// Pipeline is a sequence of stages applied in order, such as
+// Parse → DatumShift → Round → Format.
+type Pipeline []Stage
+
+// NewPipeline returns a pipeline of the given stages.
+func NewPipeline(stages ...Stage) Pipeline {
+	return Pipeline(stages)
+}
+
+// Then returns a pipeline with the stages of p followed by more stages.
+func (p Pipeline) Then(stages ...Stage) Pipeline {
+	return append(append(Pipeline(nil), p...), stages...)
+}
+
+// Process runs every stage of the pipeline, stopping at the first error.
+// It makes a Pipeline usable as a stage of another pipeline.
+func (p Pipeline) Process(s *PipelineState) error {
+	for i, stage := range p {
+		if err := stage.Process(s); err != nil {
+			return fmt.Errorf("Stage %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Run runs the pipeline on an input string and returns its output. It is
+// observed as a "pipeline" conversion; parse failures are counted once, by
+// the parse stage.
+func (p Pipeline) Run(input string) (string, error) {
+	defer observe("pipeline", time.Now(), nil)
+	s := PipelineState{Input: input}
+	if err := p.Process(&s); err != nil {
+		return "", err
+	}
+	return s.Output, nil
+}
+
+// RunCoordinate runs the pipeline on a coordinate and returns the processed
+// coordinate and output, observed like Run.
+func (p Pipeline) RunCoordinate(c Coordinate) (Coordinate, string, error) {
+	defer observe("pipeline", time.Now(), nil)
+	s := PipelineState{Coordinate: c}
+	if err := p.Process(&s); err != nil {
+		return Coordinate{}, "", err
+	}
+	return s.Coordinate, s.Output, nil
+}
+
+// ParseStage returns a stage that parses the input in any notation accepted
+// by ParseAny.
+func ParseStage() Stage {
+	return StageFunc(func(s *PipelineState) error {
+		c, notation, err := ParseAny(s.Input)
+		if err != nil {
+			return err
+		}
+		s.Coordinate, s.Notation = c, notation
+		detail := notation.String() + " notation"
+		if policy := CurrentRangePolicy(); policy != RangeError {
+			detail += ", range policy " + policy.String()
+		}
+		s.record("parse", detail)
+		return nil
+	})
+}
+
+// DatumShiftStage returns a stage that converts the coordinate between datums.
+func DatumShiftStage(from, to *Datum) Stage {
+	return StageFunc(func(s *PipelineState) error {
+		s.Coordinate = ShiftDatum(s.Coordinate, from, to)
+		s.record("datum-shift", from.Name+" to "+to.Name)
+		return nil
+	})
+}
+
+// RoundStage returns a stage that rounds the seconds of both axes to the
+// given number of decimals.
+func RoundStage(decimals int, mode RoundingMode) Stage {
+	return StageFunc(func(s *PipelineState) error {
+		s.Coordinate.Latitude.RoundSeconds(decimals, mode)
+		s.Coordinate.Longitude.RoundSeconds(decimals, mode)
+		s.record("round", fmt.Sprintf("%d decimals of seconds, %s", decimals, mode))
+		return nil
+	})
+}
+
+// ValidateStage returns a stage that fails on invalid coordinates.
+func ValidateStage() Stage {
+	return StageFunc(func(s *PipelineState) error {
+		if err := s.Coordinate.Validate(); err != nil {
+			return err
+		}
+		s.record("validate", "valid")
+		return nil
+	})
+}
+
+// FormatStage returns a stage that formats the coordinate with opts.
+func FormatStage(opts FormatOptions) Stage {
+	return StageFunc(func(s *PipelineState) error {
+		s.Output = s.Coordinate.Format(opts)
+		s.record("format", fmt.Sprintf("%d decimals, %s", opts.Precision, opts.Rounding))
+		return nil
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "math"
+
+// Polygon generation
+
+// CirclePolygon returns a closed ring of segments+1 points approximating the
+// circle of radius meters around center, as for range rings. The points are
+// spaced evenly along the circle clockwise from true north, and the last
+// point repeats the first as KML and GeoJSON rings require. It returns nil
+// when segments is less than 3.
+func CirclePolygon(center Coordinate, radius float64, segments int) []Coordinate {
+	if segments < 3 {
+		return nil
+	}
+	ring := make([]Coordinate, segments+1)
+	for i := 0; i < segments; i++ {
+		ring[i] = Destination(center, 360*float64(i)/float64(segments), radius)
+	}
+	ring[segments] = ring[0]
+	return ring
+}
+
+// sectorStep is the largest angle in degrees between two arc points of a
+// sector polygon.
+const sectorStep = 2.0
+
+// SectorPolygon returns a closed ring outlining the sector of radius meters
+// around center that sweeps clockwise from startBearing to endBearing, in
+// degrees from true north, as for antenna coverage. The ring starts and ends
+// at center and has arc points at most 2° apart. Equal bearings give a full
+// circle.
+func SectorPolygon(center Coordinate, radius, startBearing, endBearing float64) []Coordinate {
+	sweep := Normalize360(endBearing - startBearing)
+	if sweep == 0 {
+		sweep = 360
+	}
+	steps := int(math.Ceil(sweep / sectorStep))
+	ring := make([]Coordinate, 0, steps+3)
+	ring = append(ring, center)
+	for i := 0; i <= steps; i++ {
+		ring = append(ring, Destination(center, startBearing+sweep*float64(i)/float64(steps), radius))
+	}
+	return append(ring, center)
+}
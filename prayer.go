@@ -0,0 +1,85 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"time"
+)
+
+// Prayer times
+
+// PrayerMethod holds the conventions of a prayer time calculation method.
+type PrayerMethod struct {
+	Name         string
+	Fajr         float64       // Depression of the Sun below the horizon at Fajr, in degrees.
+	Isha         float64       // Depression of the Sun at Isha in degrees, unused with IshaInterval.
+	IshaInterval time.Duration // Time from Maghrib to Isha, when not given by a depression.
+	Maghrib      float64       // Depression of the Sun at Maghrib in degrees, sunset when zero.
+	AsrShadow    float64       // Shadow length factor of Asr: 1 (standard) or 2 (Hanafi).
+}
+
+// Common prayer time calculation methods.
+var (
+	PrayerMethodMWL     = PrayerMethod{Name: "Muslim World League", Fajr: 18, Isha: 17, AsrShadow: 1}
+	PrayerMethodISNA    = PrayerMethod{Name: "Islamic Society of North America", Fajr: 15, Isha: 15, AsrShadow: 1}
+	PrayerMethodEgypt   = PrayerMethod{Name: "Egyptian General Authority of Survey", Fajr: 19.5, Isha: 17.5, AsrShadow: 1}
+	PrayerMethodMakkah  = PrayerMethod{Name: "Umm al-Qura University, Makkah", Fajr: 18.5, IshaInterval: 90 * time.Minute, AsrShadow: 1}
+	PrayerMethodKarachi = PrayerMethod{Name: "University of Islamic Sciences, Karachi", Fajr: 18, Isha: 18, AsrShadow: 1}
+	PrayerMethodTehran  = PrayerMethod{Name: "Institute of Geophysics, University of Tehran", Fajr: 17.7, Isha: 14, Maghrib: 4.5, AsrShadow: 1}
+	PrayerMethodJafari  = PrayerMethod{Name: "Shia Ithna-Ashari, Leva Institute, Qum", Fajr: 16, Isha: 14, Maghrib: 4, AsrShadow: 1}
+)
+
+// PrayerTimes holds the prayer times of a day, with sunrise and sunset. Times
+// are zero when the Sun does not reach the required elevation that day, as
+// happens at high latitudes.
+type PrayerTimes struct {
+	Fajr, Sunrise, Dhuhr, Asr, Sunset, Maghrib, Isha time.Time
+}
+
+// NewPrayerTimes returns the prayer times at c on the calendar day of date,
+// in the location of date.
+func NewPrayerTimes(c Coordinate, date time.Time, method *PrayerMethod) PrayerTimes {
+	noon := solarNoon(c, date)
+	at := func(elevation float64, morning bool) time.Time {
+		t, _ := sunElevationTime(c, noon, elevation, morning)
+		return t
+	}
+	p := PrayerTimes{
+		Fajr:    at(-method.Fajr, true),
+		Sunrise: at(sunriseElevation, true),
+		Dhuhr:   noon,
+		Sunset:  at(sunriseElevation, false),
+	}
+	_, dec, _ := sunEquatorial(noon)
+	shadow := method.AsrShadow
+	if shadow <= 0 {
+		shadow = 1
+	}
+	zenith := math.Abs(signedDecimal(c.Latitude)-dec) * degToRad
+	p.Asr = at(math.Atan(1/(shadow+math.Tan(zenith)))/degToRad, false)
+	p.Maghrib = p.Sunset
+	if method.Maghrib > 0 {
+		p.Maghrib = at(-method.Maghrib, false)
+	}
+	switch {
+	case method.IshaInterval > 0 && !p.Maghrib.IsZero():
+		p.Isha = p.Maghrib.Add(method.IshaInterval)
+	case method.IshaInterval == 0:
+		p.Isha = at(-method.Isha, false)
+	}
+	return p
+}
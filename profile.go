@@ -0,0 +1,242 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Conversion profiles
+
+// Profile is a named set of conversion settings, so that every tool of an
+// organization reads and writes coordinates the same way. Profiles are
+// stored as a JSON object mapping profile names to their settings:
+//
+//	{
+//	  "survey": {"output": "dms", "precision": 3, "output_datum": "ED50"},
+//	  "radio":  {"output": "dms", "style": "phonetic", "locale": "en"}
+//	}
+type Profile struct {
+	Name        string `json:"-"`
+	Input       string `json:"input,omitempty"`        // Required input notation (a Notation name), any when empty.
+	Output      string `json:"output,omitempty"`       // Output notation, see Format; "dms" when empty.
+	Precision   *int   `json:"precision,omitempty"`    // Decimals (or geohash length, Maidenhead pairs), notation default when nil.
+	Style       string `json:"style,omitempty"`        // DMS style: symbols, units, words, phonetic or ascii.
+	Locale      Locale `json:"locale,omitempty"`       // Locale of DMS output.
+	InputDatum  string `json:"input_datum,omitempty"`  // Datum of the input, WGS 84 when empty.
+	OutputDatum string `json:"output_datum,omitempty"` // Datum of the output, WGS 84 when empty.
+}
+
+// formatStyleNames maps the style names of profiles to format styles.
+var formatStyleNames = map[string]FormatStyle{
+	"symbols":  StyleSymbols,
+	"units":    StyleUnits,
+	"words":    StyleWords,
+	"phonetic": StylePhonetic,
+	"ascii":    StyleASCII,
+}
+
+// LoadProfiles reads the profiles of a JSON profile file. The profiles are
+// not validated, so that one bad profile does not make the others unusable.
+func LoadProfiles(r io.Reader) (map[string]Profile, error) {
+	var profiles map[string]Profile
+	if err := json.NewDecoder(r).Decode(&profiles); err != nil {
+		return nil, err
+	}
+	for name, p := range profiles {
+		p.Name = name
+		profiles[name] = p
+	}
+	return profiles, nil
+}
+
+// LoadProfile reads and validates the named profile of a JSON profile file.
+func LoadProfile(path, name string) (Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	defer f.Close()
+	profiles, err := LoadProfiles(f)
+	if err != nil {
+		return Profile{}, err
+	}
+	p, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("Unknown profile %q", name)
+	}
+	if err := p.Validate(); err != nil {
+		return Profile{}, fmt.Errorf("Profile %q: %w", name, err)
+	}
+	return p, nil
+}
+
+// Validate checks that the settings of the profile are known.
+func (p *Profile) Validate() error {
+	if p.Input != "" {
+		if _, err := ParseNotation(p.Input); err != nil {
+			return err
+		}
+	}
+	if _, ok := profileOutputs[p.output()]; !ok {
+		return fmt.Errorf("Unknown output notation %q", p.Output)
+	}
+	if _, ok := formatStyleNames[p.style()]; !ok {
+		return fmt.Errorf("Unknown style %q", p.Style)
+	}
+	for _, name := range []string{p.InputDatum, p.OutputDatum} {
+		if _, err := p.datum(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pipeline returns the pipeline applying the profile: parse, check the input
+// notation, shift the datum and format.
+func (p *Profile) Pipeline() (Pipeline, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	stages := Pipeline{ParseStage()}
+	if p.Input != "" {
+		want, _ := ParseNotation(p.Input)
+		stages = append(stages, StageFunc(func(s *PipelineState) error {
+			if s.Notation != want {
+				return fmt.Errorf("Expected %s notation, got %s", want, s.Notation)
+			}
+			return nil
+		}))
+	}
+	from, _ := p.datum(p.InputDatum)
+	to, _ := p.datum(p.OutputDatum)
+	if from != to {
+		stages = append(stages, DatumShiftStage(from, to))
+	}
+	return append(stages, StageFunc(func(s *PipelineState) error {
+		out, err := p.Format(s.Coordinate)
+		if err != nil {
+			return err
+		}
+		s.Output = out
+		s.record("format", fmt.Sprintf("%s notation of profile %q", p.output(), p.Name))
+		return nil
+	})), nil
+}
+
+// Convert parses a coordinate in the input notation and datum of the profile
+// and returns it in its output notation and datum.
+func (p *Profile) Convert(input string) (string, error) {
+	pipeline, err := p.Pipeline()
+	if err != nil {
+		return "", err
+	}
+	return pipeline.Run(input)
+}
+
+// profileOutputs formats a coordinate in an output notation of profiles,
+// given the precision, or -1 for the notation default.
+var profileOutputs = map[string]func(p *Profile, c *Coordinate, precision int) (string, error){
+	"dms": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		opts := DefaultFormatOptions()
+		opts.Style, opts.Locale = formatStyleNames[p.style()], p.Locale
+		if precision >= 0 {
+			opts.Precision = precision
+		}
+		return c.Format(opts), nil
+	},
+	"ddm": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		return c.Latitude.StringDDM() + " " + c.Longitude.StringDDM(), nil
+	},
+	"decimal": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		if precision < 0 {
+			precision = 6
+		}
+		lat, lon := c.Decimal()
+		return fmt.Sprintf("%.*f, %.*f", precision, lat, precision, lon), nil
+	},
+	"compact": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		return c.StringCompact(), nil
+	},
+	"utm": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		u, err := c.UTM()
+		if err != nil {
+			return "", err
+		}
+		return u.String(), nil
+	},
+	"mgrs": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		return c.MGRS()
+	},
+	"geohash": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		if precision < 0 {
+			precision = describeGeohashLength
+		}
+		return c.Geohash(precision), nil
+	},
+	"maidenhead": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		if precision < 0 {
+			precision = describeMaidenheadPairs
+		}
+		return c.Maidenhead(precision), nil
+	},
+	"geo-uri": func(p *Profile, c *Coordinate, precision int) (string, error) {
+		return c.GeoURI(), nil
+	},
+}
+
+// Format returns a coordinate in the output notation of the profile: dms,
+// ddm, decimal, compact, utm, mgrs, geohash, maidenhead or geo-uri. The
+// datum of the coordinate is not changed.
+func (p *Profile) Format(c Coordinate) (string, error) {
+	format, ok := profileOutputs[p.output()]
+	if !ok {
+		return "", fmt.Errorf("Unknown output notation %q", p.Output)
+	}
+	precision := -1
+	if p.Precision != nil {
+		precision = *p.Precision
+	}
+	return format(p, &c, precision)
+}
+
+// output returns the output notation of the profile.
+func (p *Profile) output() string {
+	if p.Output == "" {
+		return "dms"
+	}
+	return strings.ToLower(p.Output)
+}
+
+// style returns the DMS style of the profile.
+func (p *Profile) style() string {
+	if p.Style == "" {
+		return "symbols"
+	}
+	return strings.ToLower(p.Style)
+}
+
+// datum returns the datum with the given name, WGS 84 when empty.
+func (p *Profile) datum(name string) (*Datum, error) {
+	if name == "" {
+		return &DatumWGS84, nil
+	}
+	return DatumByName(name)
+}
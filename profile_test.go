@@ -0,0 +1,117 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testProfiles is the profile file used by the profile tests.
+const testProfiles = `{
+  "survey": {"output": "dms", "precision": 3, "output_datum": "ED50"},
+  "radio":  {"output": "dms", "style": "phonetic", "locale": "en"},
+  "grid":   {"input": "decimal", "output": "mgrs"},
+  "hash":   {"output": "geohash", "precision": 5},
+  "dec":    {"output": "decimal", "precision": 3},
+  "braille": {"output": "braille"},
+  "cursive": {"style": "cursive"},
+  "mars":   {"input_datum": "Mars 2000"},
+  "fuzzy":  {"input": "fuzzy"}
+}`
+
+func TestProfileConvert(t *testing.T) {
+	profiles, err := LoadProfiles(strings.NewReader(testProfiles))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const decimal, dms = `40.446195, -79.948862`, `40°26'46.30" N 79°56'55.90" W`
+	tests := []struct {
+		profile, input, want string
+	}{
+		{"radio", dms, "Fower Zero degrees Two Six minutes Fower Six Decimal Tree Zero seconds North " +
+			"Zero Seven Niner degrees Fife Six minutes Fife Fife Decimal Niner Zero seconds West"},
+		{"grid", decimal, "17T NE 89138 77812"},
+		{"hash", dms, "dppnh"},
+		{"dec", dms, "40.446, -79.949"},
+	}
+	for _, tt := range tests {
+		p := profiles[tt.profile]
+		if p.Name != tt.profile {
+			t.Errorf("profile %q has name %q", tt.profile, p.Name)
+		}
+		if got, err := p.Convert(tt.input); err != nil || got != tt.want {
+			t.Errorf("%s.Convert(%q) = %q, %v, want %q", tt.profile, tt.input, got, err, tt.want)
+		}
+	}
+
+	survey := profiles["survey"]
+	got, err := survey.Convert(decimal)
+	if err != nil {
+		t.Fatalf("survey.Convert(%q) error: %v", decimal, err)
+	}
+	shifted, err := ParseCoordinate(got)
+	if err != nil {
+		t.Fatalf("ParseCoordinate(%q) error: %v", got, err)
+	}
+	if d := Distance(shifted, coordinateFromDecimal(40.446195, -79.948862)); d < 1 || d > 500 {
+		t.Errorf("survey.Convert(%q) = %q, %v m from the input, want a datum shift", decimal, got, d)
+	}
+
+	grid := profiles["grid"]
+	if _, err := grid.Convert(dms); err == nil {
+		t.Errorf("grid.Convert(%q) succeeded, want notation error", dms)
+	}
+}
+
+func TestProfileValidate(t *testing.T) {
+	profiles, err := LoadProfiles(strings.NewReader(testProfiles))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, p := range profiles {
+		invalid := name == "braille" || name == "cursive" || name == "mars" || name == "fuzzy"
+		if err := p.Validate(); (err != nil) != invalid {
+			t.Errorf("%s.Validate() = %v, want error %v", name, err, invalid)
+		}
+		if _, err := p.Convert("40.5, -79.5"); invalid && err == nil {
+			t.Errorf("%s.Convert succeeded, want error", name)
+		}
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	if err := os.WriteFile(path, []byte(testProfiles), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := LoadProfile(path, "hash"); err != nil || p.Output != "geohash" || *p.Precision != 5 {
+		t.Errorf("LoadProfile(hash) = %+v, %v", p, err)
+	}
+	for _, name := range []string{"braille", "missing"} {
+		if _, err := LoadProfile(path, name); err == nil {
+			t.Errorf("LoadProfile(%q) succeeded, want error", name)
+		}
+	}
+	if _, err := LoadProfile(filepath.Join(t.TempDir(), "none.json"), "hash"); err == nil {
+		t.Error("LoadProfile of a missing file succeeded, want error")
+	}
+	if _, err := LoadProfiles(strings.NewReader(`{"a": [1]}`)); err == nil {
+		t.Error("LoadProfiles of malformed JSON succeeded, want error")
+	}
+}
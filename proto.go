@@ -0,0 +1,199 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Protocol Buffers wire format converters for the messages defined in
+// proto/dms.proto. They let services exchange DMS values with any generated
+// protobuf code without this package depending on a protobuf runtime.
+
+// Protobuf wire types used by the DMS messages.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// MarshalProto encodes the DMS as a dms.DMS protobuf message.
+func (d DMS) MarshalProto() ([]byte, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return appendDMSProto(nil, d), nil
+}
+
+// UnmarshalProto decodes a dms.DMS protobuf message and validates the result.
+func (d *DMS) UnmarshalProto(data []byte) error {
+	var decoded DMS
+	if err := decodeDMSProto(data, &decoded); err != nil {
+		return err
+	}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// MarshalProto encodes the coordinate as a dms.Coordinate protobuf message.
+func (c Coordinate) MarshalProto() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	var buf []byte
+	buf = appendProtoBytes(buf, 1, appendDMSProto(nil, c.Latitude))
+	buf = appendProtoBytes(buf, 2, appendDMSProto(nil, c.Longitude))
+	if c.Accuracy != 0 {
+		buf = appendProtoFixed64(buf, 3, math.Float64bits(c.Accuracy))
+	}
+	return buf, nil
+}
+
+// UnmarshalProto decodes a dms.Coordinate protobuf message and validates the result.
+func (c *Coordinate) UnmarshalProto(data []byte) error {
+	var decoded Coordinate
+	err := walkProto(data, func(field, wireType int, value uint64, payload []byte) error {
+		switch {
+		case field == 1 && wireType == wireBytes:
+			return decodeDMSProto(payload, &decoded.Latitude)
+		case field == 2 && wireType == wireBytes:
+			return decodeDMSProto(payload, &decoded.Longitude)
+		case field == 3 && wireType == wireFixed64:
+			decoded.Accuracy = math.Float64frombits(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+	*c = decoded
+	return nil
+}
+
+// appendDMSProto appends the fields of a dms.DMS message to buf.
+func appendDMSProto(buf []byte, d DMS) []byte {
+	if d.Degree != 0 {
+		buf = appendProtoVarint(buf, 1, uint64(d.Degree))
+	}
+	if d.Minutes != 0 {
+		buf = appendProtoVarint(buf, 2, uint64(d.Minutes))
+	}
+	if d.Seconds != 0 {
+		buf = appendProtoFixed64(buf, 3, math.Float64bits(d.Seconds))
+	}
+	if d.Direction != "" {
+		buf = appendProtoBytes(buf, 4, []byte(d.Direction))
+	}
+	return buf
+}
+
+// decodeDMSProto decodes the fields of a dms.DMS message into d. Degrees and
+// minutes are uint32 fields; larger varints are rejected rather than
+// truncated.
+func decodeDMSProto(data []byte, d *DMS) error {
+	return walkProto(data, func(field, wireType int, value uint64, payload []byte) error {
+		switch {
+		case (field == 1 || field == 2) && wireType == wireVarint && value > math.MaxUint32:
+			return errors.New("Protobuf uint32 field out of range")
+		case field == 1 && wireType == wireVarint:
+			d.Degree = uint(value)
+		case field == 2 && wireType == wireVarint:
+			d.Minutes = uint(value)
+		case field == 3 && wireType == wireFixed64:
+			d.Seconds = math.Float64frombits(value)
+		case field == 4 && wireType == wireBytes:
+			d.Direction = string(payload)
+		}
+		return nil
+	})
+}
+
+// appendProtoVarint appends a varint field to buf.
+func appendProtoVarint(buf []byte, field int, value uint64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(buf, value)
+}
+
+// appendProtoFixed64 appends a 64-bit fixed-size field to buf.
+func appendProtoFixed64(buf []byte, field int, value uint64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|wireFixed64)
+	return binary.LittleEndian.AppendUint64(buf, value)
+}
+
+// appendProtoBytes appends a length-delimited field to buf.
+func appendProtoBytes(buf []byte, field int, payload []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|wireBytes)
+	buf = binary.AppendUvarint(buf, uint64(len(payload)))
+	return append(buf, payload...)
+}
+
+// walkProto calls fn for every field in a protobuf message. Fixed-size and
+// varint values are passed in value, length-delimited values in payload.
+func walkProto(data []byte, fn func(field, wireType int, value uint64, payload []byte) error) error {
+	errTruncated := errors.New("Truncated protobuf message")
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		field, wireType := int(key>>3), int(key&7)
+		var value uint64
+		var payload []byte
+		switch wireType {
+		case wireVarint:
+			value, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			value = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			value = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errTruncated
+			}
+			payload = data[n : n+int(length)]
+			data = data[n+int(length):]
+		default:
+			return errors.New("Unsupported protobuf wire type")
+		}
+		if err := fn(field, wireType, value, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
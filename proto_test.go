@@ -0,0 +1,89 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestUnmarshalProtoRejectsOverflow(t *testing.T) {
+	data := binary.AppendUvarint(nil, 1<<3|wireVarint)
+	data = binary.AppendUvarint(data, 1<<32+40)
+	data = appendProtoBytes(data, 4, []byte("N"))
+	var d DMS
+	if err := d.UnmarshalProto(data); err == nil {
+		t.Errorf("UnmarshalProto(degree 2^32+40) = %v, want error", d)
+	}
+	var c Coordinate
+	if err := c.UnmarshalProto(appendProtoBytes(nil, 1, data)); err == nil {
+		t.Errorf("Coordinate.UnmarshalProto(degree 2^32+40) = %v, want error", c)
+	}
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	tests := []Coordinate{
+		coordinateFromDecimal(40.446195, -79.948862),
+		coordinateFromDecimal(-33.8568, 151.2153),
+		coordinateFromDecimal(0, 0),
+		coordinateFromDecimal(-90, 180),
+	}
+	tests[1].Accuracy = 12.5
+	for _, want := range tests {
+		data, err := want.MarshalProto()
+		if err != nil {
+			t.Fatalf("MarshalProto(%v): %v", want, err)
+		}
+		var got Coordinate
+		if err := got.UnmarshalProto(data); err != nil {
+			t.Fatalf("UnmarshalProto(%v): %v", want, err)
+		}
+		if got != want {
+			t.Errorf("round trip of %+v gave %+v", want, got)
+		}
+		data, err = want.Latitude.MarshalProto()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var d DMS
+		if err := d.UnmarshalProto(data); err != nil || d != want.Latitude {
+			t.Errorf("DMS round trip of %+v gave %+v, %v", want.Latitude, d, err)
+		}
+	}
+}
+
+func TestUnmarshalProtoMalformed(t *testing.T) {
+	valid, _ := (&DMS{Degree: 40, Minutes: 26, Seconds: 46.3, Direction: "N"}).MarshalProto()
+	tests := map[string][]byte{
+		"truncated key":     {0x80},
+		"truncated varint":  {1 << 3, 0x80},
+		"truncated fixed64": {3<<3 | wireFixed64, 1, 2, 3},
+		"truncated bytes":   {4<<3 | wireBytes, 5, 'N'},
+		"bad wire type":     {1<<3 | 3},
+		"truncated message": valid[:len(valid)-1],
+		"missing direction": valid[:len(valid)-3],
+		"minutes 60":        appendProtoVarint(appendProtoBytes(nil, 4, []byte("N")), 2, 60),
+	}
+	for name, data := range tests {
+		var d DMS
+		if err := d.UnmarshalProto(data); err == nil {
+			t.Errorf("%s: UnmarshalProto = %+v, want error", name, d)
+		}
+	}
+	if _, err := (DMS{Degree: 95, Direction: "N"}).MarshalProto(); err == nil {
+		t.Error("MarshalProto accepted 95° N")
+	}
+}
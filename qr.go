@@ -0,0 +1,50 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "strings"
+
+// QRFormat selects the string format encoded in a coordinate QR code.
+type QRFormat int
+
+const (
+	QRGeoURI     QRFormat = iota // RFC 5870 geo URI, opened by most phone map apps.
+	QRGoogleMaps                 // Google Maps link, for scanners that only open web links.
+	QRPlainDMS                   // Plain DMS text for display.
+)
+
+// QRPayload returns the string to encode in a QR code for the coordinate.
+func QRPayload(c Coordinate, format QRFormat) string {
+	switch format {
+	case QRGoogleMaps:
+		return MapsURL(c, GoogleMaps, 0)
+	case QRPlainDMS:
+		return c.String()
+	default:
+		return c.GeoURI()
+	}
+}
+
+// ParseQRPayload extracts the coordinate from a scanned QR payload in any of
+// the QRFormat formats, or from a link of another supported map provider.
+func ParseQRPayload(payload string) (Coordinate, error) {
+	payload = strings.TrimSpace(payload)
+	lower := strings.ToLower(payload)
+	if strings.HasPrefix(lower, "geo:") || strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") {
+		return ParseMapsURL(payload)
+	}
+	return ParseCoordinate(payload)
+}
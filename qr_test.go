@@ -0,0 +1,45 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "testing"
+
+func TestQRPayloadRoundTrip(t *testing.T) {
+	for _, c := range []Coordinate{
+		coordinateFromDecimal(40.446195, -79.948862),
+		coordinateFromDecimal(-33.8568, 151.2153),
+	} {
+		for _, format := range []QRFormat{QRGeoURI, QRGoogleMaps, QRPlainDMS} {
+			payload := QRPayload(c, format)
+			got, err := ParseQRPayload(" " + payload + "\n")
+			if err != nil {
+				t.Errorf("ParseQRPayload(%q): %v", payload, err)
+				continue
+			}
+			if d := Distance(got, c); d > 0.5 {
+				t.Errorf("ParseQRPayload(%q) is %g m from %v", payload, d, c)
+			}
+		}
+	}
+}
+
+func TestParseQRPayloadMalformed(t *testing.T) {
+	for _, payload := range []string{"", "hello", "geo:", "https://example.com/", `40°26'46" N`} {
+		if c, err := ParseQRPayload(payload); err == nil {
+			t.Errorf("ParseQRPayload(%q) = %v, want error", payload, c)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+)
+
+// Range policy
+
+// RangePolicy selects how out-of-range latitudes and longitudes are handled
+// by the constructors and parsers.
+type RangePolicy int32
+
+const (
+	RangeError RangePolicy = iota // Reject out-of-range values. The default.
+	RangeClamp                    // Clamp values to ±90° and ±180°.
+	RangeWrap                     // Wrap longitudes around the globe and latitudes over the poles.
+)
+
+// rangePolicyNames maps range policies to their names.
+var rangePolicyNames = map[RangePolicy]string{
+	RangeError: "error",
+	RangeClamp: "clamp",
+	RangeWrap:  "wrap",
+}
+
+// String returns the name of the policy, e.g. "clamp".
+func (p RangePolicy) String() string {
+	if name, ok := rangePolicyNames[p]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseRangePolicy returns the policy with the given name, as returned by
+// RangePolicy.String.
+func ParseRangePolicy(name string) (RangePolicy, error) {
+	for p, s := range rangePolicyNames {
+		if strings.EqualFold(s, name) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown range policy %q", name)
+}
+
+// rangePolicy holds the package-wide policy set by SetRangePolicy.
+var rangePolicy atomic.Int32
+
+// SetRangePolicy sets the policy applied by NewDMS, NewCoordinate,
+// NewLatitude, NewLongitude, ParseDMS and ParseCoordinate. Validate always
+// rejects out-of-range values regardless of the policy.
+func SetRangePolicy(p RangePolicy) {
+	rangePolicy.Store(int32(p))
+}
+
+// CurrentRangePolicy returns the policy set by SetRangePolicy.
+func CurrentRangePolicy() RangePolicy {
+	return RangePolicy(rangePolicy.Load())
+}
+
+// Apply brings a signed decimal latitude and longitude into range according
+// to the policy. Wrapping a latitude over a pole, e.g. 95° N, gives 85° N on
+// the opposite meridian; after an even number of pole crossings, as for 300°
+// or 360°, the meridian is kept. NaN values are always rejected.
+func (p RangePolicy) Apply(lat, lon float64) (float64, float64, error) {
+	if p == RangeWrap && math.Abs(lat) > 90 && !math.IsNaN(lon) && !math.IsInf(lat, 0) {
+		if math.Abs(Normalize180(lat)) > 90 {
+			lon += 180
+		}
+		lat = wrapLatitude(lat)
+	}
+	lat, err := p.applyAxis(lat, AxisLatitude)
+	if err != nil {
+		return 0, 0, err
+	}
+	lon, err = p.applyAxis(lon, AxisLongitude)
+	if err != nil {
+		return 0, 0, err
+	}
+	return lat, lon, nil
+}
+
+// applyAxis brings a signed decimal value of an axis into range according to
+// the policy. Latitudes wrapped over a pole are reflected back.
+func (p RangePolicy) applyAxis(value float64, axis Axis) (float64, error) {
+	limit := axis.limit()
+	switch {
+	case math.IsNaN(value) || p == RangeError || math.Abs(value) <= limit:
+		return value, validateDecimal(value, axis)
+	case p == RangeClamp || math.IsInf(value, 0):
+		return math.Max(-limit, math.Min(limit, value)), nil
+	case p == RangeWrap && axis == AxisLatitude:
+		return wrapLatitude(value), nil
+	case p == RangeWrap:
+		return Normalize180(value), nil
+	}
+	return 0, errors.New("Invalid range policy")
+}
+
+// wrapLatitude reflects a latitude beyond a pole back into ±90°.
+func wrapLatitude(lat float64) float64 {
+	lat = Normalize180(lat)
+	switch {
+	case lat > 90:
+		return 180 - lat
+	case lat < -90:
+		return -180 - lat
+	}
+	return lat
+}
+
+// fitDMS applies the package-wide policy to a parsed DMS value.
+func fitDMS(d DMS) (DMS, error) {
+	axis := d.Axis()
+	if axis == AxisUnknown || DMSToDecimal(d) <= axis.limit() {
+		return d, nil
+	}
+	value, err := CurrentRangePolicy().applyAxis(signedDecimal(d), axis)
+	if err != nil {
+		return DMS{}, err
+	}
+	if axis == AxisLatitude {
+		return DecimalToDMS(value, "N", "S"), nil
+	}
+	return DecimalToDMS(value, "E", "W"), nil
+}
+
+// fitCoordinate applies the package-wide policy to a parsed coordinate.
+func fitCoordinate(c Coordinate) (Coordinate, error) {
+	if DMSToDecimal(c.Latitude) <= 90 && DMSToDecimal(c.Longitude) <= 180 {
+		return c, nil
+	}
+	lat, lon := c.Decimal()
+	lat, lon, err := CurrentRangePolicy().Apply(lat, lon)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	result := coordinateFromDecimal(lat, lon)
+	result.Accuracy = c.Accuracy
+	return result, nil
+}
+
+// rangeTolerated reports whether err is an out-of-range error that the
+// package-wide policy resolves after parsing.
+func rangeTolerated(err error) bool {
+	var v *ValidationError
+	return CurrentRangePolicy() != RangeError && errors.As(err, &v) && v.Rule == RuleRange
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+// TestParsersRejectLatitude95 runs every parser of coordinates on a latitude
+// of 95°, which must be rejected under the default range policy.
+func TestParsersRejectLatitude95(t *testing.T) {
+	parsers := map[string]func() error{
+		"ParseDMS":               func() error { _, err := ParseDMS("95 N"); return err },
+		"ParseCoordinate":        func() error { _, err := ParseCoordinate("95N 10E"); return err },
+		"ParseCompact":           func() error { _, err := ParseCompact("950000N"); return err },
+		"ParseCompactCoordinate": func() error { _, err := ParseCompactCoordinate("950000N0100000E"); return err },
+		"ParseGeoURI":            func() error { _, err := ParseGeoURI("geo:95,10"); return err },
+		"ParseMapsURL":           func() error { _, err := ParseMapsURL("https://www.openstreetmap.org/?mlat=95&mlon=10"); return err },
+		"ParseQRPayload":         func() error { _, err := ParseQRPayload("geo:95,10"); return err },
+		"ParseChecksum":          func() error { _, _, err := ParseChecksum(AppendChecksum("950000N0100000E")); return err },
+		"ParseOCR":               func() error { _, _, err := ParseOCR("95N 10E"); return err },
+		"ParseNMEA":              func() error { _, err := ParseNMEA("$GPGLL,9500.00,N,01000.00,E,120000,A"); return err },
+		"ParseAPRS":              func() error { _, err := ParseAPRS("!9500.00N/01000.00E-"); return err },
+		"NullCoordinate.Scan":    func() error { var n NullCoordinate; return n.Scan("95N10E") },
+	}
+	for _, input := range []string{"95N 10E", "95N10E", "950000N0100000E", "N95E10", "95, 10", `95°0'0" N 10°0'0" E`} {
+		parsers["ParseAny "+input] = func() error { _, _, err := ParseAny(input); return err }
+	}
+	for name, parse := range parsers {
+		if err := parse(); err == nil {
+			t.Errorf("%s accepted a latitude of 95°", name)
+		}
+	}
+}
+
+func TestRangeWrapOverPoles(t *testing.T) {
+	tests := []struct {
+		lat, lon         float64
+		wantLat, wantLon float64
+	}{
+		{95, 10, 85, -170},
+		{-95, 10, -85, -170},
+		{200, 10, -20, -170},
+		{300, 10, -60, 10},
+		{360, 10, 0, 10},
+		{-300, 10, 60, 10},
+		{450, 10, 90, 10},
+	}
+	for _, tt := range tests {
+		lat, lon, err := RangeWrap.Apply(tt.lat, tt.lon)
+		if err != nil {
+			t.Errorf("Apply(%v, %v): %v", tt.lat, tt.lon, err)
+			continue
+		}
+		if math.Abs(lat-tt.wantLat) > 1e-9 || math.Abs(lon-tt.wantLon) > 1e-9 {
+			t.Errorf("Apply(%v, %v) = %v, %v, want %v, %v", tt.lat, tt.lon, lat, lon, tt.wantLat, tt.wantLon)
+		}
+	}
+}
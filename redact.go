@@ -0,0 +1,95 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// Log redaction
+
+// Redaction selects how much of a position is kept in log output.
+type Redaction int32
+
+const (
+	RedactToMinute Redaction = iota // Truncate to whole minutes, about 2 km. The default.
+	RedactToDegree                  // Truncate to whole degrees, about 100 km.
+	RedactAll                       // Hide the position entirely.
+	RedactNone                      // Keep full precision.
+)
+
+// redactedText replaces positions hidden by RedactAll.
+const redactedText = "[redacted]"
+
+// logRedaction holds the package-wide redaction set by SetLogRedaction.
+var logRedaction atomic.Int32
+
+// SetLogRedaction sets the redaction applied by LogString and LogValue.
+// It is RedactToMinute by default, so full-precision positions stay out of
+// logs unless explicitly allowed with RedactNone.
+func SetLogRedaction(r Redaction) {
+	logRedaction.Store(int32(r))
+}
+
+// LogRedaction returns the redaction applied by LogString and LogValue.
+func LogRedaction() Redaction {
+	return Redaction(logRedaction.Load())
+}
+
+// Redacted returns the DMS with its precision truncated according to r,
+// e.g. `40°26' N` for RedactToMinute and `40° N` for RedactToDegree.
+func (d *DMS) Redacted(r Redaction) string {
+	switch r {
+	case RedactNone:
+		return d.String()
+	case RedactToDegree:
+		return unitLabel(*d, ArcDegree, 0)
+	case RedactAll:
+		return redactedText
+	}
+	return unitLabel(*d, ArcMinute, 0)
+}
+
+// LogString returns the DMS redacted according to the package-wide setting.
+func (d *DMS) LogString() string {
+	return d.Redacted(LogRedaction())
+}
+
+// LogValue implements slog.LogValuer, so DMS values passed to a structured
+// logger are redacted according to the package-wide setting.
+func (d DMS) LogValue() slog.Value {
+	return slog.StringValue(d.LogString())
+}
+
+// Redacted returns the coordinate with its precision truncated according to r.
+func (c *Coordinate) Redacted(r Redaction) string {
+	if r == RedactAll {
+		return redactedText
+	}
+	return c.Latitude.Redacted(r) + " " + c.Longitude.Redacted(r)
+}
+
+// LogString returns the coordinate redacted according to the package-wide setting.
+func (c *Coordinate) LogString() string {
+	return c.Redacted(LogRedaction())
+}
+
+// LogValue implements slog.LogValuer, so coordinates passed to a structured
+// logger are redacted according to the package-wide setting.
+func (c Coordinate) LogValue() slog.Value {
+	return slog.StringValue(c.LogString())
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+// Region classification
+
+// Region is the coarse geographic region of a coordinate.
+type Region struct {
+	Country   string // ISO 3166-1 alpha-2 country code, empty when unknown.
+	Continent string // Continent code (AF, AN, AS, EU, NA, OC, SA), empty when unknown.
+}
+
+// RegionProvider classifies coordinates into coarse regions.
+type RegionProvider interface {
+	// Region returns the region containing c, or false if it is unknown.
+	Region(c Coordinate) (Region, bool)
+}
+
+// RegionBox is a region described by a bounding box in decimal degrees. A box
+// whose West edge is greater than its East edge crosses the antimeridian.
+type RegionBox struct {
+	Region
+	South, West, North, East float64
+}
+
+// Contains reports whether the box contains the decimal position.
+func (b *RegionBox) Contains(lat, lon float64) bool {
+	if lat < b.South || lat > b.North {
+		return false
+	}
+	if b.West <= b.East {
+		return lon >= b.West && lon <= b.East
+	}
+	return lon >= b.West || lon <= b.East
+}
+
+// area returns the size of the box in square degrees.
+func (b *RegionBox) area() float64 {
+	width := b.East - b.West
+	if width < 0 {
+		width += 360
+	}
+	return width * (b.North - b.South)
+}
+
+// BoxRegionProvider is a RegionProvider backed by bounding boxes. Boxes with
+// a country take precedence over continent-only boxes, and among several
+// candidate boxes the smallest one wins.
+type BoxRegionProvider []RegionBox
+
+// Region returns the region of the best box containing c.
+func (p BoxRegionProvider) Region(c Coordinate) (Region, bool) {
+	lat, lon := c.Decimal()
+	best := -1
+	for i := range p {
+		if !p[i].Contains(lat, lon) {
+			continue
+		}
+		if best < 0 || p.better(i, best) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return Region{}, false
+	}
+	return p[best].Region, true
+}
+
+// better reports whether box i is a better match than box j.
+func (p BoxRegionProvider) better(i, j int) bool {
+	iCountry, jCountry := p[i].Country != "", p[j].Country != ""
+	if iCountry != jCountry {
+		return iCountry
+	}
+	return p[i].area() < p[j].area()
+}
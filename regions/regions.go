@@ -0,0 +1,66 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+// Package regions provides an embedded low-resolution dataset of country and
+// continent bounding boxes for offline, coarse region tagging of coordinates.
+//
+// Bounding boxes overlap near borders, so results are a heuristic suitable
+// for labeling logs and statistics, not for legal or navigational use.
+package regions
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/mshafiee/dms"
+)
+
+//go:embed regions.csv
+var regionsCSV string
+
+// boxes holds the parsed dataset.
+var boxes = parseBoxes(regionsCSV)
+
+// Provider returns a dms.RegionProvider backed by the embedded dataset.
+func Provider() dms.RegionProvider {
+	return dms.BoxRegionProvider(boxes)
+}
+
+// parseBoxes parses the embedded CSV dataset. The data is part of the
+// package, so malformed rows are a programming error.
+func parseBoxes(data string) []dms.RegionBox {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		panic("regions: " + err.Error())
+	}
+	result := make([]dms.RegionBox, 0, len(records))
+	for _, rec := range records {
+		var edges [4]float64
+		for i := range edges {
+			if edges[i], err = strconv.ParseFloat(rec[2+i], 64); err != nil {
+				panic("regions: " + err.Error())
+			}
+		}
+		result = append(result, dms.RegionBox{
+			Region: dms.Region{Country: rec[0], Continent: rec[1]},
+			South:  edges[0], West: edges[1], North: edges[2], East: edges[3],
+		})
+	}
+	return result
+}
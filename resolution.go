@@ -0,0 +1,178 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Resolution is an angular span in seconds of arc, such as the 1" or 30"
+// cell size of a digital elevation model or the precision of a DMS value.
+type Resolution float64
+
+// Common resolutions.
+const (
+	ArcSecond Resolution = 1
+	ArcMinute Resolution = 60
+	ArcDegree Resolution = 3600
+)
+
+// ResolutionFromDMS returns the resolution spanning the given degrees,
+// minutes and seconds.
+func ResolutionFromDMS(deg, min uint, sec float64) Resolution {
+	return Resolution(float64(deg)*3600 + float64(min)*60 + sec)
+}
+
+// Degrees returns the resolution in decimal degrees.
+func (r Resolution) Degrees() float64 {
+	return float64(r) / 3600
+}
+
+// Meters returns the ground size in meters of the resolution along the
+// meridian and along the parallel at the given latitude on WGS-84.
+func (r Resolution) Meters(lat float64) (north, east float64) {
+	n, e := MetersPerSecondOfArc(lat)
+	return float64(r) * n, float64(r) * e
+}
+
+// Snap rounds a decimal degree value to the nearest multiple of the resolution.
+func (r Resolution) Snap(value float64) float64 {
+	return r.snap(value, math.Round)
+}
+
+// SnapDown rounds a decimal degree value down to a multiple of the
+// resolution, giving the lower edge of the grid cell containing it.
+func (r Resolution) SnapDown(value float64) float64 {
+	return r.snap(value, math.Floor)
+}
+
+// SnapCoordinate rounds both parts of a coordinate to the nearest grid node.
+func (r Resolution) SnapCoordinate(c Coordinate) Coordinate {
+	lat, lon := c.Decimal()
+	snapped := coordinateFromDecimal(r.Snap(lat), r.Snap(lon))
+	snapped.Accuracy = c.Accuracy
+	return snapped
+}
+
+// snap rounds value to a multiple of the resolution with the given function,
+// tolerating floating-point noise just below a grid line.
+func (r Resolution) snap(value float64, round func(float64) float64) float64 {
+	if r <= 0 {
+		return value
+	}
+	cells := value * 3600 / float64(r)
+	return round(cells+1e-9) * float64(r) / 3600
+}
+
+// String returns the resolution in the largest whole unit, e.g. `30"`, `5'` or `1°`.
+func (r Resolution) String() string {
+	switch {
+	case r >= ArcDegree && math.Mod(float64(r), 3600) == 0:
+		return fmt.Sprintf("%g°", float64(r)/3600)
+	case r >= ArcMinute && math.Mod(float64(r), 60) == 0:
+		return fmt.Sprintf("%g'", float64(r)/60)
+	}
+	return fmt.Sprintf(`%g"`, float64(r))
+}
+
+// Scale-dependent precision
+
+// standardPixelSize is the OGC standardized rendering pixel size in meters,
+// used to relate map scales to pixel sizes.
+const standardPixelSize = 0.00028
+
+// scaleUnits holds the units offered by PrecisionForScale, coarsest first.
+var scaleUnits = []Resolution{
+	ArcDegree, 10 * ArcMinute, ArcMinute, 10 * ArcSecond, ArcSecond,
+	0.1, 0.01, 0.001, 0.0001,
+}
+
+// MetersPerPixel returns the ground size of a pixel at a map scale of
+// 1:denominator, with the OGC standard pixel size of 0.28 mm.
+func MetersPerPixel(denominator float64) float64 {
+	return denominator * standardPixelSize
+}
+
+// PrecisionForScale returns the finest DMS unit, among 1°, 10', 1', 10",
+// 1" and decimals of seconds, that spans at least one pixel of the given
+// ground size in meters, so that labels do not show digits finer than the
+// map.
+func PrecisionForScale(metersPerPixel float64) Resolution {
+	for i := len(scaleUnits) - 1; i >= 0; i-- {
+		if float64(scaleUnits[i])*metersPerArcSecond >= metersPerPixel {
+			return scaleUnits[i]
+		}
+	}
+	return scaleUnits[0]
+}
+
+// Decimals returns the number of decimals of the seconds needed to show
+// multiples of the resolution, 0 for whole seconds and above.
+func (r Resolution) Decimals() int {
+	if r <= 0 || r >= ArcSecond {
+		return 0
+	}
+	return min(int(math.Ceil(-math.Log10(float64(r))-1e-9)), maxAutoPrecision)
+}
+
+// FormatForScale returns the coordinate rounded to PrecisionForScale of the
+// pixel size in meters. Units below a minute are formatted with opts; coarser
+// units give degrees and minutes, or whole degrees, in symbols, such as
+// 40°27' N 79°59' W.
+func (c *Coordinate) FormatForScale(metersPerPixel float64, opts FormatOptions) string {
+	unit := PrecisionForScale(metersPerPixel)
+	if unit < ArcMinute {
+		snapped := *c
+		if unit > ArcSecond {
+			snapped = unit.SnapCoordinate(*c)
+		}
+		opts.Precision = unit.Decimals()
+		opts.AutoPrecision = false
+		return snapped.Format(opts)
+	}
+	lat, lon := c.Decimal()
+	return scaledLabel(unit.Snap(lat), "N", "S", unit) + " " + scaledLabel(unit.Snap(lon), "E", "W", unit)
+}
+
+// scaledLabel returns a decimal degree value rounded to a unit of whole
+// minutes or degrees, with its direction.
+func scaledLabel(value float64, positive, negative string, unit Resolution) string {
+	d := DecimalToDMS(value, positive, negative)
+	return unitLabel(d.roundedSeconds(0, RoundHalfAwayFromZero), unit, 0)
+}
+
+// unitLabel returns a DMS written down to the unit: whole degrees for
+// multiples of a degree, whole minutes for multiples of a minute, and
+// seconds with decimals otherwise, followed by the direction when set, as in
+// 40° N, 40°30' N or 40°30'15" N. Lower parts are dropped, not rounded. It
+// is the label layout of graticules, FormatForScale and Redacted.
+func unitLabel(d DMS, unit Resolution, decimals int) string {
+	var s string
+	switch {
+	case math.Mod(float64(unit), float64(ArcDegree)) == 0:
+		s = fmt.Sprintf("%d°", d.Degree)
+	case math.Mod(float64(unit), float64(ArcMinute)) == 0:
+		s = fmt.Sprintf("%d°%d'", d.Degree, d.Minutes)
+	default:
+		s = fmt.Sprintf(`%d°%d'%s"`, d.Degree, d.Minutes, strconv.FormatFloat(d.Seconds, 'f', decimals, 64))
+	}
+	if d.Direction != "" {
+		s += " " + d.Direction
+	}
+	return s
+}
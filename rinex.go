@@ -0,0 +1,105 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RINEX headers
+
+// RINEXHeader holds the marker position fields of a RINEX observation file
+// header.
+type RINEXHeader struct {
+	MarkerName   string     // MARKER NAME record.
+	MarkerNumber string     // MARKER NUMBER record.
+	Position     ECEF       // APPROX POSITION XYZ record, in meters.
+	Coordinate   Coordinate // Geodetic position of the marker, zero when the header has no position.
+	Height       float64    // Height of the marker in meters above the WGS-84 ellipsoid.
+	AntennaDelta ENU        // ANTENNA: DELTA H/E/N record, the antenna offset from the marker in meters.
+}
+
+// rinexLabelColumn is the column at which the label of a header record starts.
+const rinexLabelColumn = 60
+
+// ReadRINEXHeader reads the header of a RINEX observation file, up to the
+// END OF HEADER record, and converts the approximate marker position from
+// ECEF to a geodetic coordinate. A position of 0, 0, 0, written by receivers
+// without a fix, leaves Coordinate zero.
+func ReadRINEXHeader(r io.Reader) (RINEXHeader, error) {
+	var h RINEXHeader
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if len(text) <= rinexLabelColumn {
+			continue
+		}
+		data, label := text[:rinexLabelColumn], strings.TrimSpace(text[rinexLabelColumn:])
+		switch label {
+		case "MARKER NAME":
+			h.MarkerName = strings.TrimSpace(data)
+		case "MARKER NUMBER":
+			h.MarkerNumber = strings.TrimSpace(data)
+		case "APPROX POSITION XYZ":
+			v, err := rinexFloats(data)
+			if err != nil {
+				return RINEXHeader{}, fmt.Errorf("RINEX line %d: %w", line, err)
+			}
+			h.Position = ECEF{v[0], v[1], v[2]}
+		case "ANTENNA: DELTA H/E/N":
+			v, err := rinexFloats(data)
+			if err != nil {
+				return RINEXHeader{}, fmt.Errorf("RINEX line %d: %w", line, err)
+			}
+			h.AntennaDelta = ENU{East: v[1], North: v[2], Up: v[0]}
+		case "END OF HEADER":
+			if h.Position != (ECEF{}) {
+				h.Coordinate, h.Height = FromECEF(h.Position)
+			}
+			return h, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return RINEXHeader{}, err
+	}
+	return RINEXHeader{}, errors.New("Missing RINEX END OF HEADER record")
+}
+
+// AntennaPosition returns the position and ellipsoidal height of the antenna
+// reference point, offset from the marker by AntennaDelta.
+func (h *RINEXHeader) AntennaPosition() (Coordinate, float64) {
+	return FromENU(h.Coordinate, h.Height, h.AntennaDelta)
+}
+
+// rinexFloats parses the three 14-column numbers of a RINEX header record.
+func rinexFloats(data string) ([3]float64, error) {
+	var v [3]float64
+	for i := range v {
+		field := strings.TrimSpace(data[i*14 : (i+1)*14])
+		f, err := strconv.ParseFloat(strings.Replace(field, "D", "E", 1), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return v, fmt.Errorf("Invalid RINEX number %q", field)
+		}
+		v[i] = f
+	}
+	return v, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// rinexHeader returns RINEX header text from records of data and label.
+func rinexHeader(records ...[2]string) string {
+	var b strings.Builder
+	for _, r := range records {
+		fmt.Fprintf(&b, "%-60s%s\n", r[0], r[1])
+	}
+	return b.String()
+}
+
+func TestReadRINEXHeader(t *testing.T) {
+	want := coordinateFromDecimal(50.797815, 4.359267)
+	p := ToECEF(want, 158.5)
+	text := rinexHeader(
+		[2]string{"     3.04           OBSERVATION DATA    M", "RINEX VERSION / TYPE"},
+		[2]string{"BRUX", "MARKER NAME"},
+		[2]string{"13101M010", "MARKER NUMBER"},
+		[2]string{fmt.Sprintf("%14.4f%14.4f%14.4f", p.X, p.Y, p.Z), "APPROX POSITION XYZ"},
+		[2]string{"        0.4700        0.0000        0.0000", "ANTENNA: DELTA H/E/N"},
+		[2]string{"", "END OF HEADER"},
+		[2]string{"> 2021 07 01 00 00  0.0000000  0 30", ""},
+	)
+	h, err := ReadRINEXHeader(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.MarkerName != "BRUX" || h.MarkerNumber != "13101M010" || h.AntennaDelta.Up != 0.47 {
+		t.Errorf("ReadRINEXHeader = %+v", h)
+	}
+	if d := Distance(h.Coordinate, want); d > 0.01 || math.Abs(h.Height-158.5) > 0.01 {
+		t.Errorf("marker is %g m from %v at height %g", d, want, h.Height)
+	}
+	_, height := h.AntennaPosition()
+	if math.Abs(height-158.97) > 0.01 {
+		t.Errorf("AntennaPosition height = %g, want 158.97", height)
+	}
+}
+
+func TestReadRINEXHeaderWithoutFix(t *testing.T) {
+	text := rinexHeader(
+		[2]string{"        0.0000        0.0000        0.0000", "APPROX POSITION XYZ"},
+		[2]string{"  4.0D+06  3.0D+05  4.9D+06", "COMMENT"},
+		[2]string{"", "END OF HEADER"},
+	)
+	h, err := ReadRINEXHeader(strings.NewReader(text))
+	if err != nil || !h.Coordinate.IsZero() {
+		t.Errorf("ReadRINEXHeader = %+v, %v, want a zero coordinate", h, err)
+	}
+}
+
+func TestReadRINEXHeaderMalformed(t *testing.T) {
+	tests := map[string]string{
+		"empty":            "",
+		"no end of header": rinexHeader([2]string{"BRUX", "MARKER NAME"}),
+		"bad number": rinexHeader(
+			[2]string{"  4027893.8   x           4919474.9", "APPROX POSITION XYZ"},
+			[2]string{"", "END OF HEADER"}),
+		"NaN position": rinexHeader(
+			[2]string{fmt.Sprintf("%14s%14s%14s", "NaN", "0", "0"), "APPROX POSITION XYZ"},
+			[2]string{"", "END OF HEADER"}),
+		"infinite delta": rinexHeader(
+			[2]string{fmt.Sprintf("%14s%14s%14s", "Inf", "0", "0"), "ANTENNA: DELTA H/E/N"},
+			[2]string{"", "END OF HEADER"}),
+	}
+	for name, text := range tests {
+		if h, err := ReadRINEXHeader(strings.NewReader(text)); err == nil {
+			t.Errorf("%s: ReadRINEXHeader = %+v, want error", name, h)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"time"
+)
+
+// Leg is the step between two consecutive waypoints of a route or track.
+type Leg struct {
+	From, To Waypoint
+	Movement // Distance and bearing; elapsed time and speed when both waypoints have times.
+}
+
+// newLeg returns the leg between two waypoints.
+func newLeg(from, to Waypoint) Leg {
+	l := Leg{From: from, To: to}
+	l.Distance = Distance(from.Coordinate, to.Coordinate)
+	l.Bearing = Bearing(from.Coordinate, to.Coordinate)
+	if !from.Time.IsZero() && !to.Time.IsZero() {
+		l.Elapsed = to.Time.Sub(from.Time)
+		l.Speed = SpeedOver(l.Distance, l.Elapsed)
+	}
+	return l
+}
+
+// legsOf returns the legs between consecutive waypoints.
+func legsOf(waypoints []Waypoint) []Leg {
+	var legs []Leg
+	for i := 1; i < len(waypoints); i++ {
+		legs = append(legs, newLeg(waypoints[i-1], waypoints[i]))
+	}
+	return legs
+}
+
+// lengthOf returns the total great-circle length in meters of a path.
+func lengthOf(waypoints []Waypoint) float64 {
+	total := 0.0
+	for i := 1; i < len(waypoints); i++ {
+		total += Distance(waypoints[i-1].Coordinate, waypoints[i].Coordinate)
+	}
+	return total
+}
+
+// Route is a planned, ordered list of waypoints to travel through.
+type Route struct {
+	Name      string
+	Waypoints []Waypoint
+}
+
+// Legs returns the legs between consecutive waypoints of the route.
+func (r *Route) Legs() []Leg {
+	return legsOf(r.Waypoints)
+}
+
+// Leg returns the leg from waypoint i to waypoint i+1. It panics when the
+// route has no such leg.
+func (r *Route) Leg(i int) Leg {
+	return newLeg(r.Waypoints[i], r.Waypoints[i+1])
+}
+
+// Distance returns the total length of the route in meters.
+func (r *Route) Distance() float64 {
+	return lengthOf(r.Waypoints)
+}
+
+// Track is a recorded path, made of segments of timestamped points. A new
+// segment starts where the recording was interrupted, e.g. by a loss of GPS
+// reception.
+type Track struct {
+	Name     string
+	Segments [][]Waypoint
+}
+
+// Points returns the points of all segments of the track, in order.
+func (t *Track) Points() []Waypoint {
+	var points []Waypoint
+	for _, segment := range t.Segments {
+		points = append(points, segment...)
+	}
+	return points
+}
+
+// Legs returns the legs between consecutive points of each segment. No leg
+// joins the end of a segment to the start of the next one.
+func (t *Track) Legs() []Leg {
+	var legs []Leg
+	for _, segment := range t.Segments {
+		legs = append(legs, legsOf(segment)...)
+	}
+	return legs
+}
+
+// Distance returns the total length of the track segments in meters.
+func (t *Track) Distance() float64 {
+	total := 0.0
+	for _, segment := range t.Segments {
+		total += lengthOf(segment)
+	}
+	return total
+}
+
+// Duration returns the time from the first to the last timestamped point of
+// the track, gaps between segments included.
+func (t *Track) Duration() time.Duration {
+	var first, last time.Time
+	for _, segment := range t.Segments {
+		for _, p := range segment {
+			if p.Time.IsZero() {
+				continue
+			}
+			if first.IsZero() {
+				first = p.Time
+			}
+			last = p.Time
+		}
+	}
+	return last.Sub(first)
+}
+
+// MovingSpeed returns the average speed over the legs with known times,
+// excluding the gaps between segments.
+func (t *Track) MovingSpeed() Speed {
+	var distance float64
+	var elapsed time.Duration
+	for _, l := range t.Legs() {
+		if l.Elapsed > 0 {
+			distance += l.Distance
+			elapsed += l.Elapsed
+		}
+	}
+	return SpeedOver(distance, elapsed)
+}
+
+// ErrTimeNotInTrack is returned by Track.PositionAt for times outside the
+// recorded segments of a track.
+var ErrTimeNotInTrack = errors.New("Time not covered by the track")
+
+// PositionAt returns the position of the track at a time, interpolated along
+// the great circle between the timestamped points around it, as needed to
+// geotag photos taken between fixes. Points without time are skipped. Times
+// before the first point, after the last one or in a gap between segments
+// return ErrTimeNotInTrack.
+func (t *Track) PositionAt(at time.Time) (Coordinate, error) {
+	for _, segment := range t.Segments {
+		var prev *Waypoint
+		for i := range segment {
+			p := &segment[i]
+			if p.Time.IsZero() {
+				continue
+			}
+			if p.Time.Equal(at) {
+				return p.Coordinate, nil
+			}
+			if prev != nil && prev.Time.Before(at) && p.Time.After(at) {
+				f := float64(at.Sub(prev.Time)) / float64(p.Time.Sub(prev.Time))
+				a, b := prev.Coordinate, p.Coordinate
+				return Destination(a, Bearing(a, b), f*Distance(a, b)), nil
+			}
+			prev = p
+		}
+	}
+	return Coordinate{}, ErrTimeNotInTrack
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testTrack returns a two-segment track along the equator with points one
+// minute apart and a ten-minute gap between the segments.
+func testTrack(t *testing.T) Track {
+	t.Helper()
+	start := time.Date(2021, 7, 4, 12, 0, 0, 0, time.UTC)
+	point := func(lon float64, minutes int) Waypoint {
+		w, err := NewWaypoint("", 0, lon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Time = start.Add(time.Duration(minutes) * time.Minute)
+		return w
+	}
+	return Track{Name: "Ride", Segments: [][]Waypoint{
+		{point(0, 0), point(0.01, 1), point(0.02, 2)},
+		{point(0.03, 12), point(0.04, 13)},
+	}}
+}
+
+func TestTrackLegs(t *testing.T) {
+	track := testTrack(t)
+	step := Distance(coordinateFromDecimal(0, 0), coordinateFromDecimal(0, 0.01))
+	legs := track.Legs()
+	if len(legs) != 3 {
+		t.Fatalf("Legs() returned %d legs, want 3", len(legs))
+	}
+	for i, l := range legs {
+		if math.Abs(l.Distance-step) > 1e-6 || math.Abs(l.Bearing-90) > 1e-9 || l.Elapsed != time.Minute {
+			t.Errorf("leg %d = %+v, want %v m at 90° in 1m", i, l.Movement, step)
+		}
+	}
+	if got := track.Distance(); math.Abs(got-3*step) > 1e-6 {
+		t.Errorf("Distance() = %v, want %v", got, 3*step)
+	}
+	if got := track.Duration(); got != 13*time.Minute {
+		t.Errorf("Duration() = %v, want 13m", got)
+	}
+	if got, want := track.MovingSpeed(), SpeedOver(3*step, 3*time.Minute); math.Abs(float64(got-want)) > 1e-9 {
+		t.Errorf("MovingSpeed() = %v, want %v", got, want)
+	}
+
+	route := Route{Name: "Plan", Waypoints: track.Points()}
+	if got := len(route.Legs()); got != 4 {
+		t.Errorf("Route.Legs() returned %d legs, want 4", got)
+	}
+	if got := route.Distance(); math.Abs(got-4*step) > 1e-6 {
+		t.Errorf("Route.Distance() = %v, want %v", got, 4*step)
+	}
+	if l := route.Leg(2); l.Elapsed != 10*time.Minute {
+		t.Errorf("Route.Leg(2).Elapsed = %v, want 10m", l.Elapsed)
+	}
+}
+
+func TestRoutesRoundTrip(t *testing.T) {
+	track := testTrack(t)
+	route := Route{Name: "Plan", Waypoints: testWaypoints(t)}
+
+	var b bytes.Buffer
+	if err := (&GPX{Routes: []Route{route}, Tracks: []Track{track}}).Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	gpx, err := ReadGPX(&b)
+	if err != nil {
+		t.Fatalf("ReadGPX error: %v", err)
+	}
+	if len(gpx.Routes) != 1 || len(gpx.Tracks) != 1 || len(gpx.Tracks[0].Segments) != 2 {
+		t.Fatalf("ReadGPX = %+v, want one route and one track of two segments", gpx)
+	}
+	if gpx.Routes[0].Name != "Plan" || gpx.Tracks[0].Name != "Ride" {
+		t.Errorf("ReadGPX names = %q, %q", gpx.Routes[0].Name, gpx.Tracks[0].Name)
+	}
+	checkWaypoints(t, "GPX route", gpx.Routes[0].Waypoints, route.Waypoints)
+	for i, segment := range track.Segments {
+		checkWaypoints(t, "GPX track", gpx.Tracks[0].Segments[i], segment)
+	}
+
+	// KML line strings keep the vertices but none of their metadata.
+	b.Reset()
+	if err := (&KML{Routes: []Route{route}}).Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	kml, err := ReadKML(&b)
+	if err != nil {
+		t.Fatalf("ReadKML error: %v", err)
+	}
+	if len(kml.Routes) != 1 || kml.Routes[0].Name != "Plan" || len(kml.Routes[0].Waypoints) != 2 {
+		t.Fatalf("ReadKML = %+v, want route Plan of two vertices", kml)
+	}
+	for i, w := range kml.Routes[0].Waypoints {
+		if Distance(w.Coordinate, route.Waypoints[i].Coordinate) > 1e-3 {
+			t.Errorf("KML vertex %d = %v, want %v", i, w.Coordinate, route.Waypoints[i].Coordinate)
+		}
+	}
+}
+
+func TestReadRoutesMalformed(t *testing.T) {
+	gpx := []string{
+		`<gpx><rte><rtept lat="1" lon="x"/></rte></gpx>`,
+		`<gpx><trk><trkseg><trkpt lat="-91" lon="1"/></trkseg></trk></gpx>`,
+	}
+	for _, s := range gpx {
+		if _, err := ReadGPX(strings.NewReader(s)); err == nil {
+			t.Errorf("ReadGPX(%q) succeeded, want error", s)
+		}
+	}
+	const kml = `<kml><Document><Placemark><LineString><coordinates>1,1 2</coordinates></LineString></Placemark></Document></kml>`
+	if _, err := ReadKML(strings.NewReader(kml)); err == nil {
+		t.Errorf("ReadKML(%q) succeeded, want error", kml)
+	}
+}
+
+func TestTrackPositionAt(t *testing.T) {
+	track := testTrack(t)
+	start := track.Segments[0][0].Time
+	tests := []struct {
+		offset time.Duration
+		lon    float64
+	}{
+		{0, 0},
+		{30 * time.Second, 0.005},
+		{90 * time.Second, 0.015},
+		{2 * time.Minute, 0.02},
+		{12*time.Minute + 15*time.Second, 0.0325},
+		{13 * time.Minute, 0.04},
+	}
+	for _, tt := range tests {
+		c, err := track.PositionAt(start.Add(tt.offset))
+		if err != nil {
+			t.Errorf("PositionAt(+%v) error: %v", tt.offset, err)
+			continue
+		}
+		if d := Distance(c, coordinateFromDecimal(0, tt.lon)); d > 1e-3 {
+			t.Errorf("PositionAt(+%v) = %v, %v m from longitude %v", tt.offset, c, d, tt.lon)
+		}
+	}
+	for _, offset := range []time.Duration{-time.Second, 5 * time.Minute, 14 * time.Minute} {
+		if c, err := track.PositionAt(start.Add(offset)); err != ErrTimeNotInTrack {
+			t.Errorf("PositionAt(+%v) = %v, %v, want ErrTimeNotInTrack", offset, c, err)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Shapefiles
+//
+// Only point shapefiles are read, with the attributes of their dBASE table.
+// The points must be in geographic longitude and latitude; the .prj file is
+// not read.
+
+// ShapePoint is a point of a shapefile with its attributes.
+type ShapePoint struct {
+	Coordinate Coordinate        // Position of the point.
+	Attributes map[string]string // Attribute values by field name, trimmed of padding.
+}
+
+// Shapefile constants.
+const (
+	shpFileCode   = 9994
+	shpHeaderSize = 100
+	shpNull       = 0
+	shpPoint      = 1
+	shpPointZ     = 11
+	shpPointM     = 21
+	dbfDeleted    = '*'
+	dbfHeaderEnd  = 0x0d
+)
+
+// ReadShapefile reads the points of a .shp file and, when dbf is not nil,
+// their attributes from the matching .dbf file. Null shapes and records
+// marked as deleted are skipped. Z and M values are ignored. Records must fit
+// in the file length given by the header.
+func ReadShapefile(shp, dbf io.Reader) ([]ShapePoint, error) {
+	r := bufio.NewReader(shp)
+	header := make([]byte, shpHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("Invalid shapefile header: %w", err)
+	}
+	if binary.BigEndian.Uint32(header) != shpFileCode {
+		return nil, errors.New("Invalid shapefile file code")
+	}
+	switch kind := binary.LittleEndian.Uint32(header[32:]); kind {
+	case shpNull, shpPoint, shpPointZ, shpPointM:
+	default:
+		return nil, fmt.Errorf("Unsupported shapefile shape type %d, want points", kind)
+	}
+
+	// The file length counts 16-bit words, header included.
+	remaining := 2*int64(binary.BigEndian.Uint32(header[24:])) - shpHeaderSize
+
+	var table *dbfReader
+	if dbf != nil {
+		var err error
+		if table, err = newDBFReader(dbf); err != nil {
+			return nil, err
+		}
+	}
+	var points []ShapePoint
+	for n := 1; ; n++ {
+		var recordHeader [8]byte
+		if _, err := io.ReadFull(r, recordHeader[:]); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("Shapefile record %d: %w", n, err)
+		}
+		length := 2 * int64(binary.BigEndian.Uint32(recordHeader[4:]))
+		remaining -= int64(len(recordHeader)) + length
+		if remaining < 0 {
+			return nil, fmt.Errorf("Shapefile record %d exceeds the file length", n)
+		}
+		// Read through a limit rather than allocating the declared length
+		// up front, which a corrupt header could make huge.
+		content, err := io.ReadAll(io.LimitReader(r, length))
+		if err != nil {
+			return nil, fmt.Errorf("Shapefile record %d: %w", n, err)
+		}
+		if int64(len(content)) < length {
+			return nil, fmt.Errorf("Shapefile record %d: %w", n, io.ErrUnexpectedEOF)
+		}
+		var attributes map[string]string
+		deleted := false
+		if table != nil {
+			var err error
+			if attributes, deleted, err = table.next(); err != nil {
+				return nil, fmt.Errorf("dBASE record %d: %w", n, err)
+			}
+		}
+		if len(content) < 4 {
+			return nil, fmt.Errorf("Shapefile record %d: truncated", n)
+		}
+		kind := binary.LittleEndian.Uint32(content)
+		if kind == shpNull || deleted {
+			continue
+		}
+		if len(content) < 20 {
+			return nil, fmt.Errorf("Shapefile record %d: truncated", n)
+		}
+		x := math.Float64frombits(binary.LittleEndian.Uint64(content[4:]))
+		y := math.Float64frombits(binary.LittleEndian.Uint64(content[12:]))
+		c, err := NewCoordinate(y, x)
+		if err != nil {
+			return nil, fmt.Errorf("Shapefile record %d is not in longitude and latitude: %w", n, err)
+		}
+		points = append(points, ShapePoint{Coordinate: c, Attributes: attributes})
+	}
+	return points, nil
+}
+
+// dbfField is a field of a dBASE table.
+type dbfField struct {
+	name   string
+	length int
+}
+
+// dbfReader reads the records of a dBASE table in order.
+type dbfReader struct {
+	r      *bufio.Reader
+	fields []dbfField
+	record []byte
+	count  uint32 // Number of records left.
+}
+
+// newDBFReader reads the header of a dBASE table.
+func newDBFReader(dbf io.Reader) (*dbfReader, error) {
+	d := &dbfReader{r: bufio.NewReader(dbf)}
+	header := make([]byte, 32)
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		return nil, fmt.Errorf("Invalid dBASE header: %w", err)
+	}
+	d.count = binary.LittleEndian.Uint32(header[4:])
+	headerSize := int(binary.LittleEndian.Uint16(header[8:]))
+	d.record = make([]byte, binary.LittleEndian.Uint16(header[10:]))
+	if headerSize < 33 {
+		return nil, errors.New("Invalid dBASE header size")
+	}
+	descriptors := make([]byte, headerSize-32)
+	if _, err := io.ReadFull(d.r, descriptors); err != nil {
+		return nil, fmt.Errorf("Invalid dBASE header: %w", err)
+	}
+	width := 1 // Deletion flag.
+	for i := 0; i+32 <= len(descriptors) && descriptors[i] != dbfHeaderEnd; i += 32 {
+		name, _, _ := strings.Cut(string(descriptors[i:i+11]), "\x00")
+		field := dbfField{name: name, length: int(descriptors[i+16])}
+		d.fields = append(d.fields, field)
+		width += field.length
+	}
+	if width > len(d.record) {
+		return nil, errors.New("dBASE fields exceed the record length")
+	}
+	return d, nil
+}
+
+// next returns the attributes of the next record and whether it is deleted.
+func (d *dbfReader) next() (map[string]string, bool, error) {
+	if d.count == 0 {
+		return nil, false, errors.New("Missing record")
+	}
+	d.count--
+	if _, err := io.ReadFull(d.r, d.record); err != nil {
+		return nil, false, err
+	}
+	attributes := make(map[string]string, len(d.fields))
+	offset := 1
+	for _, f := range d.fields {
+		attributes[f.name] = strings.TrimSpace(string(d.record[offset : offset+f.length]))
+		offset += f.length
+	}
+	return attributes, d.record[0] == dbfDeleted, nil
+}
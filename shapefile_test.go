@@ -0,0 +1,114 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// shapefileBytes returns a point shapefile holding points given as
+// longitude and latitude pairs.
+func shapefileBytes(points [][2]float64) []byte {
+	header := make([]byte, shpHeaderSize)
+	binary.BigEndian.PutUint32(header, shpFileCode)
+	binary.BigEndian.PutUint32(header[24:], uint32(shpHeaderSize+28*len(points))/2)
+	binary.LittleEndian.PutUint32(header[28:], 1000)
+	binary.LittleEndian.PutUint32(header[32:], shpPoint)
+	buf := header
+	for i, p := range points {
+		buf = binary.BigEndian.AppendUint32(buf, uint32(i+1))
+		buf = binary.BigEndian.AppendUint32(buf, 10)
+		buf = binary.LittleEndian.AppendUint32(buf, shpPoint)
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p[0]))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p[1]))
+	}
+	return buf
+}
+
+// dbfBytes returns a dBASE table with one character field NAME of width 8,
+// the names starting with "*" being marked as deleted.
+func dbfBytes(names []string) []byte {
+	header := make([]byte, 32)
+	header[0] = 3
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(names)))
+	binary.LittleEndian.PutUint16(header[8:], 65)
+	binary.LittleEndian.PutUint16(header[10:], 9)
+	field := make([]byte, 32)
+	copy(field, "NAME")
+	field[11], field[16] = 'C', 8
+	buf := append(append(header, field...), dbfHeaderEnd)
+	for _, name := range names {
+		flag := byte(' ')
+		if name != "" && name[0] == '*' {
+			flag, name = dbfDeleted, name[1:]
+		}
+		buf = append(buf, flag)
+		buf = append(buf, []byte(name + "        ")[:8]...)
+	}
+	return buf
+}
+
+func TestReadShapefile(t *testing.T) {
+	shp := shapefileBytes([][2]float64{{-79.948862, 40.446195}, {2.3522, 48.8566}, {151.2153, -33.8568}})
+	dbf := dbfBytes([]string{"Pitt", "*Paris", "Sydney"})
+	points, err := ReadShapefile(bytes.NewReader(shp), bytes.NewReader(dbf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("ReadShapefile returned %d points, want 2", len(points))
+	}
+	for i, want := range []struct {
+		name     string
+		lat, lon float64
+	}{{"Pitt", 40.446195, -79.948862}, {"Sydney", -33.8568, 151.2153}} {
+		lat, lon := points[i].Coordinate.Decimal()
+		if points[i].Attributes["NAME"] != want.name || math.Abs(lat-want.lat) > 1e-9 || math.Abs(lon-want.lon) > 1e-9 {
+			t.Errorf("point %d = %v %v, %v, want %s %v, %v", i, points[i].Attributes, lat, lon, want.name, want.lat, want.lon)
+		}
+	}
+}
+
+func TestReadShapefileMalformed(t *testing.T) {
+	valid := shapefileBytes([][2]float64{{1, 2}})
+	tests := map[string]func([]byte) []byte{
+		"bad file code": func(b []byte) []byte { b[3] = 0; return b },
+		"polygons":      func(b []byte) []byte { b[32] = 5; return b },
+		"truncated":     func(b []byte) []byte { return b[:len(b)-4] },
+		"huge record": func(b []byte) []byte {
+			binary.BigEndian.PutUint32(b[shpHeaderSize+4:], math.MaxUint32)
+			return b
+		},
+		"huge record and file length": func(b []byte) []byte {
+			binary.BigEndian.PutUint32(b[24:], math.MaxUint32)
+			binary.BigEndian.PutUint32(b[shpHeaderSize+4:], math.MaxUint32-shpHeaderSize)
+			return b
+		},
+		"projected": func(b []byte) []byte {
+			binary.LittleEndian.PutUint64(b[shpHeaderSize+12:], math.Float64bits(4500000))
+			return b
+		},
+	}
+	for name, corrupt := range tests {
+		data := corrupt(append([]byte(nil), valid...))
+		if _, err := ReadShapefile(bytes.NewReader(data), nil); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
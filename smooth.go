@@ -0,0 +1,141 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"time"
+)
+
+// Smoother filters a stream of noisy fixes into smoothed positions.
+type Smoother interface {
+	// Update adds a fix to the stream and returns the smoothed fix at its time.
+	Update(f Fix) Fix
+}
+
+// MovingAverage smooths fixes with the mean position of the last fixes.
+type MovingAverage struct {
+	size   int
+	window []Fix
+}
+
+// NewMovingAverage creates a moving average over the last size fixes.
+func NewMovingAverage(size int) *MovingAverage {
+	return &MovingAverage{size: max(size, 1)}
+}
+
+// Update adds a fix and returns the mean position of the window.
+func (m *MovingAverage) Update(f Fix) Fix {
+	m.window = append(m.window, f)
+	if len(m.window) > m.size {
+		m.window = m.window[1:]
+	}
+	_, refLon := f.Coordinate.Decimal()
+	var sumLat, sumLon float64
+	for i := range m.window {
+		lat, lon := m.window[i].Coordinate.Decimal()
+		sumLat += lat
+		// Average longitude offsets so windows may cross the antimeridian.
+		sumLon += Normalize180(lon - refLon)
+	}
+	n := float64(len(m.window))
+	return Fix{Coordinate: coordinateFromDecimal(sumLat/n, refLon+sumLon/n), Time: f.Time}
+}
+
+// KalmanFilter smooths fixes with a constant-velocity Kalman filter, run
+// independently on the north and east axes of a local plane anchored at the
+// first fix. It suits tracks spanning up to a few hundred kilometers.
+type KalmanFilter struct {
+	noise        float64 // Standard deviation of fix positions in meters.
+	acceleration float64 // Standard deviation of acceleration in m/s².
+	started      bool
+	lat0, lon0   float64 // Origin of the local plane in degrees.
+	last         time.Time
+	north, east  kalmanAxis
+}
+
+// kalmanAxis is the state of the filter on one axis.
+type kalmanAxis struct {
+	position, velocity float64
+	p                  [2][2]float64 // State covariance.
+}
+
+// NewKalmanFilter creates a constant-velocity Kalman filter for fixes with
+// the given position noise in meters and expected acceleration in m/s².
+func NewKalmanFilter(noise, acceleration float64) *KalmanFilter {
+	return &KalmanFilter{noise: noise, acceleration: acceleration}
+}
+
+// Update adds a fix and returns the filtered position at its time.
+func (k *KalmanFilter) Update(f Fix) Fix {
+	lat, lon := f.Coordinate.Decimal()
+	if !k.started {
+		k.started, k.lat0, k.lon0, k.last = true, lat, lon, f.Time
+		variance := k.noise * k.noise
+		k.north = kalmanAxis{p: [2][2]float64{{variance, 0}, {0, variance}}}
+		k.east = k.north
+		return f
+	}
+	dt := f.Time.Sub(k.last).Seconds()
+	k.last = f.Time
+	metersPerDegree := earthRadius * degToRad
+	z := [2]float64{
+		(lat - k.lat0) * metersPerDegree,
+		Normalize180(lon-k.lon0) * metersPerDegree * math.Cos(k.lat0*degToRad),
+	}
+	q := k.acceleration * k.acceleration
+	r := k.noise * k.noise
+	k.north.step(z[0], dt, q, r)
+	k.east.step(z[1], dt, q, r)
+	return Fix{
+		Coordinate: coordinateFromDecimal(
+			k.lat0+k.north.position/metersPerDegree,
+			k.lon0+k.east.position/(metersPerDegree*math.Cos(k.lat0*degToRad))),
+		Time: f.Time,
+	}
+}
+
+// step predicts the axis state dt seconds ahead and corrects it with the
+// measured position z, given process noise q and measurement noise r.
+func (a *kalmanAxis) step(z, dt, q, r float64) {
+	if dt > 0 {
+		a.position += a.velocity * dt
+		p := a.p
+		dt2, dt3, dt4 := dt*dt, dt*dt*dt, dt*dt*dt*dt
+		a.p[0][0] = p[0][0] + dt*(p[1][0]+p[0][1]) + dt2*p[1][1] + q*dt4/4
+		a.p[0][1] = p[0][1] + dt*p[1][1] + q*dt3/2
+		a.p[1][0] = p[1][0] + dt*p[1][1] + q*dt3/2
+		a.p[1][1] = p[1][1] + q*dt2
+	}
+	s := a.p[0][0] + r
+	k0, k1 := a.p[0][0]/s, a.p[1][0]/s
+	y := z - a.position
+	a.position += k0 * y
+	a.velocity += k1 * y
+	p := a.p
+	a.p[0][0] = (1 - k0) * p[0][0]
+	a.p[0][1] = (1 - k0) * p[0][1]
+	a.p[1][0] = p[1][0] - k1*p[0][0]
+	a.p[1][1] = p[1][1] - k1*p[0][1]
+}
+
+// coordinateFromDecimal creates a Coordinate from decimal degrees, clamping
+// the latitude and wrapping the longitude into their valid ranges.
+func coordinateFromDecimal(lat, lon float64) Coordinate {
+	lat = math.Max(-90, math.Min(90, lat))
+	lon = Normalize180(lon)
+	return Coordinate{Latitude: DecimalToDMS(lat, "N", "S"), Longitude: DecimalToDMS(lon, "E", "W")}
+}
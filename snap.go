@@ -0,0 +1,95 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "math"
+
+// Snapping to lines
+
+// Snapped is a position snapped onto a line.
+type Snapped struct {
+	Coordinate Coordinate // Snapped position on the line.
+	Offset     float64    // Distance in meters from the original position.
+	Line       int        // Index of the line snapped to.
+	Segment    int        // Index of the segment of the line, starting at its first point.
+}
+
+// Snapper snaps positions onto reference lines, such as roads, to clean GPS
+// traces. Implementations must be safe for concurrent use.
+type Snapper interface {
+	// Snap returns the position on the reference lines nearest to c, and
+	// false when there is none within the range of the snapper.
+	Snap(c Coordinate) (Snapped, bool)
+}
+
+// NearestSegmentSnapper snaps positions onto the nearest point of a set of
+// polylines with great-circle segments, by testing every segment.
+type NearestSegmentSnapper struct {
+	Lines       [][]Coordinate // Reference polylines.
+	MaxDistance float64        // Largest snapping distance in meters, unlimited when zero.
+}
+
+// Snap implements Snapper.
+func (s *NearestSegmentSnapper) Snap(c Coordinate) (Snapped, bool) {
+	best, found := Snapped{Offset: math.Inf(1)}, false
+	for i, line := range s.Lines {
+		if len(line) == 1 {
+			if d := Distance(c, line[0]); d < best.Offset {
+				best, found = Snapped{Coordinate: line[0], Offset: d, Line: i}, true
+			}
+		}
+		for j := 0; j+1 < len(line); j++ {
+			p := nearestOnSegment(c, line[j], line[j+1])
+			if d := Distance(c, p); d < best.Offset {
+				best, found = Snapped{Coordinate: p, Offset: d, Line: i, Segment: j}, true
+			}
+		}
+	}
+	if !found || (s.MaxDistance > 0 && best.Offset > s.MaxDistance) {
+		return Snapped{}, false
+	}
+	return best, true
+}
+
+// nearestOnSegment returns the point of the great-circle segment from a to b
+// nearest to p.
+func nearestOnSegment(p, a, b Coordinate) Coordinate {
+	length := Distance(a, b)
+	if length == 0 {
+		return a
+	}
+	along := AlongTrackDistance(p, a, b)
+	switch {
+	case along <= 0:
+		return a
+	case along >= length:
+		return b
+	}
+	return Destination(a, Bearing(a, b), along)
+}
+
+// SnapAll snaps every position of a trace, leaving positions with nothing in
+// range unchanged.
+func SnapAll(s Snapper, trace []Coordinate) []Coordinate {
+	snapped := make([]Coordinate, len(trace))
+	for i, c := range trace {
+		snapped[i] = c
+		if r, ok := s.Snap(c); ok {
+			snapped[i] = r.Coordinate
+		}
+	}
+	return snapped
+}
@@ -0,0 +1,134 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "sort"
+
+// Sorting
+
+// CompareLatitude orders coordinates from south to north, then from west to
+// east. It returns a negative number when a comes first, a positive number
+// when b comes first and zero when they are equal, as expected by
+// slices.SortFunc.
+func CompareLatitude(a, b Coordinate) int {
+	latA, lonA := a.Decimal()
+	latB, lonB := b.Decimal()
+	if c := compareFloat(latA, latB); c != 0 {
+		return c
+	}
+	return compareFloat(lonA, lonB)
+}
+
+// CompareLongitude orders coordinates from west to east, then from south to
+// north.
+func CompareLongitude(a, b Coordinate) int {
+	latA, lonA := a.Decimal()
+	latB, lonB := b.Decimal()
+	if c := compareFloat(lonA, lonB); c != 0 {
+		return c
+	}
+	return compareFloat(latA, latB)
+}
+
+// CompareDistanceFrom returns a comparison function that orders coordinates
+// by their great-circle distance from ref, nearest first.
+func CompareDistanceFrom(ref Coordinate) func(a, b Coordinate) int {
+	return func(a, b Coordinate) int {
+		return compareFloat(Distance(ref, a), Distance(ref, b))
+	}
+}
+
+// compareFloat compares two numbers.
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// ByLatitude sorts coordinates from south to north with sort.Sort.
+type ByLatitude []Coordinate
+
+func (s ByLatitude) Len() int           { return len(s) }
+func (s ByLatitude) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s ByLatitude) Less(i, j int) bool { return CompareLatitude(s[i], s[j]) < 0 }
+
+// ByLongitude sorts coordinates from west to east with sort.Sort.
+type ByLongitude []Coordinate
+
+func (s ByLongitude) Len() int           { return len(s) }
+func (s ByLongitude) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s ByLongitude) Less(i, j int) bool { return CompareLongitude(s[i], s[j]) < 0 }
+
+// ByDistance sorts coordinates by their distance from a reference point with
+// sort.Sort, nearest first.
+type ByDistance struct {
+	Ref         Coordinate
+	Coordinates []Coordinate
+}
+
+func (s ByDistance) Len() int {
+	return len(s.Coordinates)
+}
+
+func (s ByDistance) Swap(i, j int) {
+	s.Coordinates[i], s.Coordinates[j] = s.Coordinates[j], s.Coordinates[i]
+}
+
+func (s ByDistance) Less(i, j int) bool {
+	return Distance(s.Ref, s.Coordinates[i]) < Distance(s.Ref, s.Coordinates[j])
+}
+
+// ConvexHull returns the convex hull of the coordinates in counterclockwise
+// order, starting from the westernmost point, without repeating it.
+// The hull is computed on the plane of longitudes and latitudes, which suits
+// sets spanning less than a hemisphere and not crossing the antimeridian.
+func ConvexHull(coords []Coordinate) []Coordinate {
+	points := append([]Coordinate(nil), coords...)
+	sort.Sort(ByLongitude(points))
+	if len(points) < 3 {
+		return points
+	}
+	// Andrew's monotone chain: build the lower then the upper hull.
+	hull := make([]Coordinate, 0, 2*len(points))
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for i := range points {
+			p := points[i]
+			if pass == 1 {
+				p = points[len(points)-1-i]
+			}
+			for len(hull) >= start+2 && hullCross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1] // The last point starts the other half.
+	}
+	return hull
+}
+
+// hullCross returns the cross product of the vectors o→a and o→b on the plane
+// of longitudes and latitudes, positive for a counterclockwise turn.
+func hullCross(o, a, b Coordinate) float64 {
+	latO, lonO := o.Decimal()
+	latA, lonA := a.Decimal()
+	latB, lonB := b.Decimal()
+	return (lonA-lonO)*(latB-latO) - (latA-latO)*(lonB-lonO)
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+	"iter"
+	"strings"
+	"time"
+)
+
+// Streaming sources
+
+// Source streams the waypoints of a file without loading it in memory, for
+// use with range-over-func loops:
+//
+//	src := dms.NewGPXSource(f)
+//	for c := range src.Coords() {
+//		...
+//	}
+//	if err := src.Err(); err != nil {
+//		...
+//	}
+//
+// A Source reads its input once; breaking out of the loop stops reading.
+type Source struct {
+	walk func(yield func(Waypoint) bool) error
+	err  error
+}
+
+// Waypoints returns an iterator over the waypoints of the source. Iteration
+// stops at the first error, which is then returned by Err.
+func (s *Source) Waypoints() iter.Seq[Waypoint] {
+	return func(yield func(Waypoint) bool) {
+		if err := s.walk(yield); err != nil && s.err == nil {
+			s.err = err
+		}
+	}
+}
+
+// Coords returns an iterator over the coordinates of the waypoints of the
+// source.
+func (s *Source) Coords() iter.Seq[Coordinate] {
+	return func(yield func(Coordinate) bool) {
+		for w := range s.Waypoints() {
+			if !yield(w.Coordinate) {
+				return
+			}
+		}
+	}
+}
+
+// Fixes returns an iterator over the waypoints of the source as fixes.
+func (s *Source) Fixes() iter.Seq[Fix] {
+	return func(yield func(Fix) bool) {
+		for w := range s.Waypoints() {
+			if !yield(Fix{Coordinate: w.Coordinate, Time: w.Time}) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error met while iterating, if any.
+func (s *Source) Err() error {
+	return s.err
+}
+
+// NewCSVSource returns a source reading CSV waypoints in the layout accepted
+// by ReadWaypointsCSV.
+func NewCSVSource(r io.Reader) *Source {
+	return &Source{walk: func(yield func(Waypoint) bool) error {
+		return walkWaypointsCSV(r, nil, yield)
+	}}
+}
+
+// NewCSVSourceWithSchema returns a source reading CSV waypoints laid out as
+// schema.
+func NewCSVSourceWithSchema(r io.Reader, schema CSVSchema) *Source {
+	return &Source{walk: func(yield func(Waypoint) bool) error {
+		return walkWaypointsCSV(r, &schema, yield)
+	}}
+}
+
+// NewGPXSource returns a source reading the waypoints, route points and track
+// points of a GPX file, in document order.
+func NewGPXSource(r io.Reader) *Source {
+	return &Source{walk: func(yield func(Waypoint) bool) error {
+		d := xml.NewDecoder(r)
+		for {
+			tok, err := d.Token()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			start, ok := tok.(xml.StartElement)
+			if !ok {
+				continue
+			}
+			switch start.Name.Local {
+			case "wpt", "rtept", "trkpt":
+				var p gpxPoint
+				if err := d.DecodeElement(&p, &start); err != nil {
+					return err
+				}
+				w, err := p.waypoint()
+				if err != nil {
+					return err
+				}
+				if !yield(w) {
+					return nil
+				}
+			}
+		}
+	}}
+}
+
+// NewNMEASource returns a source reading the fixes of the GGA, RMC and GLL
+// sentences of an NMEA 0183 log, one sentence per line. Other sentences,
+// sentences without a valid fix and sentences with a bad checksum are
+// skipped. Times of GGA and GLL fixes take the date of the last RMC sentence.
+func NewNMEASource(r io.Reader) *Source {
+	return &Source{walk: func(yield func(Waypoint) bool) error {
+		scanner := bufio.NewScanner(r)
+		var date time.Time
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			fix, err := ParseNMEA(line)
+			if err != nil {
+				continue
+			}
+			if fix.Time.Year() == 0 {
+				if !date.IsZero() {
+					fix.Time = fix.Time.AddDate(date.Year(), int(date.Month())-1, date.Day()-1)
+				}
+			} else {
+				date = fix.Time
+			}
+			if !yield(Waypoint{Coordinate: fix.Coordinate, Time: fix.Time}) {
+				return nil
+			}
+		}
+		return scanner.Err()
+	}}
+}
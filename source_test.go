@@ -0,0 +1,142 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNMEA(t *testing.T) {
+	tests := []struct {
+		sentence string
+		lat, lon float64
+		time     time.Time
+	}{
+		{"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47",
+			48.1173, 11.516667, time.Date(0, 1, 1, 12, 35, 19, 0, time.UTC)},
+		{"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A",
+			48.1173, 11.516667, time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC)},
+		{"$GPGLL,4916.45,N,12311.12,W,225444,A,*1D",
+			49.274167, -123.185333, time.Date(0, 1, 1, 22, 54, 44, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		fix, err := ParseNMEA(tt.sentence)
+		if err != nil {
+			t.Errorf("ParseNMEA(%q) error: %v", tt.sentence, err)
+			continue
+		}
+		lat, lon := fix.Coordinate.Decimal()
+		if math.Abs(lat-tt.lat) > 1e-6 || math.Abs(lon-tt.lon) > 1e-6 || !fix.Time.Equal(tt.time) {
+			t.Errorf("ParseNMEA(%q) = %v, %v, %v, want %v, %v, %v", tt.sentence, lat, lon, fix.Time, tt.lat, tt.lon, tt.time)
+		}
+	}
+}
+
+func TestParseNMEAMalformed(t *testing.T) {
+	tests := []string{
+		"",
+		"GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47",
+		"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*00",
+		"$GPGGA,123519,4807.038,N,01131.000,E,0,08,0.9,545.4,M,46.9,M,,*46",
+		"$GPRMC,123519,V,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*7D",
+		"$GPGSV,2,1,08,01,40,083,46,02,17,308,41,12,07,344,39,14,22,228,45*75",
+	}
+	for _, s := range tests {
+		if fix, err := ParseNMEA(s); err == nil {
+			t.Errorf("ParseNMEA(%q) = %+v, want error", s, fix)
+		}
+	}
+}
+
+func TestNMEASource(t *testing.T) {
+	const log = `$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A
+$GPGSV,2,1,08,01,40,083,46,02,17,308,41,12,07,344,39,14,22,228,45*75
+$GPGGA,123520,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*4D
+$GPGGA,123521,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*00
+garbage
+$GPGLL,4916.45,N,12311.12,W,225444,A,*1D
+`
+	src := NewNMEASource(strings.NewReader(log))
+	var times []time.Time
+	for f := range src.Fixes() {
+		times = append(times, f.Time)
+	}
+	if err := src.Err(); err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(1994, 3, 23, 0, 0, 0, 0, time.UTC)
+	want := []time.Time{
+		day.Add(12*time.Hour + 35*time.Minute + 19*time.Second),
+		day.Add(12*time.Hour + 35*time.Minute + 20*time.Second),
+		day.Add(22*time.Hour + 54*time.Minute + 44*time.Second),
+	}
+	if len(times) != len(want) {
+		t.Fatalf("NMEA source returned %d fixes, want %d", len(times), len(want))
+	}
+	for i := range want {
+		if !times[i].Equal(want[i]) {
+			t.Errorf("fix %d time = %v, want %v", i, times[i], want[i])
+		}
+	}
+}
+
+func TestGPXSource(t *testing.T) {
+	const doc = `<gpx>
+  <wpt lat="1" lon="1"><name>A</name></wpt>
+  <rte><rtept lat="2" lon="2"/></rte>
+  <trk><trkseg><trkpt lat="3" lon="3"/><trkpt lat="4" lon="4"/></trkseg></trk>
+</gpx>`
+	src := NewGPXSource(strings.NewReader(doc))
+	var lats []float64
+	for c := range src.Coords() {
+		lat, _ := c.Decimal()
+		lats = append(lats, lat)
+	}
+	if err := src.Err(); err != nil || len(lats) != 4 || lats[0] != 1 || lats[3] != 4 {
+		t.Errorf("GPX source = %v, %v, want latitudes 1 to 4", lats, err)
+	}
+
+	// Breaking out of the loop stops before the malformed point.
+	src = NewGPXSource(strings.NewReader(`<gpx><wpt lat="1" lon="1"/><wpt lat="x" lon="1"/></gpx>`))
+	for range src.Coords() {
+		break
+	}
+	if err := src.Err(); err != nil {
+		t.Errorf("GPX source error after break: %v", err)
+	}
+	src = NewGPXSource(strings.NewReader(`<gpx><wpt lat="1" lon="1"/><wpt lat="x" lon="1"/></gpx>`))
+	n := 0
+	for range src.Coords() {
+		n++
+	}
+	if n != 1 || src.Err() == nil {
+		t.Errorf("GPX source returned %d points and error %v, want 1 point and an error", n, src.Err())
+	}
+}
+
+func TestCSVSource(t *testing.T) {
+	src := NewCSVSource(strings.NewReader("name,lat,lon\nA,1,2\nB,3,4\nC,95,4\n"))
+	var names []string
+	for w := range src.Waypoints() {
+		names = append(names, w.Name)
+	}
+	if len(names) != 2 || names[0] != "A" || names[1] != "B" || src.Err() == nil {
+		t.Errorf("CSV source = %v, %v, want A, B and an error", names, src.Err())
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Speed units
+
+// Speed is a speed in meters per second.
+type Speed float64
+
+// SpeedUnit identifies a unit of speed.
+type SpeedUnit int
+
+const (
+	UnitMetersPerSecond   SpeedUnit = iota // Meters per second.
+	UnitKilometersPerHour                  // Kilometers per hour.
+	UnitKnot                               // Knots, nautical miles per hour, used at sea and in aviation.
+	UnitMilesPerHour                       // Statute miles per hour.
+)
+
+// speedUnitSymbols holds the symbols of the speed units.
+var speedUnitSymbols = [...]string{
+	UnitMetersPerSecond:   "m/s",
+	UnitKilometersPerHour: "km/h",
+	UnitKnot:              "kn",
+	UnitMilesPerHour:      "mph",
+}
+
+// metersPerSecond returns the speed of one unit in meters per second.
+func (u SpeedUnit) metersPerSecond() float64 {
+	switch u {
+	case UnitKilometersPerHour:
+		return 1000.0 / 3600
+	case UnitKnot:
+		return 1852.0 / 3600
+	case UnitMilesPerHour:
+		return 1609.344 / 3600
+	}
+	return 1
+}
+
+// String returns the symbol of the unit, e.g. "kn".
+func (u SpeedUnit) String() string {
+	if u >= 0 && int(u) < len(speedUnitSymbols) {
+		return speedUnitSymbols[u]
+	}
+	return fmt.Sprintf("SpeedUnit(%d)", int(u))
+}
+
+// ParseSpeedUnit returns the unit with the given symbol (m/s, km/h, kn, mph)
+// or name ("knots", "miles per hour"...), ignoring case.
+func ParseSpeedUnit(s string) (SpeedUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "m/s", "mps", "meters per second", "metres per second":
+		return UnitMetersPerSecond, nil
+	case "km/h", "kmh", "kph", "kilometers per hour", "kilometres per hour":
+		return UnitKilometersPerHour, nil
+	case "kn", "kt", "kts", "knot", "knots":
+		return UnitKnot, nil
+	case "mph", "mi/h", "miles per hour":
+		return UnitMilesPerHour, nil
+	}
+	return 0, fmt.Errorf("Unknown speed unit %q", s)
+}
+
+// NewSpeed returns the speed of a value in the given unit.
+func NewSpeed(value float64, unit SpeedUnit) Speed {
+	return Speed(value * unit.metersPerSecond())
+}
+
+// SpeedOver returns the average speed covering a distance in meters in the
+// elapsed time. It is zero when no time elapsed.
+func SpeedOver(distance float64, elapsed time.Duration) Speed {
+	if elapsed <= 0 {
+		return 0
+	}
+	return Speed(distance / elapsed.Seconds())
+}
+
+// In returns the speed as a value in the given unit.
+func (s Speed) In(unit SpeedUnit) float64 {
+	return float64(s) / unit.metersPerSecond()
+}
+
+// MetersPerSecond returns the speed in meters per second.
+func (s Speed) MetersPerSecond() float64 {
+	return float64(s)
+}
+
+// KilometersPerHour returns the speed in kilometers per hour.
+func (s Speed) KilometersPerHour() float64 {
+	return s.In(UnitKilometersPerHour)
+}
+
+// Knots returns the speed in knots.
+func (s Speed) Knots() float64 {
+	return s.In(UnitKnot)
+}
+
+// MilesPerHour returns the speed in statute miles per hour.
+func (s Speed) MilesPerHour() float64 {
+	return s.In(UnitMilesPerHour)
+}
+
+// Format returns the speed in the given unit with the given number of
+// decimals and the unit symbol, e.g. "12.5 kn".
+func (s Speed) Format(unit SpeedUnit, decimals int) string {
+	return fmt.Sprintf("%.*f %s", max(decimals, 0), s.In(unit), unit)
+}
+
+// String returns the speed in meters per second with two decimals.
+func (s Speed) String() string {
+	return s.Format(UnitMetersPerSecond, 2)
+}
+
+// Distance returns the distance in meters covered at the speed in the
+// elapsed time.
+func (s Speed) Distance(elapsed time.Duration) float64 {
+	return float64(s) * elapsed.Seconds()
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// SRTM tiles
+//
+// SRTM elevation data is distributed in 1°×1° tiles named after the
+// south-west corner of the tile, e.g. "N40W080.hgt".
+
+// SRTM coverage limits in degrees of latitude.
+const (
+	srtmNorthLimit = 60
+	srtmSouthLimit = -56
+)
+
+// srtmTilePattern matches an SRTM tile name with an optional .hgt extension.
+var srtmTilePattern = regexp.MustCompile(`^(?i)([NS])(\d{2})([EW])(\d{3})(?:\.hgt)?$`)
+
+// SRTMTile returns the name of the SRTM tile containing the coordinate.
+func SRTMTile(c Coordinate) string {
+	lat, lon := c.Decimal()
+	south := int(math.Floor(lat))
+	west := int(math.Floor(lon))
+	if south == 90 {
+		south = 89
+	}
+	if west == 180 {
+		west = -180
+	}
+	ns, ew := "N", "E"
+	if south < 0 {
+		ns = "S"
+	}
+	if west < 0 {
+		ew = "W"
+	}
+	return fmt.Sprintf("%s%02d%s%03d.hgt", ns, abs(south), ew, abs(west))
+}
+
+// ParseSRTMTile returns the south-west corner of the tile with the given name.
+// The .hgt extension is optional.
+func ParseSRTMTile(name string) (Coordinate, error) {
+	m := srtmTilePattern.FindStringSubmatch(name)
+	if m == nil {
+		return Coordinate{}, fmt.Errorf("Invalid SRTM tile name %q", name)
+	}
+	lat, _ := strconv.Atoi(m[2])
+	lon, _ := strconv.Atoi(m[4])
+	if m[1] == "S" || m[1] == "s" {
+		lat = -lat
+	}
+	if m[3] == "W" || m[3] == "w" {
+		lon = -lon
+	}
+	if lat > 89 || lon < -180 || lon > 179 {
+		return Coordinate{}, fmt.Errorf("Invalid SRTM tile name %q", name)
+	}
+	return NewCoordinate(float64(lat), float64(lon))
+}
+
+// SRTMCovers reports whether the coordinate lies within the SRTM coverage
+// area, between 56°S and 60°N. Tiles over open ocean are not distributed even
+// within that range.
+func SRTMCovers(c Coordinate) bool {
+	lat, _ := c.Decimal()
+	return lat >= srtmSouthLimit && lat < srtmNorthLimit
+}
+
+// SRTMGrid returns the sample grid of the named tile at the given sample
+// spacing, 1" for SRTM1 (3601×3601 samples) or 3" for SRTM3 (1201×1201).
+// Samples lie on the tile edges, so cell (row, col) is centered on sample
+// (row, col) of the .hgt file.
+func SRTMGrid(name string, spacing Resolution) (Grid, error) {
+	corner, err := ParseSRTMTile(name)
+	if err != nil {
+		return Grid{}, err
+	}
+	if spacing <= 0 || math.Mod(3600, float64(spacing)) != 0 {
+		return Grid{}, fmt.Errorf("Invalid SRTM sample spacing %v", spacing)
+	}
+	lat, lon := corner.Decimal()
+	half := spacing.Degrees() / 2
+	origin := coordinateFromDecimal(lat+1+half, lon-half)
+	samples := int(3600/spacing) + 1
+	return Grid{Origin: origin, CellHeight: spacing, CellWidth: spacing, Rows: samples, Cols: samples}, nil
+}
+
+// abs returns the absolute value of an integer.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
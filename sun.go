@@ -0,0 +1,165 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Solar position
+//
+// The position of the Sun follows the low-precision algorithms of Meeus,
+// Astronomical Algorithms, accurate to about 0.01°.
+
+// Horizontal is a direction in the horizontal frame of an observer.
+type Horizontal struct {
+	Azimuth   Angle // Clockwise from true north, in [0°, 360°).
+	Elevation Angle // Above the horizon, negative below it.
+}
+
+// String returns the azimuth and elevation in DMS notation.
+func (h Horizontal) String() string {
+	return fmt.Sprintf("az %s el %s", h.Azimuth.String(), h.Elevation.String())
+}
+
+// julianCenturies returns the Julian day of t and the Julian centuries since
+// J2000.0.
+func julianCenturies(t time.Time) (jd, centuries float64) {
+	jd = float64(t.UnixNano())/86400e9 + 2440587.5
+	return jd, (jd - 2451545) / 36525
+}
+
+// siderealTime returns the Greenwich mean sidereal time of t in degrees.
+func siderealTime(t time.Time) float64 {
+	jd, c := julianCenturies(t)
+	return Normalize360(280.46061837 + 360.98564736629*(jd-2451545) + c*c*(0.000387933-c/38710000))
+}
+
+// obliquity returns the apparent obliquity of the ecliptic in degrees, and
+// the longitude of the ascending node of the Moon used to correct it.
+func obliquity(c float64) (epsilon, node float64) {
+	node = 125.04 - 1934.136*c
+	epsilon = 23 + (26+(21.448-c*(46.815+c*(0.00059-c*0.001813)))/60)/60
+	return epsilon + 0.00256*math.Cos(node*degToRad), node
+}
+
+// equatorialFromEcliptic returns the right ascension and declination in
+// degrees of ecliptic longitude and latitude, given the obliquity.
+func equatorialFromEcliptic(lambda, beta, epsilon float64) (ra, dec float64) {
+	l, b, e := lambda*degToRad, beta*degToRad, epsilon*degToRad
+	ra = math.Atan2(math.Sin(l)*math.Cos(e)-math.Tan(b)*math.Sin(e), math.Cos(l))
+	dec = math.Asin(math.Sin(b)*math.Cos(e) + math.Cos(b)*math.Sin(e)*math.Sin(l))
+	return Normalize360(ra / degToRad), dec / degToRad
+}
+
+// sunEquatorial returns the apparent right ascension and declination of the
+// Sun in degrees, and the equation of time in minutes.
+func sunEquatorial(t time.Time) (ra, dec, equationOfTime float64) {
+	_, c := julianCenturies(t)
+	l0 := Normalize360(280.46646 + c*(36000.76983+c*0.0003032))
+	m := (357.52911 + c*(35999.05029-c*0.0001537)) * degToRad
+	center := math.Sin(m)*(1.914602-c*(0.004817+c*0.000014)) +
+		math.Sin(2*m)*(0.019993-c*0.000101) + math.Sin(3*m)*0.000289
+	epsilon, node := obliquity(c)
+	lambda := l0 + center - 0.00569 - 0.00478*math.Sin(node*degToRad)
+	ra, dec = equatorialFromEcliptic(lambda, 0, epsilon)
+	equationOfTime = 4 * Normalize180(l0-0.0057183-ra+0.00478*math.Sin(node*degToRad)*math.Cos(epsilon*degToRad))
+	return ra, dec, equationOfTime
+}
+
+// subPoint returns the point of the Earth where a body of the given right
+// ascension and declination is at the zenith at t.
+func subPoint(t time.Time, ra, dec float64) Coordinate {
+	return coordinateFromDecimal(dec, Normalize180(ra-siderealTime(t)))
+}
+
+// horizontalAt returns the direction of a body of the given right ascension
+// and declination seen from c at t, without refraction.
+func horizontalAt(c Coordinate, t time.Time, ra, dec float64) Horizontal {
+	lat, lon := c.radians()
+	h := (siderealTime(t)-ra)*degToRad + lon
+	d := dec * degToRad
+	elevation := math.Asin(math.Sin(lat)*math.Sin(d) + math.Cos(lat)*math.Cos(d)*math.Cos(h))
+	azimuth := math.Atan2(-math.Sin(h), math.Tan(d)*math.Cos(lat)-math.Sin(lat)*math.Cos(h))
+	return Horizontal{
+		Azimuth:   NewAngle(azimuth/degToRad, Wrap360),
+		Elevation: NewAngle(elevation/degToRad, WrapNone),
+	}
+}
+
+// SubsolarPoint returns the point of the Earth where the Sun is at the zenith
+// at t.
+func SubsolarPoint(t time.Time) Coordinate {
+	ra, dec, _ := sunEquatorial(t)
+	return subPoint(t, ra, dec)
+}
+
+// SunPosition returns the direction of the center of the Sun seen from c at
+// t. The elevation is geometric, without atmospheric refraction.
+func SunPosition(c Coordinate, t time.Time) Horizontal {
+	ra, dec, _ := sunEquatorial(t)
+	return horizontalAt(c, t, ra, dec)
+}
+
+// sunriseElevation is the elevation in degrees of the center of the Sun at
+// sunrise and sunset: the upper limb touches the horizon, with the mean
+// refraction.
+const sunriseElevation = -0.833
+
+// solarNoon returns the transit of the Sun over the meridian of c nearest to
+// noon of the calendar day of date, in its location.
+func solarNoon(c Coordinate, date time.Time) time.Time {
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, date.Location())
+	lon := signedDecimal(c.Longitude)
+	u := noon.UTC()
+	day := time.Date(u.Year(), u.Month(), u.Day(), 12, 0, 0, 0, time.UTC)
+	t := day.Add(time.Duration(-lon / 15 * float64(time.Hour)))
+	for t.Sub(noon) > 12*time.Hour {
+		t, day = t.Add(-24*time.Hour), day.Add(-24*time.Hour)
+	}
+	for noon.Sub(t) > 12*time.Hour {
+		t, day = t.Add(24*time.Hour), day.Add(24*time.Hour)
+	}
+	for i := 0; i < 2; i++ {
+		_, _, equationOfTime := sunEquatorial(t)
+		t = day.Add(time.Duration((-lon*4 - equationOfTime) * float64(time.Minute)))
+	}
+	return t.In(date.Location())
+}
+
+// sunElevationTime returns when the center of the Sun is at elevation
+// degrees in the morning or afternoon of the day of the given solar noon, or
+// false when it does not reach that elevation on that day.
+func sunElevationTime(c Coordinate, noon time.Time, elevation float64, morning bool) (time.Time, bool) {
+	lat := signedDecimal(c.Latitude) * degToRad
+	t := noon
+	for i := 0; i < 3; i++ {
+		_, dec, _ := sunEquatorial(t)
+		d := dec * degToRad
+		cosH := (math.Sin(elevation*degToRad) - math.Sin(lat)*math.Sin(d)) / (math.Cos(lat) * math.Cos(d))
+		if cosH < -1 || cosH > 1 {
+			return time.Time{}, false
+		}
+		offset := time.Duration(math.Acos(cosH) / degToRad / 15 * float64(time.Hour))
+		if morning {
+			offset = -offset
+		}
+		t = noon.Add(offset)
+	}
+	return t, true
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// NamedCoordinate is a coordinate labeled with a name.
+type NamedCoordinate struct {
+	Name       string     // Label of the coordinate.
+	Coordinate Coordinate // Position of the coordinate.
+}
+
+// TableColumn selects a column of a coordinate table.
+type TableColumn int
+
+const (
+	ColumnName    TableColumn = iota // Name of the coordinate.
+	ColumnDecimal                    // Signed decimal degrees.
+	ColumnDMS                        // Degrees, minutes and seconds.
+	ColumnDDM                        // Degrees and decimal minutes.
+	ColumnUTM                        // UTM zone, band, easting and northing.
+)
+
+// TableFormat selects the output format of a coordinate table.
+type TableFormat int
+
+const (
+	TableText     TableFormat = iota // Aligned plain text columns.
+	TableCSV                         // Comma-separated values with a header row.
+	TableMarkdown                    // Markdown pipe table.
+)
+
+// defaultTableColumns are the columns rendered when none are selected.
+var defaultTableColumns = []TableColumn{ColumnName, ColumnDecimal, ColumnDMS}
+
+// header returns the column heading.
+func (col TableColumn) header() string {
+	switch col {
+	case ColumnName:
+		return "Name"
+	case ColumnDecimal:
+		return "Decimal"
+	case ColumnDMS:
+		return "DMS"
+	case ColumnDDM:
+		return "DDM"
+	case ColumnUTM:
+		return "UTM"
+	}
+	return ""
+}
+
+// cell returns the column value for a named coordinate.
+func (col TableColumn) cell(nc NamedCoordinate) string {
+	c := nc.Coordinate
+	switch col {
+	case ColumnName:
+		return nc.Name
+	case ColumnDecimal:
+		lat, lon := c.Decimal()
+		return fmt.Sprintf("%.6f, %.6f", lat, lon)
+	case ColumnDMS:
+		return c.String()
+	case ColumnDDM:
+		return c.Latitude.StringDDM() + " " + c.Longitude.StringDDM()
+	case ColumnUTM:
+		if utm, err := c.UTM(); err == nil {
+			return utm.String()
+		}
+	}
+	return ""
+}
+
+// RenderTable writes the coordinates as a table in the given format. When no
+// columns are given, the name, decimal and DMS columns are rendered.
+func RenderTable(w io.Writer, coords []NamedCoordinate, format TableFormat, columns ...TableColumn) error {
+	if len(columns) == 0 {
+		columns = defaultTableColumns
+	}
+	rows := make([][]string, 0, len(coords)+1)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.header()
+	}
+	rows = append(rows, header)
+	for _, nc := range coords {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = col.cell(nc)
+		}
+		rows = append(rows, row)
+	}
+	return writeTable(w, rows, format)
+}
+
+// writeTable writes rows, the first of which holds the headings, as a table
+// in the given format.
+func writeTable(w io.Writer, rows [][]string, format TableFormat) error {
+	switch format {
+	case TableCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case TableMarkdown:
+		var b strings.Builder
+		for i, row := range rows {
+			for j := range row {
+				row[j] = strings.ReplaceAll(row[j], "|", `\|`)
+			}
+			b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+			if i == 0 {
+				b.WriteString("|" + strings.Repeat(" --- |", len(row)) + "\n")
+			}
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, row := range rows {
+			if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	}
+}
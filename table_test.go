@@ -0,0 +1,79 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+// tableCoordinates holds the rows of the table tests, with names needing
+// escaping in CSV and Markdown.
+var tableCoordinates = []NamedCoordinate{
+	{"Pitt|sburgh", coordinateFromDecimal(40.446195, -79.948862)},
+	{"Sydney, NSW", coordinateFromDecimal(-33.8568, 151.2153)},
+}
+
+func TestRenderTable(t *testing.T) {
+	tests := []struct {
+		format TableFormat
+		want   string
+	}{
+		{TableText, `Name         Decimal                 DMS
+Pitt|sburgh  40.446195, -79.948862   40°26'46.30" N 79°56'55.90" W
+Sydney, NSW  -33.856800, 151.215300  33°51'24.48" S 151°12'55.08" E
+`},
+		{TableMarkdown, `| Name | Decimal | DMS |
+| --- | --- | --- |
+| Pitt\|sburgh | 40.446195, -79.948862 | 40°26'46.30" N 79°56'55.90" W |
+| Sydney, NSW | -33.856800, 151.215300 | 33°51'24.48" S 151°12'55.08" E |
+`},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := RenderTable(&b, tableCoordinates, tt.format); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("RenderTable(%d) =\n%s\nwant\n%s", tt.format, b.String(), tt.want)
+		}
+	}
+}
+
+func TestRenderTableCSV(t *testing.T) {
+	var b strings.Builder
+	if err := RenderTable(&b, tableCoordinates, TableCSV, ColumnName, ColumnDDM, ColumnUTM); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"Name", "DDM", "UTM"},
+		{"Pitt|sburgh", "40°26.772' N 79°56.932' W", "17T 589139 4477813"},
+		{"Sydney, NSW", "33°51.408' S 151°12.918' E", "56H 334901 6252289"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("RenderTable wrote %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("record %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Web Mercator tiles
+
+const (
+	tileSize        = 256             // Width and height of a map tile in pixels.
+	mercatorMaxLat  = 85.051128779807 // Latitude of the top edge of the Web Mercator square.
+	maxTileZoom     = 30              // Highest zoom level with in-range tile indices.
+	tileURLTemplate = "https://tile.openstreetmap.org/{z}/{x}/{y}.png"
+)
+
+// Tile is a Web Mercator (slippy map) tile: 256-pixel squares numbered from
+// the north-west corner, 2^Zoom of them across.
+type Tile struct {
+	Zoom, X, Y int
+}
+
+// mercatorPixel returns the position of c at zoom in global pixels.
+func mercatorPixel(c Coordinate, zoom int) (x, y float64) {
+	lat, lon := c.Decimal()
+	lat = math.Max(-mercatorMaxLat, math.Min(mercatorMaxLat, lat)) * degToRad
+	size := tileSize * math.Exp2(float64(zoom))
+	x = (lon + 180) / 360 * size
+	y = (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * size
+	return x, y
+}
+
+// mercatorDecimal returns the latitude and longitude of global pixels at
+// zoom, in decimal degrees.
+func mercatorDecimal(x, y float64, zoom int) (lat, lon float64) {
+	size := tileSize * math.Exp2(float64(zoom))
+	lon = Normalize180(x/size*360 - 180)
+	y = math.Max(0, math.Min(size, y))
+	lat = math.Atan(math.Sinh(math.Pi*(1-2*y/size))) / degToRad
+	return lat, lon
+}
+
+// TileAt returns the tile containing c at zoom, clamped to 0–30.
+func TileAt(c Coordinate, zoom int) Tile {
+	zoom = max(0, min(maxTileZoom, zoom))
+	x, y := mercatorPixel(c, zoom)
+	n := 1 << zoom
+	return Tile{
+		Zoom: zoom,
+		X:    min(n-1, int(x/tileSize)) % n,
+		Y:    max(0, min(n-1, int(y/tileSize))),
+	}
+}
+
+// Bounds returns the area covered by the tile.
+func (t Tile) Bounds() BoundingBox {
+	north, west := mercatorDecimal(float64(t.X*tileSize), float64(t.Y*tileSize), t.Zoom)
+	south, east := mercatorDecimal(float64((t.X+1)*tileSize), float64((t.Y+1)*tileSize), t.Zoom)
+	if t.X+1 == 1<<t.Zoom {
+		east = 180
+	}
+	return BoundingBox{South: south, West: west, North: north, East: east}
+}
+
+// URL returns the URL of the tile from a template with {z}, {x} and {y}
+// placeholders, the OpenStreetMap tile server when the template is empty.
+func (t Tile) URL(template string) string {
+	if template == "" {
+		template = tileURLTemplate
+	}
+	return strings.NewReplacer(
+		"{z}", strconv.Itoa(t.Zoom),
+		"{x}", strconv.Itoa(t.X),
+		"{y}", strconv.Itoa(t.Y),
+	).Replace(template)
+}
+
+// String returns the tile as "zoom/x/y".
+func (t Tile) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Zoom, t.X, t.Y)
+}
+
+// StaticMapBounds returns the area shown by a Web Mercator map image of width
+// by height pixels centered on c at zoom, as requested from static map
+// services. The box crosses the antimeridian when West is greater than East.
+func StaticMapBounds(c Coordinate, zoom, width, height int) BoundingBox {
+	x, y := mercatorPixel(c, zoom)
+	dx, dy := float64(width)/2, float64(height)/2
+	north, west := mercatorDecimal(x-dx, y-dy, zoom)
+	south, east := mercatorDecimal(x+dx, y+dy, zoom)
+	if float64(width) >= tileSize*math.Exp2(float64(zoom)) {
+		west, east = -180, 180
+	}
+	return BoundingBox{South: south, West: west, North: north, East: east}
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTileAt(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		zoom     int
+		want     string
+	}{
+		{52.52, 13.405, 10, "10/550/335"},
+		{40.446195, -79.948862, 15, "15/9106/12352"},
+		{0, 0, 0, "0/0/0"},
+		{89, -180, 3, "3/0/0"},
+		{-89, 179.9999, 3, "3/7/7"},
+		{0, 0, 40, "30/536870912/536870912"},
+	}
+	for _, tt := range tests {
+		c := coordinateFromDecimal(tt.lat, tt.lon)
+		tile := TileAt(c, tt.zoom)
+		if got := tile.String(); got != tt.want {
+			t.Errorf("TileAt(%v, %v, %d) = %s, want %s", tt.lat, tt.lon, tt.zoom, got, tt.want)
+			continue
+		}
+		if b := tile.Bounds(); math.Abs(tt.lat) < mercatorMaxLat && !b.Contains(c) {
+			t.Errorf("TileAt(%v, %v, %d).Bounds() = %+v, does not contain the position", tt.lat, tt.lon, tt.zoom, b)
+		}
+	}
+	tile := Tile{Zoom: 10, X: 550, Y: 335}
+	if got, want := tile.URL(""), "https://tile.openstreetmap.org/10/550/335.png"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+	if got, want := tile.URL("https://example.com/{z}-{x}-{y}-{x}.jpg"), "https://example.com/10-550-335-550.jpg"; got != want {
+		t.Errorf("URL(template) = %q, want %q", got, want)
+	}
+}
+
+func TestStaticMapBounds(t *testing.T) {
+	c := coordinateFromDecimal(0, 0)
+	b := StaticMapBounds(c, 1, 256, 256)
+	if math.Abs(b.West+90) > 1e-9 || math.Abs(b.East-90) > 1e-9 || math.Abs(b.North+b.South) > 1e-9 || b.North < 66 {
+		t.Errorf("StaticMapBounds(0, 0, zoom 1) = %+v", b)
+	}
+	b = StaticMapBounds(coordinateFromDecimal(10, 179), 4, 256, 256)
+	if b.West <= b.East {
+		t.Errorf("StaticMapBounds across the antimeridian = %+v, want West > East", b)
+	}
+	b = StaticMapBounds(c, 0, 512, 256)
+	if b.West != -180 || b.East != 180 {
+		t.Errorf("StaticMapBounds wider than the world = %+v, want all longitudes", b)
+	}
+}
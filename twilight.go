@@ -0,0 +1,166 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Twilight and photographic hours
+
+// TimeWindow is a period of time, zero when it does not occur.
+type TimeWindow struct {
+	Start, End time.Time
+}
+
+// IsZero reports whether the window does not occur.
+func (w TimeWindow) IsZero() bool {
+	return w.Start.IsZero() && w.End.IsZero()
+}
+
+// Duration returns the length of the window.
+func (w TimeWindow) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Contains reports whether t is within the window.
+func (w TimeWindow) Contains(t time.Time) bool {
+	return !w.IsZero() && !t.Before(w.Start) && t.Before(w.End)
+}
+
+// String returns the start and end of the window as "15:04–15:04".
+func (w TimeWindow) String() string {
+	if w.IsZero() {
+		return "none"
+	}
+	return w.Start.Format("15:04") + "–" + w.End.Format("15:04")
+}
+
+// SunPhase is a period of the day defined by the elevation of the Sun.
+type SunPhase int
+
+const (
+	CivilTwilight        SunPhase = iota // Sun between 6° below the horizon and sunrise or sunset.
+	NauticalTwilight                     // Sun between 12° and 6° below the horizon.
+	AstronomicalTwilight                 // Sun between 18° and 12° below the horizon.
+	GoldenHour                           // Sun between 4° below and 6° above the horizon, warm light.
+	BlueHour                             // Sun between 6° and 4° below the horizon, blue light.
+)
+
+// sunPhaseNames maps sun phases to their names.
+var sunPhaseNames = map[SunPhase]string{
+	CivilTwilight:        "civil-twilight",
+	NauticalTwilight:     "nautical-twilight",
+	AstronomicalTwilight: "astronomical-twilight",
+	GoldenHour:           "golden-hour",
+	BlueHour:             "blue-hour",
+}
+
+// sunPhaseElevations holds the lowest and highest elevations of the Sun in
+// degrees of each phase.
+var sunPhaseElevations = map[SunPhase][2]float64{
+	CivilTwilight:        {-6, sunriseElevation},
+	NauticalTwilight:     {-12, -6},
+	AstronomicalTwilight: {-18, -12},
+	GoldenHour:           {-4, 6},
+	BlueHour:             {-6, -4},
+}
+
+// String returns the name of the phase, e.g. "golden-hour".
+func (p SunPhase) String() string {
+	if name, ok := sunPhaseNames[p]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseSunPhase returns the phase with the given name, as returned by
+// SunPhase.String.
+func ParseSunPhase(name string) (SunPhase, error) {
+	for p, s := range sunPhaseNames {
+		if strings.EqualFold(s, name) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown sun phase %q", name)
+}
+
+// SunPhaseWindows returns the morning and evening windows of a phase at c on
+// the calendar day of date, in the location of date. When the Sun stays
+// within the phase past solar midnight, as in summer at high latitudes, the
+// windows are cut at solar midnight; when it culminates within the phase, the
+// morning window spans the whole phase and the evening window is zero.
+func SunPhaseWindows(c Coordinate, date time.Time, phase SunPhase) (morning, evening TimeWindow) {
+	bounds, ok := sunPhaseElevations[phase]
+	if !ok {
+		return TimeWindow{}, TimeWindow{}
+	}
+	noon := solarNoon(c, date)
+	lowMorning, lowOK := sunElevationTime(c, noon, bounds[0], true)
+	lowEvening, _ := sunElevationTime(c, noon, bounds[0], false)
+	highMorning, highOK := sunElevationTime(c, noon, bounds[1], true)
+	highEvening, _ := sunElevationTime(c, noon, bounds[1], false)
+	peak := SunPosition(c, noon).Elevation
+	if !lowOK {
+		if !highOK || peak.Decimal() < bounds[0] {
+			return TimeWindow{}, TimeWindow{}
+		}
+		lowMorning, lowEvening = noon.Add(-12*time.Hour), noon.Add(12*time.Hour)
+	}
+	if !highOK {
+		if peak.Decimal() > bounds[1] {
+			return TimeWindow{}, TimeWindow{}
+		}
+		return TimeWindow{lowMorning, lowEvening}, TimeWindow{}
+	}
+	return TimeWindow{lowMorning, highMorning}, TimeWindow{highEvening, lowEvening}
+}
+
+// Day length
+
+// Daylight describes the daylight of a day at a place.
+type Daylight struct {
+	Sunrise, Sunset time.Time     // Zero during the midnight sun and the polar night.
+	Length          time.Duration // Time the Sun is above the horizon.
+	MidnightSun     bool          // The Sun does not set.
+	PolarNight      bool          // The Sun does not rise.
+}
+
+// DaylightOn returns the daylight at c on the calendar day of date, in the
+// location of date. At the poles and beyond the polar circles, days without
+// sunrise or sunset are flagged as midnight sun or polar night.
+func DaylightOn(c Coordinate, date time.Time) Daylight {
+	noon := solarNoon(c, date)
+	sunrise, ok := sunElevationTime(c, noon, sunriseElevation, true)
+	if !ok {
+		peak := SunPosition(c, noon).Elevation
+		if peak.Decimal() > sunriseElevation {
+			return Daylight{Length: 24 * time.Hour, MidnightSun: true}
+		}
+		return Daylight{PolarNight: true}
+	}
+	sunset, _ := sunElevationTime(c, noon, sunriseElevation, false)
+	return Daylight{Sunrise: sunrise, Sunset: sunset, Length: sunset.Sub(sunrise)}
+}
+
+// DayLength returns the time the Sun is above the horizon at c on the
+// calendar day of date: 24 hours during the midnight sun and zero during the
+// polar night.
+func DayLength(c Coordinate, date time.Time) time.Duration {
+	return DaylightOn(c, date).Length
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Distance units
+
+// DistanceUnit identifies a unit of length. Distances in this package are in
+// meters unless stated otherwise; DistanceUnit converts them for display.
+type DistanceUnit int
+
+const (
+	UnitMeter        DistanceUnit = iota // Meters.
+	UnitKilometer                        // Kilometers.
+	UnitNauticalMile                     // International nautical miles of 1852 m, used at sea and in aviation.
+	UnitStatuteMile                      // Statute miles of 1609.344 m.
+	UnitFoot                             // International feet of 0.3048 m.
+)
+
+// distanceUnitSymbols holds the symbols of the distance units.
+var distanceUnitSymbols = [...]string{
+	UnitMeter:        "m",
+	UnitKilometer:    "km",
+	UnitNauticalMile: "nmi",
+	UnitStatuteMile:  "mi",
+	UnitFoot:         "ft",
+}
+
+// meters returns the length of the unit in meters.
+func (u DistanceUnit) meters() float64 {
+	switch u {
+	case UnitKilometer:
+		return 1000
+	case UnitNauticalMile:
+		return 1852
+	case UnitStatuteMile:
+		return 1609.344
+	case UnitFoot:
+		return 0.3048
+	}
+	return 1
+}
+
+// String returns the symbol of the unit, e.g. "nmi".
+func (u DistanceUnit) String() string {
+	if u >= 0 && int(u) < len(distanceUnitSymbols) {
+		return distanceUnitSymbols[u]
+	}
+	return fmt.Sprintf("DistanceUnit(%d)", int(u))
+}
+
+// FromMeters converts a distance in meters to the unit.
+func (u DistanceUnit) FromMeters(meters float64) float64 {
+	return meters / u.meters()
+}
+
+// ToMeters converts a distance in the unit to meters.
+func (u DistanceUnit) ToMeters(value float64) float64 {
+	return value * u.meters()
+}
+
+// Format returns a distance in meters converted to the unit, with the given
+// number of decimals and the unit symbol, e.g. "1.25 nmi".
+func (u DistanceUnit) Format(meters float64, decimals int) string {
+	return fmt.Sprintf("%.*f %s", max(decimals, 0), u.FromMeters(meters), u)
+}
+
+// ConvertDistance converts a distance value between units.
+func ConvertDistance(value float64, from, to DistanceUnit) float64 {
+	return to.FromMeters(from.ToMeters(value))
+}
+
+// ParseDistanceUnit returns the unit with the given symbol (m, km, nmi, mi,
+// ft) or name ("meters", "nautical miles"...), ignoring case.
+func ParseDistanceUnit(s string) (DistanceUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "m", "meter", "meters", "metre", "metres":
+		return UnitMeter, nil
+	case "km", "kilometer", "kilometers", "kilometre", "kilometres":
+		return UnitKilometer, nil
+	case "nmi", "nm", "nautical mile", "nautical miles":
+		return UnitNauticalMile, nil
+	case "mi", "mile", "miles", "statute mile", "statute miles":
+		return UnitStatuteMile, nil
+	case "ft", "foot", "feet":
+		return UnitFoot, nil
+	}
+	return 0, fmt.Errorf("Unknown distance unit %q", s)
+}
+
+// DistanceIn returns the great-circle distance between two coordinates in
+// the given unit.
+func DistanceIn(a, b Coordinate, unit DistanceUnit) float64 {
+	return unit.FromMeters(Distance(a, b))
+}
+
+// DestinationIn returns the position reached by travelling a distance in the
+// given unit from start along the great circle with the given initial bearing.
+func DestinationIn(start Coordinate, bearing, distance float64, unit DistanceUnit) Coordinate {
+	return Destination(start, bearing, unit.ToMeters(distance))
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// UTM represents a position in the Universal Transverse Mercator system.
+type UTM struct {
+	Zone     int     // Longitude zone, 1 to 60.
+	Band     byte    // Latitude band letter, C to X.
+	Easting  float64 // Easting in meters, including the 500 km false easting.
+	Northing float64 // Northing in meters, including the 10,000 km false northing in the south.
+}
+
+// utmBands lists the UTM latitude band letters from 80°S northwards.
+const utmBands = "CDEFGHJKLMNPQRSTUVWX"
+
+// utmScale is the scale factor on the central meridian of a UTM zone.
+const utmScale = 0.9996
+
+// String returns the UTM position as zone, band, easting and northing.
+func (u *UTM) String() string {
+	return fmt.Sprintf("%d%c %.0f %.0f", u.Zone, u.Band, u.Easting, u.Northing)
+}
+
+// UTM converts the coordinate to UTM on the WGS-84 ellipsoid. UTM is only
+// defined between 80°S and 84°N.
+func (c *Coordinate) UTM() (UTM, error) {
+	lat, lon := c.Decimal()
+	band, err := LatitudeBand(lat)
+	if err != nil {
+		return UTM{}, err
+	}
+	zone := UTMZone(lat, lon)
+	easting, northing := transverseMercator(lat, lon, float64(zone-1)*6-180+3)
+	if lat < 0 {
+		northing += 10000000
+	}
+	return UTM{Zone: zone, Band: band, Easting: easting + 500000, Northing: northing}, nil
+}
+
+// UTMZone returns the UTM longitude zone (1 to 60) of a position, including
+// the exceptions for southwestern Norway and Svalbard. The latitude is only
+// needed for those exceptions.
+func UTMZone(lat, lon float64) int {
+	switch {
+	case lat >= 56 && lat < 64 && lon >= 3 && lon < 12:
+		return 32
+	case lat >= 72 && lat <= 84 && lon >= 0 && lon < 42:
+		// Svalbard uses the odd zones 31, 33, 35 and 37 only.
+		switch {
+		case lon < 9:
+			return 31
+		case lon < 21:
+			return 33
+		case lon < 33:
+			return 35
+		default:
+			return 37
+		}
+	}
+	zone := int(math.Floor((lon+180)/6)) + 1
+	return min(max(zone, 1), 60)
+}
+
+// LatitudeBand returns the UTM latitude band letter (C to X) of a latitude.
+// Bands are 8° high except X, which covers 72°N to 84°N.
+func LatitudeBand(lat float64) (byte, error) {
+	if math.IsNaN(lat) || lat < -80 || lat > 84 {
+		return 0, errors.New("Latitude outside the UTM range")
+	}
+	return utmBands[min(int(math.Floor((lat+80)/8)), len(utmBands)-1)], nil
+}
+
+// transverseMercator projects a position onto the transverse Mercator plane
+// of the given central meridian, returning scaled easting and northing in meters.
+func transverseMercator(lat, lon, centralMeridian float64) (easting, northing float64) {
+	phi := lat * degToRad
+	e2 := wgs84E2
+	ep2 := e2 / (1 - e2)
+	sin, cos, tan := math.Sin(phi), math.Cos(phi), math.Tan(phi)
+	n := wgs84A / math.Sqrt(1-e2*sin*sin)
+	t := tan * tan
+	c := ep2 * cos * cos
+	a := cos * (lon - centralMeridian) * degToRad
+	m := wgs84A * ((1-e2/4-3*e2*e2/64-5*e2*e2*e2/256)*phi -
+		(3*e2/8+3*e2*e2/32+45*e2*e2*e2/1024)*math.Sin(2*phi) +
+		(15*e2*e2/256+45*e2*e2*e2/1024)*math.Sin(4*phi) -
+		(35*e2*e2*e2/3072)*math.Sin(6*phi))
+	easting = utmScale * n * (a + (1-t+c)*math.Pow(a, 3)/6 +
+		(5-18*t+t*t+72*c-58*ep2)*math.Pow(a, 5)/120)
+	northing = utmScale * (m + n*tan*(a*a/2+(5-t+9*c+4*c*c)*math.Pow(a, 4)/24+
+		(61-58*t+t*t+600*c-330*ep2)*math.Pow(a, 6)/720))
+	return easting, northing
+}
@@ -0,0 +1,151 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"math"
+)
+
+// Validation
+
+// Axis identifies the latitude or longitude axis of a coordinate.
+type Axis int
+
+const (
+	AxisUnknown   Axis = iota // The axis could not be determined, e.g. from an invalid direction.
+	AxisLatitude              // North-south axis, within ±90°, directions N and S.
+	AxisLongitude             // East-west axis, within ±180°, directions E and W.
+)
+
+// String returns "latitude", "longitude" or "coordinate" for AxisUnknown.
+func (a Axis) String() string {
+	switch a {
+	case AxisLatitude:
+		return "latitude"
+	case AxisLongitude:
+		return "longitude"
+	}
+	return "coordinate"
+}
+
+// limit returns the largest magnitude of a value on the axis.
+func (a Axis) limit() float64 {
+	if a == AxisLatitude {
+		return 90
+	}
+	return 180
+}
+
+// ValidationRule identifies the rule broken by an invalid value.
+type ValidationRule int
+
+const (
+	RuleRange     ValidationRule = iota // The value exceeds the range of its axis.
+	RuleMinutes                         // The minutes are not below 60.
+	RuleSeconds                         // The seconds are negative, not below 60 or NaN.
+	RuleDirection                       // The direction is not one of those of the axis.
+	RuleNaN                             // The decimal value is NaN.
+)
+
+// String returns the name of the rule, e.g. "range".
+func (r ValidationRule) String() string {
+	switch r {
+	case RuleMinutes:
+		return "minutes"
+	case RuleSeconds:
+		return "seconds"
+	case RuleDirection:
+		return "direction"
+	case RuleNaN:
+		return "nan"
+	}
+	return "range"
+}
+
+// ValidationError reports which axis of a value broke which rule. Use
+// errors.As to inspect it.
+type ValidationError struct {
+	Axis  Axis
+	Rule  ValidationRule
+	Value string // Offending value, as written in the message.
+}
+
+// Error returns a message naming the axis and the broken rule.
+func (e *ValidationError) Error() string {
+	switch e.Rule {
+	case RuleMinutes:
+		return fmt.Sprintf("Invalid %s minutes %s: must be below 60", e.Axis, e.Value)
+	case RuleSeconds:
+		return fmt.Sprintf("Invalid %s seconds %s: must be at least 0 and below 60", e.Axis, e.Value)
+	case RuleDirection:
+		return fmt.Sprintf("Invalid %s direction %s", e.Axis, e.Value)
+	case RuleNaN:
+		return fmt.Sprintf("Invalid %s value NaN", e.Axis)
+	}
+	return fmt.Sprintf("Invalid %s value %s: out of range [-%g, %g]", e.Axis, e.Value, e.Axis.limit(), e.Axis.limit())
+}
+
+// ValidateLatitude checks that a signed decimal latitude is within ±90°.
+func ValidateLatitude(lat float64) error {
+	return validateDecimal(lat, AxisLatitude)
+}
+
+// ValidateLongitude checks that a signed decimal longitude is within ±180°.
+func ValidateLongitude(lon float64) error {
+	return validateDecimal(lon, AxisLongitude)
+}
+
+// validateDecimal checks a signed decimal value of an axis.
+func validateDecimal(value float64, axis Axis) error {
+	if math.IsNaN(value) {
+		return &ValidationError{Axis: axis, Rule: RuleNaN}
+	}
+	if math.Abs(value) > axis.limit() {
+		return &ValidationError{Axis: axis, Rule: RuleRange, Value: formatDecimal(value, 9)}
+	}
+	return nil
+}
+
+// Axis returns the axis of the DMS given by its direction, or AxisUnknown.
+func (d *DMS) Axis() Axis {
+	switch d.Direction {
+	case "N", "S":
+		return AxisLatitude
+	case "E", "W":
+		return AxisLongitude
+	}
+	return AxisUnknown
+}
+
+// ValidateAs checks the DMS as a value of the given axis: its direction must
+// belong to the axis, its minutes and seconds must be below 60 and its value
+// within the range of the axis.
+func (d *DMS) ValidateAs(axis Axis) error {
+	if d.Axis() != axis || axis == AxisUnknown {
+		return &ValidationError{Axis: axis, Rule: RuleDirection, Value: fmt.Sprintf("%q", d.Direction)}
+	}
+	if d.Minutes >= 60 {
+		return &ValidationError{Axis: axis, Rule: RuleMinutes, Value: fmt.Sprint(d.Minutes)}
+	}
+	if d.Seconds < 0 || d.Seconds >= 60 || math.IsNaN(d.Seconds) {
+		return &ValidationError{Axis: axis, Rule: RuleSeconds, Value: formatDecimal(d.Seconds, 9)}
+	}
+	if value := DMSToDecimal(*d); value > axis.limit() {
+		return &ValidationError{Axis: axis, Rule: RuleRange, Value: d.String()}
+	}
+	return nil
+}
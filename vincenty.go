@@ -0,0 +1,95 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"errors"
+	"math"
+)
+
+// Ellipsoidal geodesy
+
+// vincentyIterations bounds the iterations of the Vincenty inverse formula,
+// which fails to converge for nearly antipodal points.
+const vincentyIterations = 200
+
+// VincentyDistance returns the distance in meters between two coordinates on
+// the WGS-84 ellipsoid, using Vincenty's inverse formula. It is accurate to
+// within a millimeter, but fails for nearly antipodal points, where Distance
+// should be used instead.
+func VincentyDistance(a, b Coordinate) (float64, error) {
+	distance, _, _, err := vincentyInverse(a, b)
+	return distance, err
+}
+
+// VincentyBearing returns the initial and final bearings in degrees clockwise
+// from true north, in the range [0, 360), of the geodesic from a to b on the
+// WGS-84 ellipsoid.
+func VincentyBearing(a, b Coordinate) (initial, final float64, err error) {
+	_, initial, final, err = vincentyInverse(a, b)
+	return initial, final, err
+}
+
+// vincentyInverse solves the inverse geodesic problem from a to b.
+func vincentyInverse(a, b Coordinate) (distance, initial, final float64, err error) {
+	lat1, lon1 := a.radians()
+	lat2, lon2 := b.radians()
+	const semiMinor = wgs84A * (1 - wgs84F)
+	l := lon2 - lon1
+	u1 := math.Atan((1 - wgs84F) * math.Tan(lat1))
+	u2 := math.Atan((1 - wgs84F) * math.Tan(lat2))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM, sinLambda, cosLambda float64
+	for i := 0; ; i++ {
+		if i == vincentyIterations {
+			return 0, 0, 0, errors.New("Vincenty formula failed to converge")
+		}
+		sinLambda, cosLambda = math.Sincos(lambda)
+		x := cosU1*sinU2 - sinU1*cosU2*cosLambda
+		sinSigma = math.Hypot(cosU2*sinLambda, x)
+		if sinSigma == 0 {
+			return 0, 0, 0, nil // Coincident points.
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cos2Alpha != 0 { // Both points on the equator otherwise.
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-previous) < 1e-12 {
+			break
+		}
+	}
+
+	u2sq := cos2Alpha * (wgs84A*wgs84A - semiMinor*semiMinor) / (semiMinor * semiMinor)
+	A := 1 + u2sq/16384*(4096+u2sq*(-768+u2sq*(320-175*u2sq)))
+	B := u2sq / 1024 * (256 + u2sq*(-128+u2sq*(74-47*u2sq)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	distance = semiMinor * A * (sigma - deltaSigma)
+	initial = math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+	final = math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda)
+	return distance, Normalize360(initial / degToRad), Normalize360(final / degToRad), nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Waypoint is a named coordinate with the metadata kept by the GPX, KML and
+// CSV importers and exporters.
+type Waypoint struct {
+	Coordinate  Coordinate // Position of the waypoint.
+	Name        string     // Short name, e.g. "Summit".
+	Symbol      string     // Map symbol, e.g. the GPX symbol "Flag, Blue" or a KML style id.
+	Description string     // Free-form description.
+	Time        time.Time  // Time of creation or observation, zero when unknown.
+}
+
+// NewWaypoint creates a named waypoint from decimal degrees.
+func NewWaypoint(name string, lat, lon float64) (Waypoint, error) {
+	c, err := NewCoordinate(lat, lon)
+	if err != nil {
+		return Waypoint{}, err
+	}
+	return Waypoint{Coordinate: c, Name: name}, nil
+}
+
+// String returns the name and coordinate of the waypoint.
+func (w *Waypoint) String() string {
+	if w.Name == "" {
+		return w.Coordinate.String()
+	}
+	return w.Name + " " + w.Coordinate.String()
+}
+
+// newWaypointAt creates a waypoint from decimal degree strings, as read from
+// files.
+func newWaypointAt(lat, lon string) (Waypoint, error) {
+	latValue, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return Waypoint{}, fmt.Errorf("Invalid latitude %q", lat)
+	}
+	lonValue, err := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if err != nil {
+		return Waypoint{}, fmt.Errorf("Invalid longitude %q", lon)
+	}
+	return NewWaypoint("", latValue, lonValue)
+}
+
+// formatWaypointTime returns the time of a waypoint in RFC 3339 UTC, or an
+// empty string when it is unknown.
+func formatWaypointTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// parseWaypointTime parses an RFC 3339 time, returning the zero time for an
+// empty string.
+func parseWaypointTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// CSV waypoints
+
+// waypointCSVHeader holds the columns written by WriteWaypointsCSV.
+var waypointCSVHeader = []string{"name", "latitude", "longitude", "symbol", "description", "time"}
+
+// WriteWaypointsCSV writes waypoints as CSV with a header row and the
+// columns name, latitude, longitude, symbol, description and time.
+func WriteWaypointsCSV(w io.Writer, waypoints []Waypoint) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(waypointCSVHeader); err != nil {
+		return err
+	}
+	for i := range waypoints {
+		wp := &waypoints[i]
+		lat, lon := wp.Coordinate.Decimal()
+		record := []string{
+			wp.Name,
+			formatDecimal(lat, geoURIPrecision),
+			formatDecimal(lon, geoURIPrecision),
+			wp.Symbol,
+			wp.Description,
+			formatWaypointTime(wp.Time),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadWaypointsCSV reads waypoints from CSV. The latitude and longitude
+// columns, and the name, symbol, description and time columns when present,
+// are found by InferCSVSchema.
+func ReadWaypointsCSV(r io.Reader) ([]Waypoint, error) {
+	return readWaypointsCSV(r, nil)
+}
+
+// ReadWaypointsCSVWithSchema reads waypoints from CSV laid out as schema.
+func ReadWaypointsCSVWithSchema(r io.Reader, schema CSVSchema) ([]Waypoint, error) {
+	return readWaypointsCSV(r, &schema)
+}
+
+// readWaypointsCSV reads waypoints from CSV laid out as schema, or as
+// inferred when schema is nil.
+func readWaypointsCSV(r io.Reader, schema *CSVSchema) ([]Waypoint, error) {
+	var waypoints []Waypoint
+	err := walkWaypointsCSV(r, schema, func(w Waypoint) bool {
+		waypoints = append(waypoints, w)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return waypoints, nil
+}
+
+// walkWaypointsCSV reads waypoints from CSV laid out as schema, or as
+// inferred from the first rows when schema is nil, passing them to yield
+// until it returns false.
+func walkWaypointsCSV(r io.Reader, schema *CSVSchema, yield func(Waypoint) bool) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	var buffered [][]string
+	if schema == nil {
+		for len(buffered) < csvInferRows {
+			record, err := cr.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			buffered = append(buffered, record)
+		}
+		inferred, err := InferCSVSchema(buffered)
+		if err != nil {
+			return err
+		}
+		schema = &inferred
+	}
+	line := 1
+	if schema.Header {
+		if len(buffered) > 0 {
+			buffered = buffered[1:]
+		} else if _, err := cr.Read(); err != nil {
+			return err
+		}
+		line++
+	}
+	for ; ; line++ {
+		var record []string
+		if len(buffered) > 0 {
+			record, buffered = buffered[0], buffered[1:]
+		} else {
+			var err error
+			if record, err = cr.Read(); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+		wp, err := schema.waypoint(record)
+		if err != nil {
+			return fmt.Errorf("Line %d: %v", line, err)
+		}
+		if !yield(wp) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testWaypoints returns the waypoints written and read back by the waypoint
+// format tests.
+func testWaypoints(t *testing.T) []Waypoint {
+	t.Helper()
+	summit, err := NewWaypoint("Summit", 46.852947, -121.760424)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summit.Symbol = "Flag"
+	summit.Description = `Columbia Crest, "the top" & more`
+	summit.Time = time.Date(2021, 7, 4, 12, 30, 15, 0, time.UTC)
+	camp, err := NewWaypoint("Camp, Muir", -33.8688, 151.2093)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []Waypoint{summit, camp}
+}
+
+// checkWaypoints reports differences between waypoints read back by format
+// and the waypoints that were written.
+func checkWaypoints(t *testing.T, format string, got, want []Waypoint) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: read %d waypoints, want %d", format, len(got), len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Name != w.Name || g.Symbol != w.Symbol || g.Description != w.Description ||
+			!g.Time.Equal(w.Time) || Distance(g.Coordinate, w.Coordinate) > 1e-3 {
+			t.Errorf("%s: waypoint %d = %+v, want %+v", format, i, g, w)
+		}
+	}
+}
+
+func TestWaypointsRoundTrip(t *testing.T) {
+	waypoints := testWaypoints(t)
+
+	var b bytes.Buffer
+	if err := (&GPX{Waypoints: waypoints}).Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	gpx, err := ReadGPX(&b)
+	if err != nil {
+		t.Fatalf("ReadGPX error: %v", err)
+	}
+	checkWaypoints(t, "GPX", gpx.Waypoints, waypoints)
+
+	b.Reset()
+	if err := (&KML{Name: "Trip", Waypoints: waypoints}).Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	kml, err := ReadKML(&b)
+	if err != nil {
+		t.Fatalf("ReadKML error: %v", err)
+	}
+	if kml.Name != "Trip" {
+		t.Errorf("KML name = %q, want %q", kml.Name, "Trip")
+	}
+	checkWaypoints(t, "KML", kml.Waypoints, waypoints)
+
+	b.Reset()
+	if err := WriteWaypointsCSV(&b, waypoints); err != nil {
+		t.Fatal(err)
+	}
+	csv, err := ReadWaypointsCSV(&b)
+	if err != nil {
+		t.Fatalf("ReadWaypointsCSV error: %v", err)
+	}
+	checkWaypoints(t, "CSV", csv, waypoints)
+}
+
+func TestReadGPX10(t *testing.T) {
+	const doc = `<?xml version="1.0"?>
+<gpx version="1.0" creator="test" xmlns="http://www.topografix.com/GPX/1/0">
+  <wpt lat="46.852947" lon="-121.760424"><name>Summit</name><sym>Flag</sym></wpt>
+</gpx>`
+	gpx, err := ReadGPX(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := NewWaypoint("Summit", 46.852947, -121.760424)
+	want.Symbol = "Flag"
+	checkWaypoints(t, "GPX 1.0", gpx.Waypoints, []Waypoint{want})
+}
+
+func TestReadWaypointsMalformed(t *testing.T) {
+	gpx := []string{
+		``,
+		`<gpx><wpt lat="abc" lon="1"/></gpx>`,
+		`<gpx><wpt lat="91" lon="1"/></gpx>`,
+		`<gpx><wpt lat="1" lon="1"><time>yesterday</time></wpt></gpx>`,
+		`<gpx><wpt lat="1" lon="1">`,
+	}
+	for _, s := range gpx {
+		if _, err := ReadGPX(strings.NewReader(s)); err == nil {
+			t.Errorf("ReadGPX(%q) succeeded, want error", s)
+		}
+	}
+	kml := []string{
+		``,
+		`<kml><Document><Placemark><Point><coordinates>1</coordinates></Point></Placemark></Document></kml>`,
+		`<kml><Document><Placemark><Point><coordinates>x,1</coordinates></Point></Placemark></Document></kml>`,
+		`<kml><Document><Placemark><Point><coordinates>1,95</coordinates></Point></Placemark></Document></kml>`,
+	}
+	for _, s := range kml {
+		if _, err := ReadKML(strings.NewReader(s)); err == nil {
+			t.Errorf("ReadKML(%q) succeeded, want error", s)
+		}
+	}
+	csv := []string{
+		"name,latitude\nA,1\n",
+		"name,latitude,longitude\nA,1,east\n",
+		"name,latitude,longitude\nA,91,1\n",
+		"name,latitude,longitude,time\nA,1,1,noon\n",
+	}
+	for _, s := range csv {
+		if _, err := ReadWaypointsCSV(strings.NewReader(s)); err == nil {
+			t.Errorf("ReadWaypointsCSV(%q) succeeded, want error", s)
+		}
+	}
+}
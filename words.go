@@ -0,0 +1,187 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Verbal rendering
+
+// numberSpeller spells out numbers in a language.
+type numberSpeller struct {
+	ones               [20]string // Words for 0 to 19.
+	tens               [10]string // Words for 20, 30, ..., 90 at index 2 to 9.
+	hundreds           [10]string // Words for 100, 200, ..., 900 at index 1 to 9.
+	joiner             string     // Inserted between tens and ones, and after hundreds.
+	point              string     // Word for the decimal point.
+	partJoiner         string     // Inserted between the degree, minute and second parts.
+	lowercaseDirection bool       // Whether direction names are lowercased.
+}
+
+// numberSpellers holds the locales that can spell out numbers.
+var numberSpellers = map[Locale]numberSpeller{
+	LocaleEnglish: {
+		ones: [20]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+			"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"},
+		tens:               [10]string{2: "twenty", 3: "thirty", 4: "forty", 5: "fifty", 6: "sixty", 7: "seventy", 8: "eighty", 9: "ninety"},
+		hundreds:           [10]string{1: "one hundred", 2: "two hundred", 3: "three hundred"},
+		joiner:             "-",
+		point:              "point",
+		partJoiner:         ", ",
+		lowercaseDirection: true,
+	},
+	LocalePersian: {
+		ones: [20]string{"صفر", "یک", "دو", "سه", "چهار", "پنج", "شش", "هفت", "هشت", "نه", "ده",
+			"یازده", "دوازده", "سیزده", "چهارده", "پانزده", "شانزده", "هفده", "هجده", "نوزده"},
+		tens:       [10]string{2: "بیست", 3: "سی", 4: "چهل", 5: "پنجاه", 6: "شصت", 7: "هفتاد", 8: "هشتاد", 9: "نود"},
+		hundreds:   [10]string{1: "صد", 2: "دویست", 3: "سیصد"},
+		joiner:     " و ",
+		point:      "ممیز",
+		partJoiner: " و ",
+	},
+}
+
+// spellerFor returns the number speller of a locale, defaulting to English.
+func spellerFor(locale Locale) (numberSpeller, Locale) {
+	if s, ok := numberSpellers[locale]; ok {
+		return s, locale
+	}
+	return numberSpellers[LocaleEnglish], LocaleEnglish
+}
+
+// spellInt spells out a whole number between 0 and 399.
+func (s numberSpeller) spellInt(n uint) string {
+	if n >= 400 {
+		return strconv.FormatUint(uint64(n), 10)
+	}
+	var parts []string
+	if h := n / 100; h > 0 {
+		parts = append(parts, s.hundreds[h])
+		n %= 100
+		if n == 0 {
+			return parts[0]
+		}
+	}
+	switch {
+	case n < 20:
+		parts = append(parts, s.ones[n])
+	case n%10 == 0:
+		parts = append(parts, s.tens[n/10])
+	default:
+		parts = append(parts, s.tens[n/10]+s.joiner+s.ones[n%10])
+	}
+	if len(parts) == 2 {
+		sep := " "
+		if s.joiner != "-" {
+			sep = s.joiner
+		}
+		return parts[0] + sep + parts[1]
+	}
+	return parts[0]
+}
+
+// spellDecimal spells out a non-negative decimal number, reading the digits
+// after the decimal point one by one.
+func (s numberSpeller) spellDecimal(text string) string {
+	whole, fraction, _ := strings.Cut(text, ".")
+	n, _ := strconv.ParseUint(whole, 10, 32)
+	result := s.spellInt(uint(n))
+	if fraction == "" {
+		return result
+	}
+	digits := make([]string, len(fraction))
+	for i, r := range fraction {
+		digits[i] = s.ones[r-'0']
+	}
+	return result + " " + s.point + " " + strings.Join(digits, " ")
+}
+
+// Words returns the DMS entirely in words, e.g. "forty degrees, twenty-six
+// minutes, forty-six point three seconds north", for text-to-speech and radio
+// read-back. English and Persian are supported; other locales use English.
+func (d *DMS) Words(locale Locale) string {
+	opts := DefaultFormatOptions()
+	opts.Style = StyleWords
+	opts.Locale = locale
+	return d.Format(opts)
+}
+
+// formatWords renders the DMS in words for the StyleWords format style.
+func (d *DMS) formatWords(opts FormatOptions) string {
+	speller, locale := spellerFor(opts.Locale)
+	loc := localeFor(locale)
+	seconds := strconv.FormatFloat(d.Seconds, 'f', max(opts.Precision, 0), 64)
+	if strings.Contains(seconds, ".") {
+		seconds = strings.TrimRight(strings.TrimRight(seconds, "0"), ".")
+	}
+	direction := DirectionName(d.Direction, locale)
+	if speller.lowercaseDirection {
+		direction = strings.ToLower(direction)
+	}
+	return strings.Join([]string{
+		speller.spellInt(d.Degree) + " " + unitWord(loc, 0, d.Degree == 1),
+		speller.spellInt(d.Minutes) + " " + unitWord(loc, 1, d.Minutes == 1),
+		speller.spellDecimal(seconds) + " " + unitWord(loc, 2, seconds == "1"),
+	}, speller.partJoiner) + " " + direction
+}
+
+// phoneticDigits holds the ICAO/NATO radiotelephony pronunciation of digits.
+var phoneticDigits = [10]string{"Zero", "One", "Two", "Tree", "Fower", "Fife", "Six", "Seven", "Eight", "Niner"}
+
+// Phonetic returns the DMS read digit by digit using ICAO/NATO radiotelephony
+// pronunciation, e.g. "Fower Zero degrees Two Six minutes Fower Six Decimal
+// Tree Zero seconds North", for voice transmission.
+func (d *DMS) Phonetic() string {
+	opts := DefaultFormatOptions()
+	opts.Style = StylePhonetic
+	return d.Format(opts)
+}
+
+// formatPhonetic renders the DMS for the StylePhonetic format style. Degrees
+// are read with two digits for latitude and three for longitude, and minutes
+// and seconds with two digits, as is customary in aviation and maritime use.
+func (d *DMS) formatPhonetic(opts FormatOptions) string {
+	degreeWidth := 2
+	if d.Direction == "E" || d.Direction == "W" {
+		degreeWidth = 3
+	}
+	seconds := strconv.FormatFloat(d.Seconds, 'f', max(opts.Precision, 0), 64)
+	if len(seconds) == 1 || seconds[1] == '.' {
+		seconds = "0" + seconds
+	}
+	return strings.Join([]string{
+		spellDigits(fmt.Sprintf("%0*d", degreeWidth, d.Degree)), "degrees",
+		spellDigits(fmt.Sprintf("%02d", d.Minutes)), "minutes",
+		spellDigits(seconds), "seconds",
+		DirectionName(d.Direction, LocaleEnglish),
+	}, " ")
+}
+
+// spellDigits reads a decimal number digit by digit in radiotelephony words.
+func spellDigits(text string) string {
+	words := make([]string, 0, len(text))
+	for _, r := range text {
+		if r == '.' {
+			words = append(words, "Decimal")
+		} else {
+			words = append(words, phoneticDigits[r-'0'])
+		}
+	}
+	return strings.Join(words, " ")
+}
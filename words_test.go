@@ -0,0 +1,60 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import "testing"
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		d      DMS
+		locale Locale
+		want   string
+	}{
+		{DMS{40, 1, 46.3, "N"}, LocaleEnglish, "forty degrees, one minute, forty-six point three seconds north"},
+		{DMS{1, 0, 0, "W"}, LocaleEnglish, "one degree, zero minutes, zero seconds west"},
+		{DMS{179, 59, 59, "E"}, LocaleEnglish, "one hundred seventy-nine degrees, fifty-nine minutes, fifty-nine seconds east"},
+		{DMS{0, 0, 0.05, "S"}, LocaleEnglish, "zero degrees, zero minutes, zero point zero five seconds south"},
+		{DMS{100, 10, 10.5, "E"}, LocalePersian, "صد درجه و ده دقیقه و ده ممیز پنج ثانیه شرق"},
+		{DMS{40, 1, 46.3, "N"}, LocalePersian, "چهل درجه و یک دقیقه و چهل و شش ممیز سه ثانیه شمال"},
+		{DMS{40, 1, 46.3, "N"}, LocaleGerman, "forty degrees, one minute, forty-six point three seconds north"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Words(tt.locale); got != tt.want {
+			t.Errorf("Words(%+v, %s) = %q, want %q", tt.d, tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestPhonetic(t *testing.T) {
+	tests := []struct {
+		d    DMS
+		want string
+	}{
+		{DMS{40, 1, 46.3, "N"}, "Fower Zero degrees Zero One minutes Fower Six Decimal Tree Zero seconds North"},
+		{DMS{179, 59, 59, "E"}, "One Seven Niner degrees Fife Niner minutes Fife Niner Decimal Zero Zero seconds East"},
+		{DMS{1, 0, 0.05, "W"}, "Zero Zero One degrees Zero Zero minutes Zero Zero Decimal Zero Fife seconds West"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Phonetic(); got != tt.want {
+			t.Errorf("Phonetic(%+v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+	opts := DefaultFormatOptions()
+	opts.Style, opts.Precision = StylePhonetic, 0
+	if got, want := (&DMS{8, 2, 29.6, "S"}).Format(opts), "Zero Eight degrees Zero Two minutes Tree Zero seconds South"; got != want {
+		t.Errorf("Format(StylePhonetic, precision 0) = %q, want %q", got, want)
+	}
+}
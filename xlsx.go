@@ -0,0 +1,332 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+//go:build xlsx
+
+package dms
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Spreadsheets
+//
+// Waypoint tables are read from and written to the first worksheet of Office
+// Open XML workbooks (.xlsx). This file is built with the "xlsx" build tag,
+// e.g. go build -tags xlsx, so that programs without spreadsheets do not
+// carry it.
+
+// xlsxHeader holds the columns written by WriteWaypointsXLSX: those of
+// WriteWaypointsCSV followed by the position in DMS.
+var xlsxHeader = append(append([]string(nil), waypointCSVHeader...), "dms")
+
+// excelEpoch is day 0 of the 1900 date system of spreadsheets, accounting
+// for its fictitious February 29, 1900.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// ReadWaypointsXLSX reads waypoints from the first worksheet of a workbook,
+// laid out as inferred by InferCSVSchema. Times may be RFC 3339 text or
+// spreadsheet dates in UTC.
+func ReadWaypointsXLSX(r io.ReaderAt, size int64) ([]Waypoint, error) {
+	rows, err := readXLSXRows(r, size)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := InferCSVSchema(rows[:min(len(rows), csvInferRows)])
+	if err != nil {
+		return nil, err
+	}
+	return xlsxWaypoints(rows, schema)
+}
+
+// ReadWaypointsXLSXWithSchema reads waypoints from the first worksheet of a
+// workbook laid out as schema.
+func ReadWaypointsXLSXWithSchema(r io.ReaderAt, size int64, schema CSVSchema) ([]Waypoint, error) {
+	rows, err := readXLSXRows(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return xlsxWaypoints(rows, schema)
+}
+
+// xlsxWaypoints creates waypoints from worksheet rows.
+func xlsxWaypoints(rows [][]string, schema CSVSchema) ([]Waypoint, error) {
+	first := 0
+	if schema.Header {
+		first = 1
+	}
+	var waypoints []Waypoint
+	for i := first; i < len(rows); i++ {
+		record := rows[i]
+		if schema.Time >= 0 && schema.Time < len(record) {
+			if serial, err := strconv.ParseFloat(record[schema.Time], 64); err == nil {
+				record[schema.Time] = excelEpoch.Add(time.Duration(serial * 24 * float64(time.Hour))).Round(time.Millisecond).Format(time.RFC3339Nano)
+			}
+		}
+		wp, err := schema.waypoint(record)
+		if err != nil {
+			return nil, fmt.Errorf("Row %d: %v", i+1, err)
+		}
+		waypoints = append(waypoints, wp)
+	}
+	return waypoints, nil
+}
+
+// WriteWaypointsXLSX writes waypoints as a workbook with one worksheet with a
+// header row and the columns name, latitude, longitude, symbol, description,
+// time and dms.
+func WriteWaypointsXLSX(w io.Writer, waypoints []Waypoint) error {
+	var sheet strings.Builder
+	sheet.WriteString(xml.Header)
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeXLSXRow(&sheet, 1, xlsxHeader, nil)
+	for i := range waypoints {
+		wp := &waypoints[i]
+		lat, lon := wp.Coordinate.Decimal()
+		writeXLSXRow(&sheet, i+2, []string{
+			wp.Name,
+			formatDecimal(lat, geoURIPrecision),
+			formatDecimal(lon, geoURIPrecision),
+			wp.Symbol,
+			wp.Description,
+			formatWaypointTime(wp.Time),
+			wp.Coordinate.String(),
+		}, map[int]bool{1: true, 2: true})
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	z := zip.NewWriter(w)
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	} {
+		f, err := z.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return z.Close()
+}
+
+// writeXLSXRow writes a worksheet row of the given number, with the cells of
+// the columns in numeric written as numbers and the others as text.
+func writeXLSXRow(b *strings.Builder, row int, cells []string, numeric map[int]bool) {
+	fmt.Fprintf(b, `<row r="%d">`, row)
+	for i, cell := range cells {
+		ref := xlsxColumnName(i) + strconv.Itoa(row)
+		switch {
+		case cell == "":
+		case numeric[i]:
+			fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, cell)
+		default:
+			fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			xml.EscapeText(b, []byte(cell))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+}
+
+// xlsxColumnName returns the letters of a column index, e.g. "AA" for 26.
+func xlsxColumnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxMaxColumn is the index of XFD, the last column of a worksheet.
+const xlsxMaxColumn = 16383
+
+// xlsxColumnIndex returns the column index of a cell reference, e.g. 1 for
+// "B7", -1 without column letters, or a value above xlsxMaxColumn for
+// columns beyond XFD.
+func xlsxColumnIndex(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		if col > xlsxMaxColumn+1 {
+			break
+		}
+	}
+	return col - 1
+}
+
+// Parts of the workbooks written by WriteWaypointsXLSX.
+const (
+	xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Waypoints" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+)
+
+// XML layouts of the workbook parts read by readXLSXRows.
+type (
+	xlsxWorkbookXML struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelsXML struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxStringXML struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxSharedStringsXML struct {
+		Items []xlsxStringXML `xml:"si"`
+	}
+	xlsxSheetXML struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string        `xml:"r,attr"`
+				Type   string        `xml:"t,attr"`
+				Value  string        `xml:"v"`
+				Inline xlsxStringXML `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+// text returns the text of a string item, joining rich text runs.
+func (s *xlsxStringXML) text() string {
+	if len(s.Runs) == 0 {
+		return s.Text
+	}
+	var b strings.Builder
+	for _, r := range s.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+// readXLSXRows returns the cell texts of the first worksheet of a workbook.
+// Missing cells are empty, and booleans read as "0" or "1".
+func readXLSXRows(r io.ReaderAt, size int64) ([][]string, error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	var workbook xlsxWorkbookXML
+	if err := decodeXLSXPart(z, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	var rels xlsxRelsXML
+	if err := decodeXLSXPart(z, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, errors.New("Workbook has no worksheet")
+	}
+	sheetPath := ""
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].ID {
+			sheetPath = path.Join("xl", rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPath = strings.TrimPrefix(rel.Target, "/")
+			}
+		}
+	}
+	var shared xlsxSharedStringsXML
+	if err := decodeXLSXPart(z, "xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, errMissingXLSXPart) {
+		return nil, err
+	}
+	var sheet xlsxSheetXML
+	if err := decodeXLSXPart(z, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var record []string
+		for _, cell := range row.Cells {
+			col := xlsxColumnIndex(cell.Ref)
+			if col > xlsxMaxColumn {
+				return nil, fmt.Errorf("Invalid cell reference %q", cell.Ref)
+			}
+			if col < 0 {
+				col = len(record)
+			}
+			for len(record) <= col {
+				record = append(record, "")
+			}
+			switch cell.Type {
+			case "s":
+				i, err := strconv.Atoi(cell.Value)
+				if err != nil || i < 0 || i >= len(shared.Items) {
+					return nil, fmt.Errorf("Invalid shared string in cell %s", cell.Ref)
+				}
+				record[col] = shared.Items[i].text()
+			case "inlineStr":
+				record[col] = cell.Inline.text()
+			default:
+				record[col] = cell.Value
+			}
+		}
+		rows = append(rows, record)
+	}
+	return rows, nil
+}
+
+// errMissingXLSXPart is returned by decodeXLSXPart for missing parts.
+var errMissingXLSXPart = errors.New("Missing workbook part")
+
+// decodeXLSXPart decodes the XML part of a workbook with the given name.
+func decodeXLSXPart(z *zip.Reader, name string, v interface{}) error {
+	f, err := z.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w %q", errMissingXLSXPart, name)
+	}
+	defer f.Close()
+	if err := xml.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("Invalid workbook part %s: %w", name, err)
+	}
+	return nil
+}
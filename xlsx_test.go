@@ -0,0 +1,102 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+//go:build xlsx
+
+package dms
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaypointsXLSXRoundTrip(t *testing.T) {
+	summit, _ := NewWaypoint("Summit <1>", 46.852, -121.7603)
+	summit.Symbol, summit.Description = "Flag, Blue", "Top & view"
+	summit.Time = time.Date(2021, 7, 1, 12, 30, 0, 0, time.UTC)
+	camp, _ := NewWaypoint("Camp", -33.8568, 151.2153)
+	var buf bytes.Buffer
+	if err := WriteWaypointsXLSX(&buf, []Waypoint{summit, camp}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadWaypointsXLSX(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("ReadWaypointsXLSX returned %d waypoints, want 2", len(got))
+	}
+	for i, want := range []Waypoint{summit, camp} {
+		if got[i].Name != want.Name || got[i].Symbol != want.Symbol || got[i].Description != want.Description || !got[i].Time.Equal(want.Time) {
+			t.Errorf("waypoint %d = %+v, want %+v", i, got[i], want)
+		}
+		if d := Distance(got[i].Coordinate, want.Coordinate); d > 0.01 {
+			t.Errorf("waypoint %d is %g m away", i, d)
+		}
+	}
+}
+
+func TestReadXLSXRejectsColumnBeyondXFD(t *testing.T) {
+	wp, _ := NewWaypoint("Camp", 1, 2)
+	var buf bytes.Buffer
+	if err := WriteWaypointsXLSX(&buf, []Waypoint{wp}); err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{"XFE2", "ZZZZZZZZ2", strings.Repeat("Z", 40) + "2"} {
+		data := replaceZipPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml", `r="A2"`, `r="`+ref+`"`)
+		if _, err := ReadWaypointsXLSX(bytes.NewReader(data), int64(len(data))); err == nil {
+			t.Errorf("ReadWaypointsXLSX with cell %s: want error", ref)
+		}
+	}
+}
+
+// replaceZipPart returns the zip archive data with old replaced by new in
+// the named part.
+func replaceZipPart(t *testing.T, data []byte, name, old, new string) []byte {
+	t.Helper()
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	w := zip.NewWriter(&out)
+	for _, f := range z.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Name == name {
+			content = []byte(strings.Replace(string(content), old, new, 1))
+		}
+		part, err := w.Create(f.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(content)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
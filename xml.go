@@ -0,0 +1,183 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// XML marshaling
+
+// XMLStyle selects how DMS and Coordinate values are written as XML.
+type XMLStyle int
+
+const (
+	// XMLElements writes values as child elements, e.g.
+	// <pos><latitude><degree>40</degree>...</latitude>...</pos>.
+	XMLElements XMLStyle = iota
+	// XMLAttributes writes values as attributes, e.g. <pos lat="40.446" lon="-79.982"/>.
+	XMLAttributes
+)
+
+// DefaultXMLStyle is the style used when marshaling DMS and Coordinate values.
+// Unmarshaling accepts both styles regardless of this setting.
+var DefaultXMLStyle = XMLElements
+
+// xmlDMS is the element-style XML layout of a DMS.
+type xmlDMS struct {
+	Degree    uint    `xml:"degree"`
+	Minutes   uint    `xml:"minutes"`
+	Seconds   float64 `xml:"seconds"`
+	Direction string  `xml:"direction"`
+}
+
+// xmlCoordinate is the element-style XML layout of a Coordinate.
+type xmlCoordinate struct {
+	Latitude  DMS     `xml:"latitude"`
+	Longitude DMS     `xml:"longitude"`
+	Accuracy  float64 `xml:"accuracy,omitempty"`
+}
+
+// MarshalXML implements xml.Marshaler using DefaultXMLStyle.
+func (d DMS) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if DefaultXMLStyle == XMLAttributes {
+		start.Attr = append(start.Attr,
+			xmlAttr("degree", strconv.FormatUint(uint64(d.Degree), 10)),
+			xmlAttr("minutes", strconv.FormatUint(uint64(d.Minutes), 10)),
+			xmlAttr("seconds", strconv.FormatFloat(d.Seconds, 'f', -1, 64)),
+			xmlAttr("direction", d.Direction))
+		return encodeEmptyElement(e, start)
+	}
+	return e.EncodeElement(xmlDMS(d), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler for both attribute and element style.
+func (d *DMS) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var decoded xmlDMS
+	if err := dec.DecodeElement(&decoded, &start); err != nil {
+		return err
+	}
+	for _, attr := range start.Attr {
+		var err error
+		switch attr.Name.Local {
+		case "degree":
+			decoded.Degree, err = parseUintAttr(attr.Value)
+		case "minutes":
+			decoded.Minutes, err = parseUintAttr(attr.Value)
+		case "seconds":
+			decoded.Seconds, err = strconv.ParseFloat(attr.Value, 64)
+		case "direction":
+			decoded.Direction = attr.Value
+		}
+		if err != nil {
+			return err
+		}
+	}
+	result := DMS(decoded)
+	if err := result.Validate(); err != nil {
+		return err
+	}
+	*d = result
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler using DefaultXMLStyle. In attribute
+// style the coordinate is written as signed decimal lat and lon attributes.
+func (c Coordinate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if DefaultXMLStyle == XMLAttributes {
+		lat, lon := c.Decimal()
+		start.Attr = append(start.Attr,
+			xmlAttr("lat", strconv.FormatFloat(lat, 'f', -1, 64)),
+			xmlAttr("lon", strconv.FormatFloat(lon, 'f', -1, 64)))
+		if c.Accuracy > 0 {
+			start.Attr = append(start.Attr, xmlAttr("accuracy", strconv.FormatFloat(c.Accuracy, 'f', -1, 64)))
+		}
+		return encodeEmptyElement(e, start)
+	}
+	return e.EncodeElement(xmlCoordinate(c), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler for both attribute and element style.
+func (c *Coordinate) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var lat, lon, accuracy string
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "lat":
+			lat = attr.Value
+		case "lon":
+			lon = attr.Value
+		case "accuracy":
+			accuracy = attr.Value
+		}
+	}
+	if lat == "" && lon == "" {
+		var decoded xmlCoordinate
+		if err := dec.DecodeElement(&decoded, &start); err != nil {
+			return err
+		}
+		result := Coordinate(decoded)
+		if err := result.Validate(); err != nil {
+			return err
+		}
+		*c = result
+		return nil
+	}
+	if err := dec.Skip(); err != nil {
+		return err
+	}
+	latValue, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return err
+	}
+	lonValue, err := strconv.ParseFloat(lon, 64)
+	if err != nil {
+		return err
+	}
+	result, err := NewCoordinate(latValue, lonValue)
+	if err != nil {
+		return err
+	}
+	if accuracy != "" {
+		if result.Accuracy, err = strconv.ParseFloat(accuracy, 64); err != nil {
+			return err
+		}
+		if err := result.Validate(); err != nil {
+			return err
+		}
+	}
+	*c = result
+	return nil
+}
+
+// xmlAttr builds an unqualified XML attribute.
+func xmlAttr(name, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
+
+// encodeEmptyElement writes start immediately followed by its end element.
+func encodeEmptyElement(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// parseUintAttr parses an unsigned integer attribute value.
+func parseUintAttr(value string) (uint, error) {
+	n, err := strconv.ParseUint(value, 10, 32)
+	return uint(n), err
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+// xmlPlace is a struct holding a coordinate, as used by callers.
+type xmlPlace struct {
+	XMLName  xml.Name   `xml:"place"`
+	Name     string     `xml:"name,attr"`
+	Position Coordinate `xml:"pos"`
+}
+
+func setXMLStyle(t *testing.T, style XMLStyle) {
+	saved := DefaultXMLStyle
+	DefaultXMLStyle = style
+	t.Cleanup(func() { DefaultXMLStyle = saved })
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	c := coordinateFromDecimal(40.446195, -79.948862)
+	accurate := coordinateFromDecimal(-33.8568, 151.2153)
+	accurate.Accuracy = 7.5
+	for _, style := range []XMLStyle{XMLElements, XMLAttributes} {
+		setXMLStyle(t, style)
+		for _, want := range []Coordinate{c, accurate} {
+			data, err := xml.Marshal(xmlPlace{Name: "p", Position: want})
+			if err != nil {
+				t.Fatalf("style %d: %v", style, err)
+			}
+			var got xmlPlace
+			if err := xml.Unmarshal(data, &got); err != nil {
+				t.Fatalf("style %d: Unmarshal(%s): %v", style, data, err)
+			}
+			if d := Distance(got.Position, want); d > 1e-6 || got.Position.Accuracy != want.Accuracy || got.Name != "p" {
+				t.Errorf("style %d: round trip of %+v through %s gave %+v", style, want, data, got.Position)
+			}
+		}
+	}
+}
+
+func TestXMLDMSAttributes(t *testing.T) {
+	setXMLStyle(t, XMLAttributes)
+	want := DMS{Degree: 40, Minutes: 26, Seconds: 46.3, Direction: "N"}
+	data, err := xml.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(data); s != `<DMS degree="40" minutes="26" seconds="46.3" direction="N"></DMS>` {
+		t.Errorf("Marshal = %s", s)
+	}
+	var got DMS
+	if err := xml.Unmarshal(data, &got); err != nil || got != want {
+		t.Errorf("Unmarshal(%s) = %+v, %v", data, got, err)
+	}
+}
+
+func TestXMLMalformed(t *testing.T) {
+	for _, s := range []string{
+		`<pos lat="95" lon="10"/>`,
+		`<pos lat="x" lon="10"/>`,
+		`<pos lat="40"/>`,
+		`<pos lat="40" lon="10" accuracy="-1"/>`,
+		`<pos lat="40" lon="10" accuracy="x"/>`,
+		`<pos><latitude><degree>40</degree><direction>E</direction></latitude><longitude><degree>10</degree><direction>E</direction></longitude></pos>`,
+		`<pos><latitude><degree>40</degree><minutes>60</minutes><direction>N</direction></latitude></pos>`,
+		`<pos><latitude degree="-1" direction="N"/></pos>`,
+	} {
+		var c Coordinate
+		if err := xml.Unmarshal([]byte(s), &c); err == nil {
+			t.Errorf("Unmarshal(%s) = %+v, want error", s, c)
+		}
+	}
+}
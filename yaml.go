@@ -0,0 +1,122 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+// YAML marshaling
+//
+// DMS and Coordinate implement the MarshalYAML and UnmarshalYAML methods
+// recognized by gopkg.in/yaml.v2 and gopkg.in/yaml.v3, so they can be used in
+// configuration files without this package importing a YAML library.
+
+// YAMLStyle selects how DMS and Coordinate values are written as YAML.
+type YAMLStyle int
+
+const (
+	// YAMLString writes values as a single string, e.g. `40°26'46.30" N`.
+	YAMLString YAMLStyle = iota
+	// YAMLMapping writes values as a mapping of their components.
+	YAMLMapping
+)
+
+// DefaultYAMLStyle is the style used when marshaling DMS and Coordinate values.
+// Under YAMLString, a Coordinate with a known accuracy is still written as a
+// mapping, since the string form has no room for the accuracy. Unmarshaling
+// accepts both styles regardless of this setting.
+var DefaultYAMLStyle = YAMLString
+
+// yamlDMS is the mapping-style YAML layout of a DMS.
+type yamlDMS struct {
+	Degree    uint    `yaml:"degree"`
+	Minutes   uint    `yaml:"minutes"`
+	Seconds   float64 `yaml:"seconds"`
+	Direction string  `yaml:"direction"`
+}
+
+// yamlCoordinate is the mapping-style YAML layout of a Coordinate.
+type yamlCoordinate struct {
+	Latitude  DMS     `yaml:"latitude"`
+	Longitude DMS     `yaml:"longitude"`
+	Accuracy  float64 `yaml:"accuracy,omitempty"`
+}
+
+// MarshalYAML implements the yaml.Marshaler interface using DefaultYAMLStyle.
+func (d DMS) MarshalYAML() (interface{}, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	if DefaultYAMLStyle == YAMLMapping {
+		return yamlDMS(d), nil
+	}
+	return d.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for both styles.
+func (d *DMS) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err == nil {
+		parsed, err := ParseDMS(text)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	}
+	var decoded yamlDMS
+	if err := unmarshal(&decoded); err != nil {
+		return err
+	}
+	result := DMS(decoded)
+	if err := result.Validate(); err != nil {
+		return err
+	}
+	*d = result
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface using DefaultYAMLStyle,
+// writing a mapping when the accuracy is known, as documented there.
+func (c Coordinate) MarshalYAML() (interface{}, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if DefaultYAMLStyle == YAMLMapping || c.Accuracy != 0 {
+		return yamlCoordinate(c), nil
+	}
+	return c.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for both styles.
+func (c *Coordinate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err == nil {
+		parsed, err := ParseCoordinate(text)
+		if err != nil {
+			return err
+		}
+		*c = parsed
+		return nil
+	}
+	var decoded yamlCoordinate
+	if err := unmarshal(&decoded); err != nil {
+		return err
+	}
+	result := Coordinate(decoded)
+	if err := result.Validate(); err != nil {
+		return err
+	}
+	*c = result
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Mohammad Shafiee and The DMS Authors
+//
+// Licensed under the GNU General Public License, Version 3.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.gnu.org/licenses/gpl-3.0.html
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Copyright notice.
+
+package dms
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// yamlValue returns an unmarshal function decoding the value returned by a
+// MarshalYAML method, as a YAML library would after a round trip.
+func yamlValue(v interface{}) func(interface{}) error {
+	return func(dst interface{}) error {
+		target := reflect.ValueOf(dst).Elem()
+		if !reflect.TypeOf(v).AssignableTo(target.Type()) {
+			return fmt.Errorf("cannot unmarshal %T into %T", v, dst)
+		}
+		target.Set(reflect.ValueOf(v))
+		return nil
+	}
+}
+
+func setYAMLStyle(t *testing.T, style YAMLStyle) {
+	saved := DefaultYAMLStyle
+	DefaultYAMLStyle = style
+	t.Cleanup(func() { DefaultYAMLStyle = saved })
+}
+
+func TestCoordinateYAMLRoundTrip(t *testing.T) {
+	c := coordinateFromDecimal(40.446195, -79.948862)
+	c.Latitude.RoundSeconds(2, RoundHalfAwayFromZero)
+	c.Longitude.RoundSeconds(2, RoundHalfAwayFromZero)
+	accurate := c
+	accurate.Accuracy = 5
+	for _, style := range []YAMLStyle{YAMLString, YAMLMapping} {
+		setYAMLStyle(t, style)
+		for _, want := range []Coordinate{c, accurate} {
+			v, err := want.MarshalYAML()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got Coordinate
+			if err := got.UnmarshalYAML(yamlValue(v)); err != nil {
+				t.Fatalf("style %d: %v", style, err)
+			}
+			if got != want {
+				t.Errorf("style %d: round trip of %+v gave %+v", style, want, got)
+			}
+		}
+	}
+}
+
+func TestCoordinateYAMLShape(t *testing.T) {
+	setYAMLStyle(t, YAMLString)
+	c := coordinateFromDecimal(40.5, -79.9)
+	if v, _ := c.MarshalYAML(); reflect.TypeOf(v).Kind() != reflect.String {
+		t.Errorf("YAMLString without accuracy wrote %T, want a string", v)
+	}
+	// The string form cannot hold the accuracy, so a mapping is written.
+	c.Accuracy = 5
+	if v, _ := c.MarshalYAML(); reflect.TypeOf(v) != reflect.TypeOf(yamlCoordinate{}) {
+		t.Errorf("YAMLString with accuracy wrote %T, want a mapping", v)
+	}
+}
+
+func TestDMSYAMLMalformed(t *testing.T) {
+	var d DMS
+	if err := d.UnmarshalYAML(yamlValue("95 N")); err == nil {
+		t.Error("UnmarshalYAML accepted 95 N")
+	}
+	if err := d.UnmarshalYAML(yamlValue(yamlDMS{Degree: 40, Minutes: 60, Direction: "N"})); err == nil {
+		t.Error("UnmarshalYAML accepted 60 minutes")
+	}
+	if err := d.UnmarshalYAML(yamlValue(42)); err == nil {
+		t.Error("UnmarshalYAML accepted a number")
+	}
+}